@@ -2,28 +2,22 @@ package ink
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"hash"
-	"log"
 	"math/big"
-	"sync"
-	"time"
 
-	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic/extensions"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/xxhash"
 	"golang.org/x/crypto/blake2b"
 
 	"github.com/wetee-dao/ink.go/pallet/revive"
-	"github.com/wetee-dao/ink.go/pallet/system"
 	gtypes "github.com/wetee-dao/ink.go/pallet/types"
 	"github.com/wetee-dao/ink.go/util"
 )
@@ -36,15 +30,28 @@ type ChainClient struct {
 	ErrorMap registry.ErrorRegistry
 	Hash     types.Hash
 	Debug    bool
-
-	currIndex int
-	mu        sync.Mutex
-	conns     []*gsrpc.SubstrateAPI
+	// 本地 nonce 管理
+	// local nonce manager of signers
+	Nonces *NonceManager
+	// 默认交易有效期，nil 时为永久有效
+	// default era of transactions, nil means immortal
+	DefaultEra *Era
+	// 默认小费
+	// default tip of transactions
+	DefaultTip types.U128
+
+	pool *connPool
 }
 
 // 初始化区块连链接
 // Init chain client
 func InitClient(urls []string, debug bool) (*ChainClient, error) {
+	return InitClientWithPool(urls, debug, DefaultPoolOptions())
+}
+
+// 初始化区块连链接，连接失败的 url 会在后台重连
+// Init chain client with options of connection pool, urls failed to connect are reconnected in background
+func InitClientWithPool(urls []string, debug bool, opts PoolOptions) (*ChainClient, error) {
 	if len(urls) == 0 {
 		urls = []string{config.Default().RPCURL}
 	}
@@ -52,67 +59,94 @@ func InitClient(urls []string, debug bool) (*ChainClient, error) {
 	var meta *types.Metadata
 	var runtime *types.RuntimeVersion
 	var errMap registry.ErrorRegistry
-	var genesisHash types.Hash
-
-	conns := make([]*gsrpc.SubstrateAPI, 0, len(urls))
-	for _, url := range urls {
-		api, err := gsrpc.NewSubstrateAPI(url)
-		if err != nil {
-			return nil, err
-		}
 
-		if len(conns) > 0 {
-			hash, err := api.RPC.Chain.GetBlockHash(0)
+	pool := newConnPool(urls, opts, debug)
+	var lastErr error
+	for _, conn := range pool.conns {
+		if meta != nil {
+			api, err := pool.dial(conn.url)
 			if err != nil {
-				return nil, err
-			}
-
-			if hash != genesisHash {
-				return nil, errors.New("url " + url + " genesis hash is not match")
+				if errors.Is(err, errGenesisMismatch) {
+					pool.close()
+					return nil, err
+				}
+				util.LogWithRed("connect "+conn.url, err.Error())
+				continue
 			}
-
-			conns = append(conns, api)
+			conn.api = api
 			continue
 		}
 
-		meta, err = api.RPC.State.GetMetadataLatest()
+		api, err := newSubstrateAPI(pool.opts.Transport, conn.url)
 		if err != nil {
-			return nil, err
+			util.LogWithRed("connect "+conn.url, err.Error())
+			lastErr = err
+			continue
 		}
-		gtypes.Meta = *meta
 
-		genesisHash, err = api.RPC.Chain.GetBlockHash(0)
-		if err != nil {
-			return nil, err
+		// 初始化失败的 url 与连接失败相同，由后台重连，继续尝试下一个 url
+		// url failed to init is reconnected in background like the ones failed to connect, the next url is tried
+		genesis, err := api.RPC.Chain.GetBlockHash(0)
+		var m *types.Metadata
+		if err == nil {
+			m, err = api.RPC.State.GetMetadataLatest()
 		}
-
-		errMap, err = InitErrors(meta)
-		if err != nil {
-			return nil, err
+		if err == nil {
+			errMap, err = InitErrors(m)
+		}
+		if err == nil {
+			runtime, err = api.RPC.State.GetRuntimeVersionLatest()
 		}
-
-		runtime, err = api.RPC.State.GetRuntimeVersionLatest()
 		if err != nil {
-			return nil, err
+			api.Client.Close()
+			util.LogWithRed("init "+conn.url, err.Error())
+			lastErr = err
+			continue
 		}
+		meta = m
+		pool.genesis = genesis
+		gtypes.Meta = *meta
 
-		conns = append(conns, api)
+		conn.api = api
 	}
 
-	return &ChainClient{
+	if meta == nil {
+		pool.close()
+		return nil, errors.New("connect blockchain: " + lastErr.Error())
+	}
+	pool.start()
+
+	client := &ChainClient{
 		Meta:     meta,
 		Runtime:  runtime,
 		ErrorMap: errMap,
-		Hash:     genesisHash,
+		Hash:     pool.genesis,
 		Debug:    debug,
-		conns:    conns,
-	}, nil
+		pool:     pool,
+	}
+	client.Nonces = NewNonceManager(client.accountNextIndexContext)
+	client.DefaultEra = MortalEra(DefaultMortalPeriod)
+	client.DefaultTip = types.NewU128(*big.NewInt(0))
+
+	return client, nil
+}
+
+// 是否打印调试日志
+// Whether debug logs are printed
+func (c *ChainClient) IsDebug() bool {
+	return c.Debug
 }
 
 // 检查 metadata 是否匹配
 // 不匹配就更新
 func (c *ChainClient) CheckMetadata() error {
-	runtime, err := c.Api().RPC.State.GetRuntimeVersionLatest()
+	return c.CheckMetadataContext(context.Background())
+}
+
+// 检查 metadata 是否匹配
+// Check whether metadata is matched, update it if not
+func (c *ChainClient) CheckMetadataContext(ctx context.Context) error {
+	runtime, err := c.getRuntimeVersionContext(ctx)
 	if err != nil {
 		return err
 	}
@@ -121,7 +155,7 @@ func (c *ChainClient) CheckMetadata() error {
 		return nil
 	}
 
-	meta, err := c.Api().RPC.State.GetMetadataLatest()
+	meta, err := c.getMetadataContext(ctx)
 	if err != nil {
 		return err
 	}
@@ -141,140 +175,102 @@ func (c *ChainClient) CheckMetadata() error {
 // 获取区块高度
 // Get block number
 func (c *ChainClient) GetBlockNumber() (types.BlockNumber, error) {
-	hash, err := c.Api().RPC.Chain.GetHeaderLatest()
+	return c.GetBlockNumberContext(context.Background())
+}
+
+// 获取区块高度
+// Get block number with context
+func (c *ChainClient) GetBlockNumberContext(ctx context.Context) (types.BlockNumber, error) {
+	header, err := c.getHeaderContext(ctx, nil)
 	if err != nil {
 		return 0, err
 	}
-	return hash.Number, nil
+	return header.Number, nil
 }
 
 // 获取账户信息
 // Get account info
 func (c *ChainClient) GetAccount(address SignerType) (*types.AccountInfo, error) {
-	key, err := types.CreateStorageKey(c.Meta, "System", "Account", address.Public())
+	return c.GetAccountContext(context.Background(), address)
+}
+
+// 获取账户信息
+// Get account info with context
+func (c *ChainClient) GetAccountContext(ctx context.Context, address SignerType) (*types.AccountInfo, error) {
+	account := address.AccountID()
+	key, err := types.CreateStorageKey(c.Meta, "System", "Account", account[:])
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 	var accountInfo types.AccountInfo
-	_, err = c.Api().RPC.State.GetStorageLatest(key, &accountInfo)
+	_, err = c.getStorageContext(ctx, key, &accountInfo, nil)
 	return &accountInfo, err
 }
 
 // 签名并提交交易
 // Sign and submit transaction
-func (c *ChainClient) SignAndSubmit(signer SignerType, call types.Call, untilFinalized bool, nonce uint64) error {
-	if nonce == 0 {
-		accountInfo, err := c.GetAccount(signer)
-		if err != nil {
-			return errors.New("GetAccountInfo error: " + err.Error())
-		}
-		nonce = uint64(accountInfo.Nonce)
-	}
-
-	ext := NewExtrinsic(call)
-	err := ext.Sign(signer, c.Meta, extrinsic.WithEra(types.ExtrinsicEra{IsImmortalEra: true}, c.Hash),
-		extrinsic.WithNonce(types.NewUCompactFromUInt(nonce)),
-		extrinsic.WithTip(types.NewUCompactFromUInt(0)),
-		extrinsic.WithSpecVersion(c.Runtime.SpecVersion),
-		extrinsic.WithTransactionVersion(c.Runtime.TransactionVersion),
-		extrinsic.WithGenesisHash(c.Hash),
-		extrinsic.WithMetadataMode(extensions.CheckMetadataModeDisabled, extensions.CheckMetadataHash{Hash: types.NewEmptyOption[types.H256]()}),
-		extrinsic.WithAssetID(types.NewEmptyOption[types.AssetID]()),
-	)
-	if err != nil {
-		return err
-	}
-
-	sub, err := c.Api().RPC.Author.SubmitAndWatchExtrinsic(ext.Extrinsic)
-	if err != nil {
-		return errors.New("Author.SubmitAndWatchExtrinsic error: " + err.Error())
-	}
+func (c *ChainClient) SignAndSubmit(signer SignerType, call types.Call, untilFinalized bool, nonce uint64) (*TxReceipt, error) {
+	return c.SignAndSubmitContext(context.Background(), signer, call, untilFinalized, nonce)
+}
 
-	defer sub.Unsubscribe()
-	timeout := time.After(120 * time.Second)
+// 签名并提交交易，ctx 取消或超时时停止等待
+// Sign and submit transaction, stop waiting when ctx is canceled or deadline exceeded
+func (c *ChainClient) SignAndSubmitContext(ctx context.Context, signer SignerType, call types.Call, untilFinalized bool, nonce uint64) (*TxReceipt, error) {
+	return c.SignAndSubmitWithOptions(ctx, signer, call, SubmitOptions{
+		UntilFinalized: untilFinalized,
+		Nonce:          nonce,
+	})
+}
 
-	extBytes, err := codec.Encode(ext.Extrinsic)
+// 检查交易是否成功
+// 交易不在区块中时返回 nil
+// Check whether the transaction is successful, return nil receipt if the extrinsic is not in block
+func (c *ChainClient) checkExtrinsic(ctx context.Context, extHash types.Hash, blockHash types.Hash) (*TxReceipt, error) {
+	block, err := c.getBlockContext(ctx, &blockHash)
 	if err != nil {
-		return errors.New("Codec.Encode error: " + err.Error())
+		return nil, err
 	}
-	hash := blake2b.Sum256(extBytes)
 
-	for {
-		select {
-		case status := <-sub.Chan():
-			if status.IsInBlock {
-				_, success, err := c.checkExtrinsic(hash, status.AsInBlock)
-				if err != nil {
-					return err
-				}
-
-				if success && c.Debug {
-					util.LogWithGreen("[Extrinsic]", "InBlock")
-				}
-
-				if success && !untilFinalized {
-					return nil
-				}
-			} else if status.IsFinalized {
-				_, success, err := c.checkExtrinsic(hash, status.AsFinalized)
-				if err != nil {
-					return err
-				}
-				if success {
-					if c.Debug {
-						util.LogWithGreen("[Extrinsic]", "Finalized")
-						fmt.Println()
-					}
-					return nil
-				}
-			} else if status.IsDropped {
-				util.LogWithRed("SubmitAndWatchExtrinsic Dropped")
-			} else if status.IsUsurped {
-				util.LogWithRed("SubmitAndWatchExtrinsic Usurped")
-			}
-		case err := <-sub.Err():
-			if c.Debug {
-				util.LogWithRed("SubmitAndWatchExtrinsic ERROR", err.Error())
-			}
-
-			return err
-		case <-timeout:
-			util.LogWithRed("SubmitAndWatchExtrinsic ERROR: timeout")
-			return nil
+	// 查找交易在区块中的位置
+	extrinsicIndex := -1
+	for i, ext := range block.Block.Extrinsics {
+		extBytes, err := hex.DecodeString(ext[2:])
+		if err != nil {
+			return nil, err
+		}
+		if blake2b.Sum256(extBytes) == extHash {
+			extrinsicIndex = i
+			break
 		}
 	}
-}
-
-// 检查交易是否成功
-// Check whether the transaction is successful
-func (c *ChainClient) checkExtrinsic(extHash types.Hash, blockHash types.Hash) ([]gtypes.EventRecord, bool, error) {
-	block, err := c.Api().RPC.Chain.GetBlock(blockHash)
-	if err != nil {
-		return nil, false, err
+	if extrinsicIndex < 0 {
+		return nil, nil
 	}
 
-	events, err := system.GetEvents(c.Api().RPC.State, blockHash)
+	events, err := c.getEventsContext(ctx, blockHash)
 	if err != nil {
-		return nil, false, err
+		return nil, err
 	}
 
-	cevents := make([]gtypes.EventRecord, 0, len(events))
+	receipt := newTxReceipt(extHash, blockHash, block.Block.Header.Number, uint32(extrinsicIndex))
+	var errInfo error
 	for _, e := range events {
-		extrinsicIndex := e.Phase.AsApplyExtrinsicField0
-		ext := block.Block.Extrinsics[extrinsicIndex]
-		extBytes, err := hex.DecodeString(ext[2:])
-		if err != nil {
-			return nil, false, err
+		// 只保留当前交易的 event
+		if !e.Phase.IsApplyExtrinsic || e.Phase.AsApplyExtrinsicField0 != receipt.ExtrinsicIndex {
+			continue
 		}
-		eventExtHash := blake2b.Sum256(extBytes)
-
-		// 添加相关的event
-		if eventExtHash != extHash {
-			cevents = append(cevents, e)
+		receipt.Events = append(receipt.Events, e)
+
+		// 交易手续费
+		if e.Event.IsTransactionPayment && e.Event.AsTransactionPaymentField0 != nil {
+			feePaid := e.Event.AsTransactionPaymentField0
+			if feePaid.IsTransactionFeePaid {
+				receipt.Fee = feePaid.AsTransactionFeePaidActualFee1
+				receipt.Tip = feePaid.AsTransactionFeePaidTip2
+			}
 		}
 
-		// 判断是否是当前交易的消息
-		if eventExtHash != extHash || !e.Event.IsSystem {
+		if !e.Event.IsSystem || e.Event.AsSystemField0 == nil {
 			continue
 		}
 
@@ -283,53 +279,42 @@ func (c *ChainClient) checkExtrinsic(extHash types.Hash, blockHash types.Hash) (
 			if c.Debug {
 				util.LogWithPurple("Extrinsic", "ExtrinsicSuccess")
 			}
-			return cevents, true, nil
+			receipt.Success = true
+			receipt.Weight = e.Event.AsSystemField0.AsExtrinsicSuccessDispatchInfo0.Weight
 		}
 
 		// 获取交易失败的消息
 		if e.Event.AsSystemField0.IsExtrinsicFailed {
 			errData := e.Event.AsSystemField0.AsExtrinsicFailedDispatchError0
+			receipt.Weight = e.Event.AsSystemField0.AsExtrinsicFailedDispatchInfo1.Weight
 			if c.Debug {
 				util.LogWithPurple("Extrinsic", "ExtrinsicFailed")
 			}
 
-			var errInfo error
-			// 判断是否是区块链模块错误
-			if errData.IsModule {
-				merr := errData.AsModuleField0
-				info, ierr := c.GetErrorInfo(merr.Index, merr.Error)
-				if ierr == nil {
-					errInfo = errors.New("tx: module error " + info.Name)
-				} else {
-					errInfo = errors.New("tx: unknown module error ")
-				}
-			} else {
-				b, err := errData.MarshalJSON()
-				if err != nil {
-					fmt.Println(err)
-					return nil, false, err
-				}
-				errInfo = errors.New(string(b))
-			}
-
-			return nil, false, errInfo
+			errInfo = fmt.Errorf("tx: %w", c.WrapDispatchError(errData))
 		}
 	}
 
-	return nil, false, nil
+	return receipt, errInfo
 }
 
 // 查询 map 所有数据
 // query map data list of map
 func (c *ChainClient) QueryMapAll(pallet string, method string) ([]types.StorageChangeSet, error) {
+	return c.QueryMapAllContext(context.Background(), pallet, method)
+}
+
+// 查询 map 所有数据
+// query map data list of map with context
+func (c *ChainClient) QueryMapAllContext(ctx context.Context, pallet string, method string) ([]types.StorageChangeSet, error) {
 	key := CreatePrefixedKey(pallet, method)
 
-	keys, err := c.Api().RPC.State.GetKeysLatest(key)
+	keys, err := c.getKeysContext(ctx, key, nil)
 	if err != nil {
 		return []types.StorageChangeSet{}, err
 	}
 
-	set, err := c.Api().RPC.State.QueryStorageAtLatest(keys)
+	set, err := c.queryStorageAtContext(ctx, keys, nil)
 	if err != nil {
 		return []types.StorageChangeSet{}, err
 	}
@@ -340,6 +325,12 @@ func (c *ChainClient) QueryMapAll(pallet string, method string) ([]types.Storage
 // 查询 map 所有数据
 // query map data list of map4
 func (c *ChainClient) QueryMapKeys(pallet string, method string, fkeys []any) ([]types.StorageChangeSet, error) {
+	return c.QueryMapKeysContext(context.Background(), pallet, method, fkeys)
+}
+
+// 查询 map 所有数据
+// query map data of keys with context
+func (c *ChainClient) QueryMapKeysContext(ctx context.Context, pallet string, method string, fkeys []any) ([]types.StorageChangeSet, error) {
 	key := CreatePrefixedKey(pallet, method)
 
 	hashers, err := c.GetHashers(pallet, method)
@@ -349,18 +340,19 @@ func (c *ChainClient) QueryMapKeys(pallet string, method string, fkeys []any) ([
 
 	keys := make([]types.StorageKey, 0, len(fkeys))
 	for i, sk := range fkeys {
-		arg2, err := codec.Encode(sk)
+		arg, err := codec.Encode(sk)
 		if err != nil {
 			return nil, err
 		}
-		_, err = hashers[0].Write(arg2)
+		hashers[0].Reset()
+		_, err = hashers[0].Write(arg)
 		if err != nil {
-			return nil, fmt.Errorf("unable to hash args[%d]: %s Error: %v", 1, arg2, err)
+			return nil, fmt.Errorf("unable to hash args[%d]: %s Error: %v", i, arg, err)
 		}
-		keys[i] = types.StorageKey(append(key, hashers[1].Sum(nil)...))
+		keys = append(keys, types.StorageKey(append(append([]byte{}, key...), hashers[0].Sum(nil)...)))
 	}
 
-	set, err := c.Api().RPC.State.QueryStorageAtLatest(keys)
+	set, err := c.queryStorageAtContext(ctx, keys, nil)
 	if err != nil {
 		return []types.StorageChangeSet{}, err
 	}
@@ -371,30 +363,25 @@ func (c *ChainClient) QueryMapKeys(pallet string, method string, fkeys []any) ([
 // 查询 double map 第一个 key 的所有数据
 // query double map data list of double map
 func (c *ChainClient) QueryDoubleMapAll(pallet string, method string, keyarg any, at *types.Hash) ([]types.StorageChangeSet, error) {
+	return c.QueryDoubleMapAllContext(context.Background(), pallet, method, keyarg, at)
+}
+
+// 查询 double map 第一个 key 的所有数据
+// query double map data list of double map with context
+func (c *ChainClient) QueryDoubleMapAllContext(ctx context.Context, pallet string, method string, keyarg any, at *types.Hash) ([]types.StorageChangeSet, error) {
 	key, err := c.GetDoubleMapPrefixKey(pallet, method, keyarg)
 	if err != nil {
 		return nil, err
 	}
 
 	// query key
-	var keys []types.StorageKey
-	if at == nil {
-		keys, err = c.Api().RPC.State.GetKeysLatest(key)
-	} else {
-		keys, err = c.Api().RPC.State.GetKeys(key, *at)
-	}
-
+	keys, err := c.getKeysContext(ctx, key, at)
 	if err != nil {
 		return nil, err
 	}
 
 	// get all data
-	var set []types.StorageChangeSet
-	if at == nil {
-		set, err = c.Api().RPC.State.QueryStorageAtLatest(keys)
-	} else {
-		set, err = c.Api().RPC.State.QueryStorageAt(keys, *at)
-	}
+	set, err := c.queryStorageAtContext(ctx, keys, at)
 	if err != nil {
 		return nil, err
 	}
@@ -432,18 +419,19 @@ func (c *ChainClient) GetDoubleMapPrefixKey(pallet string, method string, keyarg
 // 查询 double map 第一个 key 的所有数据
 // query double map data list of double map
 func (c *ChainClient) QueryDoubleMapKeys(pallet string, method string, keyarg any, skeys []any, at *types.Hash) ([]types.StorageChangeSet, error) {
+	return c.QueryDoubleMapKeysContext(context.Background(), pallet, method, keyarg, skeys, at)
+}
+
+// 查询 double map 第一个 key 的所有数据
+// query double map data list of double map with context
+func (c *ChainClient) QueryDoubleMapKeysContext(ctx context.Context, pallet string, method string, keyarg any, skeys []any, at *types.Hash) ([]types.StorageChangeSet, error) {
 	keys, err := c.GetDoubleMapPrefixKeys(pallet, method, keyarg, skeys)
 	if err != nil {
 		return nil, err
 	}
 
 	// get all data
-	var set []types.StorageChangeSet
-	if at == nil {
-		set, err = c.Api().RPC.State.QueryStorageAtLatest(keys)
-	} else {
-		set, err = c.Api().RPC.State.QueryStorageAt(keys, *at)
-	}
+	set, err := c.queryStorageAtContext(ctx, keys, at)
 	if err != nil {
 		return nil, err
 	}
@@ -516,6 +504,11 @@ func (c *ChainClient) GetHashers(pallet, method string) ([]hash.Hash, error) {
 
 // Call runtime api
 func (c *ChainClient) CallRuntimeApi(pallet, method string, args []any, result any) error {
+	return c.CallRuntimeApiContext(context.Background(), pallet, method, args, result)
+}
+
+// Call runtime api with context
+func (c *ChainClient) CallRuntimeApiContext(ctx context.Context, pallet, method string, args []any, result any) error {
 	var buffer bytes.Buffer
 	var err error
 	encoder := scale.NewEncoder(&buffer)
@@ -524,14 +517,13 @@ func (c *ChainClient) CallRuntimeApi(pallet, method string, args []any, result a
 	for _, arg := range args {
 		err = encoder.Encode(arg)
 		if err != nil {
-			log.Fatal(err)
-			return err
+			return errors.New("CallRuntimeApi encode args: " + err.Error())
 		}
 	}
 
 	// Call runtime api
 	var rawResult string
-	err = c.Api().Client.Call(&rawResult, "state_call", pallet+"_"+method, "0x"+hex.EncodeToString(buffer.Bytes()))
+	err = c.callContext(ctx, &rawResult, "state_call", nil, pallet+"_"+method, "0x"+hex.EncodeToString(buffer.Bytes()))
 	if err != nil {
 		return err
 	}
@@ -542,10 +534,9 @@ func (c *ChainClient) CallRuntimeApi(pallet, method string, args []any, result a
 	}
 
 	// Decode the raw result from hex to bytes
-	rawResult = rawResult[2:]
-	resultBytes, err := hex.DecodeString(rawResult)
+	resultBytes, err := codec.HexDecodeString(rawResult)
 	if err != nil {
-		log.Fatalf("CallRuntimeApi Failed to decode result: %v", err)
+		return errors.New("CallRuntimeApi failed to decode result: " + err.Error())
 	}
 
 	// Decode the result using scale.Decoder
@@ -554,12 +545,17 @@ func (c *ChainClient) CallRuntimeApi(pallet, method string, args []any, result a
 
 // Get balance of h160
 func (c *ChainClient) BalanceOfH160(address string) (types.U128, error) {
+	return c.BalanceOfH160Context(context.Background(), address)
+}
+
+// Get balance of h160 with context
+func (c *ChainClient) BalanceOfH160Context(ctx context.Context, address string) (types.U128, error) {
 	balance := types.NewU128(*big.NewInt(0))
 	bt, err := util.HexToH160(address)
 	if err != nil {
 		return types.U128{}, err
 	}
-	err = c.CallRuntimeApi("ReviveApi", "balance", []any{bt}, &balance)
+	err = c.CallRuntimeApiContext(ctx, "ReviveApi", "balance", []any{bt}, &balance)
 	if err != nil {
 		return types.U128{}, err
 	}
@@ -567,15 +563,20 @@ func (c *ChainClient) BalanceOfH160(address string) (types.U128, error) {
 	return balance, nil
 }
 
-func (c *ChainClient) MapReviveAccount(signer SignerType) error {
+func (c *ChainClient) MapReviveAccount(signer SignerType, opts SubmitOptions) (*TxReceipt, error) {
+	return c.MapReviveAccountContext(context.Background(), signer, opts)
+}
+
+// Map account to revive h160 address with context
+func (c *ChainClient) MapReviveAccountContext(ctx context.Context, signer SignerType, opts SubmitOptions) (*TxReceipt, error) {
 	runtimeCall := revive.MakeMapAccountCall()
 
 	call, err := (runtimeCall).AsCall()
 	if err != nil {
-		return errors.New("(runtimeCall).AsCall() error: " + err.Error())
+		return nil, errors.New("(runtimeCall).AsCall() error: " + err.Error())
 	}
 
-	return c.SignAndSubmit(signer, call, true, 0)
+	return c.SignAndSubmitWithOptions(ctx, signer, call, opts)
 }
 
 // Get block gas limit
@@ -587,7 +588,7 @@ func (c *ChainClient) InkBlockGasLimit(address [32]byte) error {
 
 // Close chain client
 func (c *ChainClient) Close() {
-	c.Api().Client.Close()
+	c.pool.close()
 }
 
 // Utility.batch 如果批量中的某个调用失败（返回错误），整个批量调用立即停止，后续调用永远不会执行
@@ -0,0 +1,331 @@
+package ink
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	gtypes "github.com/wetee-dao/ink.go/pallet/types"
+	"github.com/wetee-dao/ink.go/util"
+	"golang.org/x/crypto/blake2b"
+)
+
+// 区块及其事件
+// Block with its events
+type BlockEvents struct {
+	Number uint64
+	Hash   types.Hash
+	Header types.Header
+	Events []gtypes.EventRecord
+}
+
+// 订阅新区块头，连接断开后自动重新订阅
+// Subscribe new heads, it is resubscribed automatically when the connection is lost
+func (c *ChainClient) SubscribeNewHeads() (*EventSubscription[types.Header], error) {
+	return c.SubscribeNewHeadsContext(context.Background())
+}
+
+// 订阅新区块头，ctx 取消时结束订阅
+// Subscribe new heads, the subscription ends when ctx is done
+func (c *ChainClient) SubscribeNewHeadsContext(ctx context.Context) (*EventSubscription[types.Header], error) {
+	return c.subscribeHeads(ctx, false)
+}
+
+// 订阅最终确认区块头，按区块号连续返回，跳过的区块会被补齐
+// Subscribe finalized heads, heads are returned one by one in order of number, skipped blocks are filled in
+func (c *ChainClient) SubscribeFinalizedHeads() (*EventSubscription[types.Header], error) {
+	return c.SubscribeFinalizedHeadsContext(context.Background())
+}
+
+// 订阅最终确认区块头，ctx 取消时结束订阅
+// Subscribe finalized heads, the subscription ends when ctx is done
+func (c *ChainClient) SubscribeFinalizedHeadsContext(ctx context.Context) (*EventSubscription[types.Header], error) {
+	return c.subscribeHeads(ctx, true)
+}
+
+// 订阅每个最终确认区块的事件
+// Subscribe events of every finalized block
+func (c *ChainClient) SubscribeFinalizedEvents() (*EventSubscription[BlockEvents], error) {
+	return c.SubscribeFinalizedEventsContext(context.Background())
+}
+
+// 订阅每个最终确认区块的事件，ctx 取消时结束订阅
+// Subscribe events of every finalized block, the subscription ends when ctx is done
+func (c *ChainClient) SubscribeFinalizedEventsContext(ctx context.Context) (*EventSubscription[BlockEvents], error) {
+	heads, err := c.SubscribeFinalizedHeadsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	esub := newEventSubscription[BlockEvents]()
+	go func() {
+		defer heads.Unsubscribe()
+		defer close(esub.channel)
+
+		for {
+			select {
+			case header, ok := <-heads.Chan():
+				if !ok {
+					select {
+					case err := <-heads.Err():
+						esub.errs <- err
+					default:
+					}
+					return
+				}
+
+				block, ok := c.retryBlockEvents(ctx, esub, header)
+				if !ok {
+					return
+				}
+				if !esub.send(*block) {
+					return
+				}
+			case <-esub.quit:
+				return
+			}
+		}
+	}()
+
+	return esub, nil
+}
+
+// 获取区块的事件，失败时按连接池的退避时间重试，订阅结束时返回 false
+// Get events of block, it is retried with backoff of the pool on failure, false is returned when the subscription ends
+func (c *ChainClient) retryBlockEvents(ctx context.Context, esub *EventSubscription[BlockEvents], header types.Header) (*BlockEvents, bool) {
+	backoff := c.pool.opts.MinBackoff
+	for {
+		block, err := c.blockEvents(ctx, header)
+		if err == nil {
+			return block, true
+		}
+		if c.Debug {
+			util.LogWithRed("SubscribeFinalizedEvents", err.Error(), "retry")
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			esub.errs <- ctx.Err()
+			return nil, false
+		case <-esub.quit:
+			return nil, false
+		}
+		backoff = min(backoff*2, c.pool.opts.MaxBackoff)
+	}
+}
+
+// 获取区块及其事件
+// Get block of number with its events
+func (c *ChainClient) GetBlockEvents(number uint64) (*BlockEvents, error) {
+	return c.GetBlockEventsContext(context.Background(), number)
+}
+
+// 获取区块及其事件
+// Get block of number with its events with context
+func (c *ChainClient) GetBlockEventsContext(ctx context.Context, number uint64) (*BlockEvents, error) {
+	hash, err := c.getBlockHashContext(ctx, number)
+	if err != nil {
+		return nil, errors.New("Chain.GetBlockHash error: " + err.Error())
+	}
+	header, err := c.getHeaderContext(ctx, &hash)
+	if err != nil {
+		return nil, errors.New("Chain.GetHeader error: " + err.Error())
+	}
+
+	return c.blockEvents(ctx, *header)
+}
+
+// 获取区块哈希
+// Get hash of block number
+func (c *ChainClient) GetBlockHash(number uint64) (types.Hash, error) {
+	return c.GetBlockHashContext(context.Background(), number)
+}
+
+// 获取区块哈希
+// Get hash of block number with context
+func (c *ChainClient) GetBlockHashContext(ctx context.Context, number uint64) (types.Hash, error) {
+	hash, err := c.getBlockHashContext(ctx, number)
+	if err != nil {
+		return types.Hash{}, errors.New("Chain.GetBlockHash error: " + err.Error())
+	}
+	return hash, nil
+}
+
+// 获取最新的最终确认区块头
+// Get header of the latest finalized block
+func (c *ChainClient) GetFinalizedHeader() (*types.Header, error) {
+	return c.GetFinalizedHeaderContext(context.Background())
+}
+
+// 获取最新的最终确认区块头
+// Get header of the latest finalized block with context
+func (c *ChainClient) GetFinalizedHeaderContext(ctx context.Context) (*types.Header, error) {
+	hash, err := c.getFinalizedHeadContext(ctx)
+	if err != nil {
+		return nil, errors.New("Chain.GetFinalizedHead error: " + err.Error())
+	}
+	header, err := c.getHeaderContext(ctx, &hash)
+	if err != nil {
+		return nil, errors.New("Chain.GetHeader error: " + err.Error())
+	}
+	return header, nil
+}
+
+// 获取区块的事件
+// Get events of block
+func (c *ChainClient) blockEvents(ctx context.Context, header types.Header) (*BlockEvents, error) {
+	hash, err := headerHash(header)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := c.getEventsContext(ctx, hash)
+	if err != nil {
+		return nil, errors.New("System.Events error: " + err.Error())
+	}
+
+	return &BlockEvents{
+		Number: uint64(header.Number),
+		Hash:   hash,
+		Header: header,
+		Events: events,
+	}, nil
+}
+
+func (c *ChainClient) subscribeHeadsOnce(ctx context.Context, finalized bool) (*gethrpc.ClientSubscription, chan types.Header, error) {
+	ch := make(chan types.Header)
+
+	var sub *gethrpc.ClientSubscription
+	var err error
+	if finalized {
+		sub, err = c.subscribeContext(ctx, "chain", "subscribeFinalizedHeads", "unsubscribeFinalizedHeads", "finalizedHead", ch)
+	} else {
+		sub, err = c.subscribeContext(ctx, "chain", "subscribeNewHeads", "unsubscribeNewHeads", "newHead", ch)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sub, ch, nil
+}
+
+func (c *ChainClient) subscribeHeads(ctx context.Context, finalized bool) (*EventSubscription[types.Header], error) {
+	sub, ch, err := c.subscribeHeadsOnce(ctx, finalized)
+	if err != nil {
+		return nil, errors.New("Chain.SubscribeHeads error: " + err.Error())
+	}
+
+	hsub := newEventSubscription[types.Header]()
+	go func() {
+		defer close(hsub.channel)
+
+		var next uint64
+		for {
+			err := c.forwardHeads(ctx, hsub, sub, ch, finalized, &next)
+			sub.Unsubscribe()
+			if err == nil {
+				return
+			}
+			if c.Debug {
+				util.LogWithRed("SubscribeHeads", err.Error(), "resubscribe")
+			}
+
+			// 连接断开，等待连接池重连后重新订阅
+			// connection lost, resubscribe after the pool reconnects
+			backoff := c.pool.opts.MinBackoff
+			for {
+				select {
+				case <-time.After(backoff):
+				case <-ctx.Done():
+					hsub.errs <- ctx.Err()
+					return
+				case <-hsub.quit:
+					return
+				}
+
+				sub, ch, err = c.subscribeHeadsOnce(ctx, finalized)
+				if err == nil {
+					break
+				}
+				backoff = min(backoff*2, c.pool.opts.MaxBackoff)
+			}
+		}
+	}()
+
+	return hsub, nil
+}
+
+// 转发区块头直到订阅出错，订阅正常结束时返回 nil
+// Forward heads until the subscription fails, nil is returned when the subscription ends normally
+func (c *ChainClient) forwardHeads(
+	ctx context.Context,
+	hsub *EventSubscription[types.Header],
+	sub *gethrpc.ClientSubscription,
+	ch chan types.Header,
+	finalized bool,
+	next *uint64,
+) error {
+	for {
+		select {
+		case header := <-ch:
+			number := uint64(header.Number)
+			if !finalized {
+				if !hsub.send(header) {
+					return nil
+				}
+				continue
+			}
+
+			// 重新订阅后可能收到重复的区块
+			// heads may be repeated after resubscribing
+			if *next != 0 && number < *next {
+				continue
+			}
+			// 补齐跳过的区块
+			// fill in skipped blocks
+			for *next != 0 && *next < number {
+				hash, err := c.getBlockHashContext(ctx, *next)
+				if err != nil {
+					return err
+				}
+				skipped, err := c.getHeaderContext(ctx, &hash)
+				if err != nil {
+					return err
+				}
+				if !hsub.send(*skipped) {
+					return nil
+				}
+				*next++
+			}
+
+			if !hsub.send(header) {
+				return nil
+			}
+			*next = number + 1
+		case err := <-sub.Err():
+			if err == nil {
+				err = ErrNoConnection
+			}
+			return err
+		case <-ctx.Done():
+			hsub.errs <- ctx.Err()
+			return nil
+		case <-hsub.quit:
+			return nil
+		}
+	}
+}
+
+// 区块头哈希
+// Hash of block header
+func headerHash(header types.Header) (types.Hash, error) {
+	bt, err := codec.Encode(header)
+	if err != nil {
+		return types.Hash{}, errors.New("Codec.Encode error: " + err.Error())
+	}
+	return blake2b.Sum256(bt), nil
+}
@@ -0,0 +1,146 @@
+package ink
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/wetee-dao/ink.go/util"
+)
+
+// 读取合约原始存储，key 不存在时返回 nil
+// Get raw storage of contract, nil is returned when the key does not exist
+func (c *ChainClient) GetContractStorage(address types.H160, key []byte) ([]byte, error) {
+	return c.GetContractStorageContext(context.Background(), address, key)
+}
+
+// 读取合约原始存储，32 字节的 key 使用 ReviveApi_get_storage，其他长度（如 ink! 的 key）使用 ReviveApi_get_storage_var_key
+// Get raw storage of contract with context, 32 bytes keys are read by ReviveApi_get_storage,
+// keys of other length (such as ink! keys) are read by ReviveApi_get_storage_var_key
+func (c *ChainClient) GetContractStorageContext(ctx context.Context, address types.H160, key []byte) ([]byte, error) {
+	result := util.GetStorageResult{}
+
+	var err error
+	if len(key) == 32 {
+		err = c.CallRuntimeApiContext(ctx, "ReviveApi", "get_storage", []any{address, [32]byte(key)}, &result)
+	} else {
+		err = c.CallRuntimeApiContext(ctx, "ReviveApi", "get_storage_var_key", []any{address, key}, &result)
+	}
+	if err != nil {
+		return nil, errors.New("ReviveApi get_storage: " + err.Error())
+	}
+
+	if result.IsErr {
+		return nil, result.E
+	}
+	if result.V.IsNone() {
+		return nil, nil
+	}
+
+	if c.Debug {
+		util.LogWithPurple("[ Contract storage ]", address.Hex(), "0x"+hex.EncodeToString(key))
+	}
+
+	return result.V.V, nil
+}
+
+// 按 ABI 存储布局读取合约存储并解码为 T，mapKey 为 Mapping 的键，其他存储项为 nil
+// Read contract storage by the storage layout of ABI and decode it to T,
+// mapKey is the key of Mapping and nil for other entries
+func QueryInkStorage[T any](contractIns Ink, abi *util.InkAbi, path string, mapKey any) (*T, bool, error) {
+	return QueryInkStorageContext[T](context.Background(), contractIns, abi, path, mapKey)
+}
+
+// 按 ABI 存储布局读取合约存储并解码为 T，存储不存在时返回 false
+// Read contract storage by the storage layout of ABI with context, false is returned when the storage does not exist
+func QueryInkStorageContext[T any](ctx context.Context, contractIns Ink, abi *util.InkAbi, path string, mapKey any) (*T, bool, error) {
+	var key []byte
+	if mapKey != nil {
+		var err error
+		key, err = codec.Encode(mapKey)
+		if err != nil {
+			return nil, false, errors.New("encode storage key: " + err.Error())
+		}
+	}
+
+	_, value, err := readInkStorage(ctx, contractIns, abi, path, key)
+	if err != nil || value == nil {
+		return nil, false, err
+	}
+
+	data := new(T)
+	err = scale.NewDecoder(bytes.NewReader(value)).Decode(data)
+	if err != nil {
+		return nil, false, errors.New("storage " + path + " scale.NewDecoder.Decode: " + err.Error())
+	}
+
+	return data, true, nil
+}
+
+// 按 ABI 存储布局读取合约存储，返回 JSON 格式的值，mapKey 按 Mapping 键的类型编码
+// Read contract storage by the storage layout of ABI and return its value as JSON,
+// mapKey is encoded with the key type of Mapping
+func (c *DynamicContract) Storage(path string, mapKey any) (json.RawMessage, bool, error) {
+	return c.StorageContext(context.Background(), path, mapKey)
+}
+
+// 按 ABI 存储布局读取合约存储，返回 JSON 格式的值
+// Read contract storage by the storage layout of ABI with context and return its value as JSON
+func (c *DynamicContract) StorageContext(ctx context.Context, path string, mapKey any) (json.RawMessage, bool, error) {
+	var key []byte
+	if mapKey != nil {
+		entry, err := c.Abi.StorageEntry(path)
+		if err != nil {
+			return nil, false, err
+		}
+		if entry.Kind != util.StorageMapping {
+			return nil, false, errors.New("storage " + path + " is " + entry.Kind.String() + ", it has no key")
+		}
+		key, err = c.Abi.EncodeValue(entry.KeyType, mapKey)
+		if err != nil {
+			return nil, false, errors.New("encode storage key: " + err.Error())
+		}
+	}
+
+	entry, value, err := readInkStorage(ctx, c, c.Abi, path, key)
+	if err != nil || value == nil {
+		return nil, false, err
+	}
+
+	v, err := c.Abi.DecodeValueJSON(entry.Type, value)
+	if err != nil {
+		return nil, false, errors.New("storage " + path + " decode: " + err.Error())
+	}
+
+	return v, true, nil
+}
+
+// 计算存储 key，读取存储单元并取出存储项的值
+// Compute the key, read the storage cell and take the value of entry from it
+func readInkStorage(ctx context.Context, contractIns Ink, abi *util.InkAbi, path string, mapKey []byte) (*util.StorageEntry, []byte, error) {
+	entry, err := abi.StorageEntry(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := entry.Key(mapKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cell, err := contractIns.Client().GetContractStorageContext(ctx, contractIns.ContractAddress(), key)
+	if err != nil || cell == nil {
+		return entry, nil, err
+	}
+
+	value, err := abi.StorageValue(entry, cell)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return entry, value, nil
+}
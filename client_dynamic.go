@@ -0,0 +1,198 @@
+package ink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/wetee-dao/ink.go/util"
+)
+
+// 无需生成代码的动态合约，参数和返回值通过 ABI 类型注册表编解码
+// Contract driven by ABI at runtime without code generation,
+// args and return values are encoded and decoded with the type registry of ABI
+type DynamicContract struct {
+	ChainClient InkClient
+	Address     types.H160
+	Abi         *util.InkAbi
+}
+
+func InitDynamicContract(client InkClient, abi *util.InkAbi, address string) (*DynamicContract, error) {
+	contractAddress, err := util.HexToH160(address)
+	if err != nil {
+		return nil, err
+	}
+	return &DynamicContract{
+		ChainClient: client,
+		Address:     contractAddress,
+		Abi:         abi,
+	}, nil
+}
+
+func (c *DynamicContract) Client() InkClient {
+	return c.ChainClient
+}
+
+func (c *DynamicContract) ContractAddress() types.H160 {
+	return c.Address
+}
+
+// 编码合约方法调用，args 为按参数名的 map、按顺序的数组或 JSON
+// Encode input of message, args is a map by label, a positional slice or JSON of them
+func (c *DynamicContract) Input(label string, args any) (*util.Message, util.InkContractInput, error) {
+	msg, err := c.Abi.Message(label)
+	if err != nil {
+		return nil, util.InkContractInput{}, err
+	}
+
+	encoded, err := c.Abi.EncodeArgs(msg, args)
+	if err != nil {
+		return nil, util.InkContractInput{}, err
+	}
+
+	return msg, util.InkContractInput{Selector: msg.Selector, Args: encoded}, nil
+}
+
+// 预执行合约方法，返回 JSON 格式的返回值
+// Dry run message and return its value as JSON
+func (c *DynamicContract) DryRun(label string, args any, params DryRunParams) (json.RawMessage, *DryRunReturnGas, error) {
+	return c.DryRunContext(context.Background(), label, args, params)
+}
+
+// 预执行合约方法，返回 JSON 格式的返回值
+// 合约回滚时返回回滚数据和 *ContractRevertError
+// Dry run message with context and return its value as JSON,
+// the decoded revert data and *ContractRevertError are returned when contract reverts
+func (c *DynamicContract) DryRunContext(ctx context.Context, label string, args any, params DryRunParams) (json.RawMessage, *DryRunReturnGas, error) {
+	msg, input, err := c.Input(label, args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", label)
+	}
+
+	raw, gas, err := DryRunInkContext[util.RawBytes](
+		ctx,
+		c,
+		params.Origin,
+		params.PayAmount,
+		params.GasLimit,
+		params.StorageDepositLimit,
+		input,
+	)
+	if err != nil {
+		// 回滚数据按 ABI 解码，LangError 已由 DryRunInk 解码
+		// decode revert data with ABI, LangError is already decoded by DryRunInk
+		rerr := &ContractRevertError{}
+		if !errors.As(err, &rerr) || rerr.Decoded != nil {
+			return nil, nil, err
+		}
+		value, derr := c.decodeReturn(msg, rerr.Data)
+		if derr != nil {
+			return nil, nil, err
+		}
+		return value, nil, WithRevertReason(err, value)
+	}
+
+	value, err := c.decodeReturn(msg, *raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if msg.Mutates && params.EstimateFee {
+		gas.Fee, err = EstimateInkFee(ctx, c, params.Origin, params.KeyType, params.PayAmount, gas, input)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return value, gas, nil
+}
+
+// 执行合约方法，先预执行获取 gas
+// Call message, it is dry run first to get the gas
+func (c *DynamicContract) Exec(label string, args any, params ExecParams) (*TxReceipt, error) {
+	return c.ExecContext(context.Background(), label, args, params)
+}
+
+// 执行合约方法，先预执行获取 gas
+// Call message with context, it is dry run first to get the gas
+func (c *DynamicContract) ExecContext(ctx context.Context, label string, args any, params ExecParams) (*TxReceipt, error) {
+	origin, err := params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := DefaultParamWithOrigin(origin)
+	_param.PayAmount = params.PayAmount
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunContext(ctx, label, args, _param)
+	if err != nil {
+		return nil, err
+	}
+
+	_, input, err := c.Input(label, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return CallInkContext(ctx, c, gas.GasRequired, gas.StorageDeposit, input, params)
+}
+
+// 解码返回值，ink! 的 MessageResult 会被展开，LangError 作为错误返回
+// 数据不是完整的 MessageResult 时按其 Ok 类型解码
+// Decode return value, ink! MessageResult is unwrapped and LangError is returned as error,
+// data is decoded as the Ok type when it is not a whole MessageResult
+func (c *DynamicContract) decodeReturn(msg *util.Message, data []byte) (json.RawMessage, error) {
+	if !slices.Contains(msg.ReturnType.DisplayName, "MessageResult") {
+		return c.decodeValue(msg.ReturnType.Type, data)
+	}
+
+	v, err := c.Abi.DecodeValue(msg.ReturnType.Type, data)
+	if err != nil {
+		okType, terr := c.messageResultOk(msg.ReturnType.Type)
+		if terr != nil {
+			return nil, errors.New("decode return value: " + err.Error())
+		}
+		return c.decodeValue(okType, data)
+	}
+
+	if m, ok := v.(map[string]any); ok && len(m) == 1 {
+		if ok, has := m["Ok"]; has {
+			v = ok
+		} else if langErr, has := m["Err"]; has {
+			return nil, fmt.Errorf("LangError: %v", langErr)
+		}
+	}
+
+	return json.Marshal(v)
+}
+
+func (c *DynamicContract) decodeValue(typeId int, data []byte) (json.RawMessage, error) {
+	v, err := c.Abi.DecodeValueJSON(typeId, data)
+	if err != nil {
+		return nil, errors.New("decode return value: " + err.Error())
+	}
+	return v, nil
+}
+
+// Ok type of MessageResult
+func (c *DynamicContract) messageResultOk(typeId int) (int, error) {
+	ty, err := c.Abi.TypeOf(typeId)
+	if err != nil {
+		return 0, err
+	}
+	if ty.Def.Variant != nil {
+		for _, variant := range ty.Def.Variant.Variants {
+			if variant.Name == "Ok" && len(variant.Fields) == 1 {
+				return variant.Fields[0].Type, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("type %d is not MessageResult", typeId)
+}
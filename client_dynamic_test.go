@@ -0,0 +1,69 @@
+package ink
+
+import (
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/wetee-dao/ink.go/util"
+)
+
+func TestDynamicContractOrigin(t *testing.T) {
+	raw, err := os.ReadFile("example/contracts/pod.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	abi, err := util.InitAbi(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// MessageResult Ok(Result Ok(()))
+	client := &mockInkClient{data: []byte{0, 0}}
+	contract, err := InitDynamicContract(client, abi, "0x0100000000000000000000000000000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := Ed25519PairFromSecret("//Alice", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsa, err := EcdsaPairFromSecret("0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, _ := Sr25519PairFromSecret("//Bob", 42)
+	m, err := NewMultisig(2, signer.AccountID(), bob.AccountID())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 以太坊交易、代理和多签调用以以太坊地址对应的账户、被代理账户和多签账户预执行
+	// Ethereum transactions, proxy and multisig calls are dry run as the account of Ethereum address,
+	// the real account and the multisig account
+	realAccount := types.AccountID{2}
+	for _, params := range []ExecParams{
+		{Signer: &ecdsa, Eth: &EthTxOptions{}},
+		{Signer: &signer, Proxy: &ProxyOptions{Real: realAccount}},
+		{Signer: &signer, Multisig: m},
+	} {
+		params.PayAmount = types.NewU128(*big.NewInt(0))
+		client.origins = nil
+		_, _ = contract.Exec("charge", nil, params)
+		if len(client.origins) == 0 || client.origins[0] != params.Origin() || client.origins[0] == params.Signer.AccountID() {
+			t.Errorf("dry run origins %x, want %x", client.origins, params.Origin())
+		}
+	}
+
+	// 已映射的以太坊地址以原账户预执行
+	// mapped Ethereum address is dry run as its original account
+	address, _ := ecdsa.EthAddress()
+	client.mapped = map[types.H160]types.AccountID{address: {3}}
+	client.origins = nil
+	_, _ = contract.Exec("charge", nil, ExecParams{Signer: &ecdsa, Eth: &EthTxOptions{}, PayAmount: types.NewU128(*big.NewInt(0))})
+	if len(client.origins) == 0 || client.origins[0] != (types.AccountID{3}) {
+		t.Errorf("dry run origins of mapped address %x", client.origins)
+	}
+}
@@ -1,8 +1,19 @@
 package ink
 
 import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	gtypes "github.com/wetee-dao/ink.go/pallet/types"
+	"github.com/wetee-dao/ink.go/util"
 )
 
 func InitErrors(m *types.Metadata) (registry.ErrorRegistry, error) {
@@ -27,3 +38,260 @@ func (c *ChainClient) GetErrorInfo(index byte, err [4]byte) (*registry.TypeDecod
 	}
 	return info, nil
 }
+
+// 区块链模块错误
+// Error of runtime pallet, the Module variant of DispatchError
+type ModuleError struct {
+	Pallet string
+	Name   string
+	// 模块序号
+	// index of pallet
+	Index uint8
+	// 错误序号，SCALE 编码的错误枚举
+	// SCALE encoded error variant of pallet
+	ErrorIndex [4]byte
+	Docs       []string
+}
+
+func (e *ModuleError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("module error: unknown error %d of pallet %d", e.ErrorIndex[0], e.Index)
+	}
+	return "module error: " + e.Pallet + "." + e.Name
+}
+
+// 交易或预执行返回的 DispatchError
+// DispatchError returned by transaction or dry run
+type DispatchError struct {
+	Err gtypes.DispatchError
+	// 不为 nil 时为模块错误
+	// not nil for module errors
+	Module *ModuleError
+}
+
+// 错误类型，如 Module、Token、Arithmetic、BadOrigin
+// Variant of DispatchError, such as Module, Token, Arithmetic and BadOrigin
+func (e *DispatchError) Kind() string {
+	return strings.TrimPrefix(variantName(e.Err), "DispatchError::")
+}
+
+// Token、Arithmetic、Transactional 和 Trie 错误的具体类型，如 FundsUnavailable
+// Inner variant of Token, Arithmetic, Transactional and Trie errors, such as FundsUnavailable
+func (e *DispatchError) Detail() string {
+	var inner json.Marshaler
+	switch {
+	case e.Err.IsToken && e.Err.AsTokenField0 != nil:
+		inner = e.Err.AsTokenField0
+	case e.Err.IsArithmetic && e.Err.AsArithmeticField0 != nil:
+		inner = e.Err.AsArithmeticField0
+	case e.Err.IsTransactional && e.Err.AsTransactionalField0 != nil:
+		inner = e.Err.AsTransactionalField0
+	case e.Err.IsTrie && e.Err.AsTrieField0 != nil:
+		inner = e.Err.AsTrieField0
+	default:
+		return ""
+	}
+
+	name := variantName(inner)
+	if i := strings.LastIndex(name, "::"); i >= 0 {
+		return name[i+2:]
+	}
+	return name
+}
+
+func (e *DispatchError) Error() string {
+	if e.Module != nil {
+		return "dispatch error: " + e.Module.Error()
+	}
+	if detail := e.Detail(); detail != "" {
+		return "dispatch error: " + e.Kind() + "." + detail
+	}
+	return "dispatch error: " + e.Kind()
+}
+
+func (e *DispatchError) Unwrap() error {
+	if e.Module != nil {
+		return e.Module
+	}
+	return nil
+}
+
+// 合约回滚错误，可以通过 errors.Is(err, ErrContractReverted) 判断
+// Error of reverted contract, errors.Is(err, ErrContractReverted) reports true for it
+type ContractRevertError struct {
+	// 合约返回的原始数据
+	// raw data returned by contract
+	Data []byte
+	// 生成代码解码后的合约错误，无法解码时为 nil
+	// contract error decoded by generated code, nil when it can not be decoded
+	Decoded any
+}
+
+func (e *ContractRevertError) Error() string {
+	if raw, ok := e.Decoded.(json.RawMessage); ok {
+		return "contract reverted: " + string(raw)
+	}
+	if inner := e.Unwrap(); inner != nil {
+		return "contract reverted: " + inner.Error()
+	}
+	if e.Decoded != nil {
+		return fmt.Sprintf("contract reverted: %v", e.Decoded)
+	}
+	return "contract reverted: 0x" + hex.EncodeToString(e.Data)
+}
+
+func (e *ContractRevertError) Is(target error) bool {
+	return target == ErrContractReverted
+}
+
+// 返回解码后的合约错误，生成的错误枚举在指针上实现 error
+// Unwrap returns the decoded contract error, generated error enums implement error on pointer receiver
+func (e *ContractRevertError) Unwrap() error {
+	return decodedError(e.Decoded)
+}
+
+// 合约方法执行成功但返回 Result::Err，交易不会回滚
+// Contract message returned Result::Err without reverting
+type ContractResultError struct {
+	// 生成代码解码后的合约错误
+	// contract error decoded by generated code
+	Decoded any
+}
+
+func (e *ContractResultError) Error() string {
+	if inner := e.Unwrap(); inner != nil {
+		return "contract returned error: " + inner.Error()
+	}
+	return fmt.Sprintf("contract returned error: %v", e.Decoded)
+}
+
+// 返回解码后的合约错误
+// Unwrap returns the decoded contract error
+func (e *ContractResultError) Unwrap() error {
+	return decodedError(e.Decoded)
+}
+
+// 解码后的合约错误作为 error，生成的错误枚举在指针上实现 error
+// Decoded contract error as error, generated error enums implement error on pointer receiver
+func decodedError(decoded any) error {
+	if decoded == nil {
+		return nil
+	}
+	if err, ok := decoded.(error); ok {
+		return err
+	}
+
+	ptr := reflect.New(reflect.TypeOf(decoded))
+	ptr.Elem().Set(reflect.ValueOf(decoded))
+	if err, ok := ptr.Interface().(error); ok {
+		return err
+	}
+	return nil
+}
+
+// 将 DispatchError 转换为带有模块错误信息的错误
+// Wrap DispatchError with the info of module error from metadata
+func (c *ChainClient) WrapDispatchError(d gtypes.DispatchError) *DispatchError {
+	derr := &DispatchError{Err: d}
+	if d.IsModule {
+		derr.Module = c.ModuleError(d.AsModuleField0.Index, d.AsModuleField0.Error)
+	}
+	return derr
+}
+
+// 根据模块序号和错误序号获取模块错误
+// Get module error by index of pallet and error
+func (c *ChainClient) ModuleError(index byte, err [4]byte) *ModuleError {
+	merr := &ModuleError{Index: index, ErrorIndex: err}
+	if c.Meta == nil {
+		return merr
+	}
+
+	for _, mod := range c.Meta.AsMetadataV14.Pallets {
+		if uint8(mod.Index) != index {
+			continue
+		}
+		merr.Pallet = string(mod.Name)
+		if !mod.HasErrors {
+			return merr
+		}
+
+		errorsType, ok := c.Meta.AsMetadataV14.EfficientLookup[mod.Errors.Type.Int64()]
+		if !ok || !errorsType.Def.IsVariant {
+			return merr
+		}
+		for _, variant := range errorsType.Def.Variant.Variants {
+			if uint8(variant.Index) != err[0] {
+				continue
+			}
+			merr.Name = string(variant.Name)
+			for _, doc := range variant.Docs {
+				merr.Docs = append(merr.Docs, string(doc))
+			}
+		}
+		return merr
+	}
+
+	return merr
+}
+
+func variantName(v json.Marshaler) string {
+	bt, err := v.MarshalJSON()
+	if err != nil {
+		return "unknown"
+	}
+
+	var name string
+	if json.Unmarshal(bt, &name) == nil {
+		return name
+	}
+	var m map[string]json.RawMessage
+	if json.Unmarshal(bt, &m) == nil {
+		for k := range m {
+			return k
+		}
+	}
+	return "unknown"
+}
+
+// 为合约回滚错误设置解码后的合约错误，err 不是合约回滚错误时创建新的错误
+// Set decoded contract error of revert error, a new revert error is created when err is not a revert error
+func WithRevertReason(err error, decoded any) error {
+	rerr := &ContractRevertError{}
+	if errors.As(err, &rerr) {
+		return &ContractRevertError{Data: rerr.Data, Decoded: decoded}
+	}
+	return &ContractRevertError{Decoded: decoded}
+}
+
+// 将合约回滚数据解码为合约方法返回类型 T 中的错误或 LangError
+// 回滚数据为 MessageResult::Err(LangError)、MessageResult::Ok(T) 或 T
+// Decode revert data into the error of message return type T or LangError,
+// the revert data is MessageResult::Err(LangError), MessageResult::Ok(T) or T
+func DecodeContractRevert[T any](data []byte) *ContractRevertError {
+	rerr := &ContractRevertError{Data: data}
+	if lerr, ok := util.DecodeLangError(data); ok {
+		rerr.Decoded = *lerr
+		return rerr
+	}
+
+	payloads := [][]byte{data}
+	if len(data) > 0 && data[0] == 0 {
+		payloads = [][]byte{data[1:], data}
+	}
+	for _, payload := range payloads {
+		r := bytes.NewReader(payload)
+		v := new(T)
+		if err := scale.NewDecoder(r).Decode(v); err != nil || r.Len() > 0 {
+			continue
+		}
+		if result, ok := any(*v).(interface{ ErrValue() (any, bool) }); ok {
+			if e, isErr := result.ErrValue(); isErr {
+				rerr.Decoded = e
+				return rerr
+			}
+		}
+	}
+
+	return rerr
+}
@@ -0,0 +1,91 @@
+package ink
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	gtypes "github.com/wetee-dao/ink.go/pallet/types"
+	"github.com/wetee-dao/ink.go/util"
+)
+
+func TestDispatchError(t *testing.T) {
+	c := &ChainClient{}
+
+	token := c.WrapDispatchError(gtypes.DispatchError{IsToken: true, AsTokenField0: &gtypes.TokenError{IsFundsUnavailable: true}})
+	if token.Kind() != "Token" || token.Detail() != "FundsUnavailable" || token.Module != nil {
+		t.Errorf("token error: %s %s", token.Kind(), token.Detail())
+	}
+
+	origin := c.WrapDispatchError(gtypes.DispatchError{IsBadOrigin: true})
+	if origin.Error() != "dispatch error: BadOrigin" {
+		t.Errorf("bad origin error: %s", origin.Error())
+	}
+
+	module := c.WrapDispatchError(gtypes.DispatchError{IsModule: true, AsModuleField0: gtypes.ModuleError{Index: 60, Error: [4]byte{3}}})
+	err := fmt.Errorf("tx: %w", module)
+
+	var derr *DispatchError
+	if !errors.As(err, &derr) || derr.Kind() != "Module" {
+		t.Fatalf("errors.As DispatchError: %v", err)
+	}
+	var merr *ModuleError
+	if !errors.As(err, &merr) || merr.Index != 60 || merr.ErrorIndex[0] != 3 {
+		t.Errorf("errors.As ModuleError: %v", err)
+	}
+}
+
+func TestContractRevertError(t *testing.T) {
+	reverted := fmt.Errorf("DryRun: %w", &ContractRevertError{Data: []byte{1, 2}})
+	if !errors.Is(reverted, ErrContractReverted) {
+		t.Error("ContractRevertError is not ErrContractReverted")
+	}
+
+	decodedErr := errors.New("InsufficientBalance")
+	err := WithRevertReason(reverted, decodedErr)
+
+	var rerr *ContractRevertError
+	if !errors.As(err, &rerr) || len(rerr.Data) != 2 || rerr.Decoded != decodedErr {
+		t.Fatalf("errors.As ContractRevertError: %v", err)
+	}
+	if !errors.Is(err, decodedErr) {
+		t.Error("decoded error is not unwrapped")
+	}
+	if err.Error() != "contract reverted: InsufficientBalance" {
+		t.Errorf("error message: %s", err.Error())
+	}
+}
+
+func TestContractResultError(t *testing.T) {
+	decodedErr := errors.New("InsufficientBalance")
+	err := fmt.Errorf("DryRun: %w", &ContractResultError{Decoded: decodedErr})
+	if errors.Is(err, ErrContractReverted) {
+		t.Error("ContractResultError is ErrContractReverted")
+	}
+	if !errors.Is(err, decodedErr) {
+		t.Error("decoded error is not unwrapped")
+	}
+	if msg := (&ContractResultError{Decoded: uint32(5)}).Error(); msg != "contract returned error: 5" {
+		t.Errorf("error message: %s", msg)
+	}
+}
+
+func TestDecodeContractRevert(t *testing.T) {
+	lang := DecodeContractRevert[util.Result[util.NullTuple, uint32]]([]byte{1, 1})
+	if !errors.Is(lang, util.LangError{IsCouldNotReadInput: true}) {
+		t.Errorf("LangError: %v", lang)
+	}
+
+	// with and without MessageResult::Ok
+	for _, data := range [][]byte{{1, 5, 0, 0, 0}, {0, 1, 5, 0, 0, 0}} {
+		rerr := DecodeContractRevert[util.Result[util.NullTuple, uint32]](data)
+		if rerr.Decoded != uint32(5) {
+			t.Errorf("decode %v: %v", data, rerr.Decoded)
+		}
+	}
+
+	unknown := DecodeContractRevert[util.Result[util.NullTuple, uint32]]([]byte{9})
+	if unknown.Decoded != nil || !errors.Is(unknown, ErrContractReverted) {
+		t.Errorf("unknown revert: %v", unknown)
+	}
+}
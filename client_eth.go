@@ -0,0 +1,298 @@
+package ink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/wetee-dao/ink.go/pallet/revive"
+	"github.com/wetee-dao/ink.go/util"
+)
+
+// 以太坊交易参数
+// Options of Ethereum transaction
+type EthTxOptions struct {
+	// 使用 legacy（EIP-155）交易，默认为 EIP-1559 交易
+	// use legacy (EIP-155) transaction, EIP-1559 transaction by default
+	Legacy bool
+	// gas 上限，为 0 时使用区块 gas 上限，未使用的 gas 会退还
+	// gas limit, the block gas limit is used when it is 0, unused gas is refunded
+	GasLimit uint64
+	// legacy 交易的 gas 价格或 EIP-1559 交易的 max fee per gas，nil 时使用 ReviveApi_gas_price
+	// gas price of legacy transaction or max fee per gas of EIP-1559 transaction, ReviveApi_gas_price when nil
+	GasPrice *big.Int
+	// EIP-1559 交易的 max priority fee per gas，nil 时为 0
+	// max priority fee per gas of EIP-1559 transaction, 0 when nil
+	MaxPriorityFeePerGas *big.Int
+}
+
+// 通过 Revive.eth_transact 提交的以太坊交易
+// Ethereum transaction submitted by Revive.eth_transact
+type EthTransaction struct {
+	// 目标合约，nil 时为部署合约
+	// contract called, nil to deploy contract
+	To *types.H160
+	// 转账金额，单位为链上原生代币，按 NativeToEthRatio 换算为 wei
+	// value transferred in native balance, converted to wei by NativeToEthRatio
+	Value types.U128
+	Data  []byte
+	EthTxOptions
+}
+
+// 以太坊链 id，即 Revive.ChainId 常量
+// Ethereum chain id, the Revive.ChainId constant
+func (c *ChainClient) EthChainID() (uint64, error) {
+	var id types.U64
+	if err := c.reviveConstant("ChainId", &id); err != nil {
+		return 0, err
+	}
+	return uint64(id), nil
+}
+
+// 原生代币换算为 wei，乘以 Revive.NativeToEthRatio 常量
+// Convert native balance to wei, multiplied by the Revive.NativeToEthRatio constant
+func (c *ChainClient) NativeToWei(value types.U128) (*big.Int, error) {
+	var ratio types.U32
+	if err := c.reviveConstant("NativeToEthRatio", &ratio); err != nil {
+		return nil, err
+	}
+
+	wei := big.NewInt(0)
+	if value.Int != nil {
+		wei.Mul(value.Int, big.NewInt(int64(ratio)))
+	}
+	return wei, nil
+}
+
+// 以太坊交易的 gas 价格
+// Gas price of Ethereum transactions
+func (c *ChainClient) EthGasPrice() (*big.Int, error) {
+	return c.EthGasPriceContext(context.Background())
+}
+
+// 以太坊交易的 gas 价格
+// Gas price of Ethereum transactions with context
+func (c *ChainClient) EthGasPriceContext(ctx context.Context) (*big.Int, error) {
+	var price types.U256
+	if err := c.CallRuntimeApiContext(ctx, "ReviveApi", "gas_price", nil, &price); err != nil {
+		return nil, errors.New("CallRuntimeApi: " + err.Error())
+	}
+	return price.Int, nil
+}
+
+// 区块 gas 上限
+// Gas limit of block
+func (c *ChainClient) EthBlockGasLimitContext(ctx context.Context) (*big.Int, error) {
+	var limit types.U256
+	if err := c.CallRuntimeApiContext(ctx, "ReviveApi", "block_gas_limit", nil, &limit); err != nil {
+		return nil, errors.New("CallRuntimeApi: " + err.Error())
+	}
+	return limit.Int, nil
+}
+
+// 签名以太坊交易，返回 RLP 编码的已签名交易
+// Sign Ethereum transaction and return the RLP encoded signed transaction
+func (c *ChainClient) SignEthTransactionContext(ctx context.Context, signer EthSignerType, tx EthTransaction, nonce uint64) ([]byte, error) {
+	chainID, err := c.EthChainID()
+	if err != nil {
+		return nil, err
+	}
+	value, err := c.NativeToWei(tx.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	gasPrice := tx.GasPrice
+	if gasPrice == nil {
+		gasPrice, err = c.EthGasPriceContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	gasLimit := tx.GasLimit
+	if gasLimit == 0 {
+		limit, err := c.EthBlockGasLimitContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !limit.IsUint64() {
+			return nil, fmt.Errorf("block gas limit %s overflows uint64", limit)
+		}
+		gasLimit = limit.Uint64()
+	}
+
+	var to *common.Address
+	if tx.To != nil {
+		addr := common.Address(*tx.To)
+		to = &addr
+	}
+
+	var data ethtypes.TxData
+	if tx.Legacy {
+		data = &ethtypes.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: gasPrice,
+			Gas:      gasLimit,
+			To:       to,
+			Value:    value,
+			Data:     tx.Data,
+		}
+	} else {
+		tip := tx.MaxPriorityFeePerGas
+		if tip == nil {
+			tip = big.NewInt(0)
+		}
+		data = &ethtypes.DynamicFeeTx{
+			ChainID:   new(big.Int).SetUint64(chainID),
+			Nonce:     nonce,
+			GasTipCap: tip,
+			GasFeeCap: gasPrice,
+			Gas:       gasLimit,
+			To:        to,
+			Value:     value,
+			Data:      tx.Data,
+		}
+	}
+
+	ethSigner := ethtypes.LatestSignerForChainID(new(big.Int).SetUint64(chainID))
+	unsigned := ethtypes.NewTx(data)
+	hash := ethSigner.Hash(unsigned)
+	sig, err := signer.SignEthHash(hash[:])
+	if err != nil {
+		return nil, errors.New("SignEthHash: " + err.Error())
+	}
+	signed, err := unsigned.WithSignature(ethSigner, sig)
+	if err != nil {
+		return nil, errors.New("WithSignature: " + err.Error())
+	}
+
+	return signed.MarshalBinary()
+}
+
+// 签名以太坊交易并通过 Revive.eth_transact 以无签名交易提交，链上交易来源为以太坊地址对应的账户，
+// opts 中的 Tip 和 Era 不适用于以太坊交易
+// Sign Ethereum transaction and submit it by Revive.eth_transact as an unsigned extrinsic,
+// the origin is the account mapped to Ethereum address, Tip and Era of opts do not apply to Ethereum transactions
+func (c *ChainClient) SubmitEthTransaction(signer SignerType, tx EthTransaction, opts SubmitOptions) (*TxReceipt, error) {
+	return c.SubmitEthTransactionContext(context.Background(), signer, tx, opts)
+}
+
+// 签名以太坊交易并通过 Revive.eth_transact 提交
+// Sign Ethereum transaction and submit it by Revive.eth_transact with context
+func (c *ChainClient) SubmitEthTransactionContext(ctx context.Context, signer SignerType, tx EthTransaction, opts SubmitOptions) (*TxReceipt, error) {
+	ethSigner, ok := signer.(EthSignerType)
+	if !ok {
+		return nil, errors.New("SubmitEthTransaction: signer can not sign Ethereum transactions")
+	}
+	address, err := ethSigner.EthAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = DefaultSubmitTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// 以太坊交易的 nonce 为对应账户的 nonce
+	// nonce of Ethereum transaction is the nonce of account mapped to Ethereum address
+	account, err := c.EthAccountIDContext(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	nonce := opts.Nonce
+	managed := nonce == 0
+	if managed {
+		nonce, err = c.Nonces.Next(ctx, account)
+		if err != nil {
+			return nil, errors.New("NonceManager.Next error: " + err.Error())
+		}
+	}
+
+	xt, err := c.ethExtrinsic(ctx, ethSigner, tx, nonce)
+	if err != nil {
+		if managed {
+			c.Nonces.Release(account, nonce)
+		}
+		return nil, err
+	}
+
+	if c.Debug {
+		util.LogWithYellow("[    Eth transact ]", address.Hex())
+		util.LogWithYellow("[           nonce ]", nonce)
+	}
+
+	return c.submitAndWatch(ctx, xt, account, nonce, managed, opts.UntilFinalized)
+}
+
+// 以太坊地址对应的账户，已通过 Revive.map_account 映射的地址为原账户，否则为回退账户
+// Account of Ethereum address, it is the original account of address mapped by Revive.map_account,
+// or the fallback account otherwise
+func (c *ChainClient) EthAccountID(address types.H160) (types.AccountID, error) {
+	return c.EthAccountIDContext(context.Background(), address)
+}
+
+// 以太坊地址对应的账户
+// Account of Ethereum address with context
+func (c *ChainClient) EthAccountIDContext(ctx context.Context, address types.H160) (types.AccountID, error) {
+	key, err := revive.MakeOriginalAccountStorageKey(address)
+	if err != nil {
+		return types.AccountID{}, err
+	}
+
+	var original [32]byte
+	ok, err := c.getStorageContext(ctx, key, &original, nil)
+	if err != nil {
+		return types.AccountID{}, errors.New("Revive.OriginalAccount: " + err.Error())
+	}
+	if ok {
+		return types.AccountID(original), nil
+	}
+
+	return util.AccountIDFromH160(address), nil
+}
+
+// 构造包含已签名以太坊交易的无签名交易
+// Build unsigned extrinsic of Revive.eth_transact with signed Ethereum transaction
+func (c *ChainClient) ethExtrinsic(ctx context.Context, signer EthSignerType, tx EthTransaction, nonce uint64) (extrinsic.Extrinsic, error) {
+	payload, err := c.SignEthTransactionContext(ctx, signer, tx, nonce)
+	if err != nil {
+		return extrinsic.Extrinsic{}, err
+	}
+
+	runtimeCall := revive.MakeEthTransactCall(payload)
+	call, err := (runtimeCall).AsCall()
+	if err != nil {
+		return extrinsic.Extrinsic{}, errors.New("(runtimeCall).AsCall() error: " + err.Error())
+	}
+
+	return extrinsic.Extrinsic{Version: extrinsic.Version4, Method: call}, nil
+}
+
+// 读取 Revive 模块常量
+// Read constant of Revive pallet
+func (c *ChainClient) reviveConstant(name string, v any) error {
+	for _, pallet := range c.Meta.AsMetadataV14.Pallets {
+		if string(pallet.Name) != "Revive" {
+			continue
+		}
+		for _, constant := range pallet.Constants {
+			if string(constant.Name) == name {
+				return codec.Decode(constant.Value, v)
+			}
+		}
+	}
+
+	return fmt.Errorf("constant Revive.%s not found", name)
+}
@@ -0,0 +1,225 @@
+package ink
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	gtypes "github.com/wetee-dao/ink.go/pallet/types"
+	"github.com/wetee-dao/ink.go/util"
+)
+
+var ErrUnknownEvent = errors.New("contract event: unknown signature topic")
+
+// 合约事件，来自 Revive.ContractEmitted
+// Raw ink! contract event from Revive.ContractEmitted
+type InkEvent struct {
+	BlockNumber uint64
+	BlockHash   types.Hash
+	Phase       gtypes.Phase
+	Contract    types.H160
+	Topics      [][32]byte
+	Data        []byte
+}
+
+// 事件订阅，也用于区块订阅
+// Subscription of contract events, also used by block subscriptions
+type EventSubscription[T any] struct {
+	channel  chan T
+	errs     chan error
+	quit     chan struct{}
+	quitOnce sync.Once
+}
+
+func newEventSubscription[T any]() *EventSubscription[T] {
+	return &EventSubscription[T]{
+		channel: make(chan T),
+		errs:    make(chan error, 1),
+		quit:    make(chan struct{}),
+	}
+}
+
+// Chan returns the event channel, it is closed when the subscription ends.
+func (s *EventSubscription[T]) Chan() <-chan T {
+	return s.channel
+}
+
+// Err returns the error channel, it receives a value when the subscription ends due to an error.
+func (s *EventSubscription[T]) Err() <-chan error {
+	return s.errs
+}
+
+// Unsubscribe stops the subscription, it can safely be called more than once.
+func (s *EventSubscription[T]) Unsubscribe() {
+	s.quitOnce.Do(func() {
+		close(s.quit)
+	})
+}
+
+// send value to subscriber, return false if subscription is closed
+func (s *EventSubscription[T]) send(v T) bool {
+	select {
+	case s.channel <- v:
+		return true
+	case <-s.quit:
+		return false
+	}
+}
+
+// 订阅合约事件，topic 为 nil 时返回所有事件
+// Watch Revive.ContractEmitted of contract, all events are returned when topic is nil
+func (c *ChainClient) WatchContractEvents(address types.H160, topic *types.Hash) (*EventSubscription[InkEvent], error) {
+	return c.WatchContractEventsContext(context.Background(), address, topic)
+}
+
+// 订阅合约事件，只返回最终确认区块的事件，连接断开后自动重新订阅，跳过的区块会被补齐，ctx 取消时结束订阅
+// Watch Revive.ContractEmitted of contract in finalized blocks, it is resubscribed automatically when the connection is lost
+// and events of skipped blocks are filled in, the subscription ends when ctx is done
+func (c *ChainClient) WatchContractEventsContext(ctx context.Context, address types.H160, topic *types.Hash) (*EventSubscription[InkEvent], error) {
+	blocks, err := c.SubscribeFinalizedEventsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	esub := newEventSubscription[InkEvent]()
+	go func() {
+		defer blocks.Unsubscribe()
+		defer close(esub.channel)
+
+		for {
+			select {
+			case block, ok := <-blocks.Chan():
+				if !ok {
+					select {
+					case err := <-blocks.Err():
+						esub.errs <- err
+					default:
+					}
+					return
+				}
+
+				for _, e := range FilterInkEvents(block.Events, address, topic) {
+					e.BlockNumber = block.Number
+					e.BlockHash = block.Hash
+					if !esub.send(e) {
+						return
+					}
+				}
+			case <-esub.quit:
+				return
+			}
+		}
+	}()
+
+	return esub, nil
+}
+
+// 过滤合约事件
+// Filter Revive.ContractEmitted events of contract by signature topic
+func FilterInkEvents(records []gtypes.EventRecord, address types.H160, topic *types.Hash) []InkEvent {
+	events := make([]InkEvent, 0)
+	for _, r := range records {
+		if !r.Event.IsRevive || r.Event.AsReviveField0 == nil || !r.Event.AsReviveField0.IsContractEmitted {
+			continue
+		}
+
+		e := r.Event.AsReviveField0
+		if types.H160(e.AsContractEmittedContract0) != address {
+			continue
+		}
+
+		if topic != nil {
+			if len(e.AsContractEmittedTopics2) == 0 || types.Hash(e.AsContractEmittedTopics2[0]) != *topic {
+				continue
+			}
+		}
+
+		events = append(events, InkEvent{
+			Phase:    r.Phase,
+			Contract: e.AsContractEmittedContract0,
+			Topics:   e.AsContractEmittedTopics2,
+			Data:     e.AsContractEmittedData1,
+		})
+	}
+
+	return events
+}
+
+// 订阅合约事件并解码
+// Watch ink! event of contract and decode it to T
+func WatchInkEvent[T any](contractIns Ink, signatureTopic string) (*EventSubscription[T], error) {
+	return WatchInkEventContext[T](context.Background(), contractIns, signatureTopic)
+}
+
+// 订阅合约事件并解码，ctx 取消时结束订阅
+// Watch ink! event of contract and decode it to T, the subscription ends when ctx is done
+func WatchInkEventContext[T any](ctx context.Context, contractIns Ink, signatureTopic string) (*EventSubscription[T], error) {
+	topic, err := types.NewHashFromHexString(signatureTopic)
+	if err != nil {
+		return nil, errors.New("NewHashFromHexString: " + err.Error())
+	}
+
+	client := contractIns.Client()
+	sub, err := client.WatchContractEventsContext(ctx, contractIns.ContractAddress(), &topic)
+	if err != nil {
+		return nil, err
+	}
+
+	tsub := newEventSubscription[T]()
+	go func() {
+		defer sub.Unsubscribe()
+		defer close(tsub.channel)
+
+		for {
+			select {
+			case e, ok := <-sub.Chan():
+				if !ok {
+					select {
+					case err := <-sub.Err():
+						tsub.errs <- err
+					default:
+					}
+					return
+				}
+				if client.IsDebug() {
+					util.LogWithCyan("[ Contract event ]", signatureTopic, "block", e.BlockHash.Hex())
+				}
+
+				v, err := DecodeInkEvent[T](e.Data)
+				if err != nil {
+					tsub.errs <- err
+					return
+				}
+				if !tsub.send(*v) {
+					return
+				}
+			case err := <-sub.Err():
+				tsub.errs <- err
+				return
+			case <-tsub.quit:
+				return
+			}
+		}
+	}()
+
+	return tsub, nil
+}
+
+// 解码合约事件数据，数据未被完全解码时返回错误
+// Decode SCALE encoded ink! event data, an error is returned when the data is not fully decoded
+func DecodeInkEvent[T any](data []byte) (*T, error) {
+	v := new(T)
+	r := bytes.NewReader(data)
+	err := scale.NewDecoder(r).Decode(v)
+	if err != nil {
+		return nil, errors.New("decode contract event: " + err.Error())
+	}
+	if r.Len() > 0 {
+		return nil, fmt.Errorf("decode contract event: %d trailing bytes", r.Len())
+	}
+	return v, nil
+}
@@ -0,0 +1,196 @@
+package ink
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/wetee-dao/ink.go/pallet/transactionpayment"
+	gtypes "github.com/wetee-dao/ink.go/pallet/types"
+	"github.com/wetee-dao/ink.go/util"
+)
+
+// TransactionPaymentApi_query_info 返回值
+// Result of TransactionPaymentApi_query_info
+type RuntimeDispatchInfo struct {
+	Weight     gtypes.Weight
+	Class      gtypes.DispatchClass
+	PartialFee types.U128
+}
+
+// 交易基础费用
+// Inclusion fee of transaction
+type InclusionFee struct {
+	BaseFee           types.U128
+	LenFee            types.U128
+	AdjustedWeightFee types.U128
+}
+
+// TransactionPaymentApi_query_fee_details 返回值
+// Result of TransactionPaymentApi_query_fee_details
+type FeeDetails struct {
+	InclusionFee util.Option[InclusionFee]
+	Tip          types.U128
+}
+
+// 交易费用估算
+// Estimated fee of transaction
+type FeeEstimate struct {
+	// 不含小费的费用
+	// fee without tip, which is what the signer pays besides tip
+	PartialFee types.U128
+	// 费用明细，未收费的交易为 0
+	// fee details, zero for unsigned or free transactions
+	BaseFee           types.U128
+	LenFee            types.U128
+	AdjustedWeightFee types.U128
+	Tip               types.U128
+	Weight            gtypes.Weight
+	Class             gtypes.DispatchClass
+	// 编码后交易长度
+	// length of encoded extrinsic
+	Length uint32
+}
+
+// 估算交易费用
+// Estimate fee of call signed by signer
+func (c *ChainClient) EstimateFee(call types.Call, signer SignerType) (*FeeEstimate, error) {
+	return c.EstimateFeeContext(context.Background(), call, signer)
+}
+
+// 估算交易费用，使用空签名，不需要签名者签名
+// Estimate fee of call with context, the extrinsic is signed with an empty signature so signer is never asked to sign
+func (c *ChainClient) EstimateFeeContext(ctx context.Context, call types.Call, signer SignerType) (*FeeEstimate, error) {
+	return c.estimateFee(ctx, call, signer.AccountID(), signer.SignType(), SubmitOptions{})
+}
+
+// 签名类型决定签名长度，从而影响交易长度费用
+// key type decides the length of signature, and so the length fee of extrinsic
+func (c *ChainClient) estimateFee(ctx context.Context, call types.Call, account types.AccountID, keyType uint8, opts SubmitOptions) (*FeeEstimate, error) {
+	signOpts, err := c.signingOptions(ctx, opts.Nonce, opts.Tip, opts.Era)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := NewExtrinsic(call)
+	err = ext.Sign(&feeSigner{account: account, keyType: keyType}, c.Meta, signOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	extBytes, err := codec.Encode(ext.Extrinsic)
+	if err != nil {
+		return nil, errors.New("Codec.Encode error: " + err.Error())
+	}
+	length := uint32(len(extBytes))
+
+	info := RuntimeDispatchInfo{}
+	err = c.CallRuntimeApiContext(ctx, "TransactionPaymentApi", "query_info", []any{ext.Extrinsic, length}, &info)
+	if err != nil {
+		return nil, errors.New("TransactionPaymentApi_query_info: " + err.Error())
+	}
+
+	details := FeeDetails{}
+	err = c.CallRuntimeApiContext(ctx, "TransactionPaymentApi", "query_fee_details", []any{ext.Extrinsic, length}, &details)
+	if err != nil {
+		return nil, errors.New("TransactionPaymentApi_query_fee_details: " + err.Error())
+	}
+
+	fee := &FeeEstimate{
+		PartialFee:        info.PartialFee,
+		BaseFee:           types.NewU128(*big.NewInt(0)),
+		LenFee:            types.NewU128(*big.NewInt(0)),
+		AdjustedWeightFee: types.NewU128(*big.NewInt(0)),
+		Tip:               details.Tip,
+		Weight:            info.Weight,
+		Class:             info.Class,
+		Length:            length,
+	}
+	if details.InclusionFee.IsSome() {
+		fee.BaseFee = details.InclusionFee.V.BaseFee
+		fee.LenFee = details.InclusionFee.V.LenFee
+		fee.AdjustedWeightFee = details.InclusionFee.V.AdjustedWeightFee
+	}
+
+	if c.Debug {
+		util.LogWithPurple("[    Estimate fee ]", fee.PartialFee.String(), "length", length)
+	}
+
+	return fee, nil
+}
+
+// 下一个区块的费用乘数，FixedU128 精度为 1e18
+// Fee multiplier of next block, FixedU128 with 1e18 precision
+func (c *ChainClient) NextFeeMultiplier() (types.U128, error) {
+	return c.NextFeeMultiplierContext(context.Background())
+}
+
+// 下一个区块的费用乘数
+// Fee multiplier of next block with context
+func (c *ChainClient) NextFeeMultiplierContext(ctx context.Context) (types.U128, error) {
+	var multiplier types.U128
+	key, err := transactionpayment.MakeNextFeeMultiplierStorageKey()
+	if err != nil {
+		return multiplier, err
+	}
+
+	ok, err := c.getStorageContext(ctx, key, &multiplier, nil)
+	if err != nil {
+		return multiplier, errors.New("TransactionPayment.NextFeeMultiplier: " + err.Error())
+	}
+	if !ok {
+		err = codec.Decode(transactionpayment.NextFeeMultiplierResultDefaultBytes, &multiplier)
+	}
+	return multiplier, err
+}
+
+// 估算合约调用费用，keyType 为 origin 的签名类型
+// Estimate fee of calling contract with gas of dry run, keyType is the key type of origin
+func EstimateInkFee(
+	ctx context.Context,
+	contractIns Ink,
+	origin types.AccountID,
+	keyType uint8,
+	amount types.U128,
+	gas *DryRunReturnGas,
+	contractInput util.InkContractInput,
+) (*FeeEstimate, error) {
+	call, err := CallOfTransaction(contractIns, amount, gas.GasRequired, gas.StorageDeposit, contractInput)
+	if err != nil {
+		return nil, errors.New("CallOfTransaction: " + err.Error())
+	}
+
+	return contractIns.Client().EstimateFeeContext(ctx, *call, &feeSigner{account: origin, keyType: keyType})
+}
+
+// 估算费用用的签名者，签名为空
+// Signer of fee estimation, TransactionPaymentApi does not verify the signature
+type feeSigner struct {
+	account types.AccountID
+	keyType uint8
+}
+
+func (s *feeSigner) Public() []byte {
+	return s.account[:]
+}
+
+func (s *feeSigner) AccountID() types.AccountID {
+	return s.account
+}
+
+func (s *feeSigner) Sign([]byte) ([]byte, error) {
+	if s.keyType == KeyTypeEcdsa {
+		return make([]byte, 65), nil
+	}
+	return make([]byte, 64), nil
+}
+
+func (s *feeSigner) Verify([]byte, []byte) bool {
+	return false
+}
+
+func (s *feeSigner) SignType() uint8 {
+	return s.keyType
+}
@@ -2,8 +2,8 @@ package ink
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -15,11 +15,33 @@ import (
 	"github.com/wetee-dao/ink.go/util"
 )
 
+// 合约回滚，具体错误为 *ContractRevertError
+// Contract reverted, the specific error is *ContractRevertError
 var ErrContractReverted = errors.New("contract reverted: the specific error information is returned")
 
+// 合约调用依赖的链客户端，*ChainClient 实现了该接口，测试中可以替换为 mock
+// Chain client which contract calls depend on, *ChainClient implements it and it can be mocked in tests
+type InkClient interface {
+	// 是否打印调试日志
+	// whether debug logs are printed
+	IsDebug() bool
+	CallRuntimeApiContext(ctx context.Context, pallet, method string, args []any, result any) error
+	WrapDispatchError(d gtypes.DispatchError) *DispatchError
+	SignAndSubmitWithOptions(ctx context.Context, signer SignerType, call types.Call, opts SubmitOptions) (*TxReceipt, error)
+	SubmitEthTransactionContext(ctx context.Context, signer SignerType, tx EthTransaction, opts SubmitOptions) (*TxReceipt, error)
+	AsMultiContext(ctx context.Context, signer SignerType, m *Multisig, call types.Call, maxWeight types.Weight, opts SubmitOptions) (*TxReceipt, error)
+	ProxyCall(opts ProxyOptions, call types.Call) (types.Call, error)
+	EstimateFeeContext(ctx context.Context, call types.Call, signer SignerType) (*FeeEstimate, error)
+	GetContractStorageContext(ctx context.Context, address types.H160, key []byte) ([]byte, error)
+	WatchContractEventsContext(ctx context.Context, address types.H160, topic *types.Hash) (*EventSubscription[InkEvent], error)
+	EthAccountIDContext(ctx context.Context, address types.H160) (types.AccountID, error)
+}
+
+var _ InkClient = (*ChainClient)(nil)
+
 // Revive module
 type Ink interface {
-	Client() *ChainClient
+	Client() InkClient
 	ContractAddress() types.H160
 }
 
@@ -31,6 +53,19 @@ func DryRunInk[T any](
 	gas_limit util.Option[types.Weight],
 	storage_deposit_limit util.Option[types.U128],
 	contractInput util.InkContractInput,
+) (*T, *DryRunReturnGas, error) {
+	return DryRunInkContext[T](context.Background(), contractIns, origin, amount, gas_limit, storage_deposit_limit, contractInput)
+}
+
+// Dry run contract with context
+func DryRunInkContext[T any](
+	ctx context.Context,
+	contractIns Ink,
+	origin types.AccountID,
+	amount types.U128,
+	gas_limit util.Option[types.Weight],
+	storage_deposit_limit util.Option[types.U128],
+	contractInput util.InkContractInput,
 ) (*T, *DryRunReturnGas, error) {
 	inputBt, err := contractInput.Encode()
 	if err != nil {
@@ -40,14 +75,15 @@ func DryRunInk[T any](
 	client := contractIns.Client()
 	addres := contractIns.ContractAddress()
 
-	if client.Debug {
+	if client.IsDebug() {
 		util.LogWithPurple("[        contract ]", addres.Hex())
 		util.LogWithPurple("[          origin ]", origin.ToHexString())
 		util.LogWithPurple("[            args ]", "0x"+hex.EncodeToString(inputBt))
 	}
 
 	result := util.ContractResult{}
-	err = client.CallRuntimeApi(
+	err = client.CallRuntimeApiContext(
+		ctx,
 		"ReviveApi",
 		"call",
 		[]any{
@@ -61,43 +97,31 @@ func DryRunInk[T any](
 
 	var returnValue *util.ExecReturnValue
 	if result.Result.IsErr {
-		if result.Result.E.IsModule {
-			merr := result.Result.E.AsModuleField0
-			info, ierr := client.GetErrorInfo(merr.Index, merr.Error)
-			if ierr == nil {
-				err = errors.New("DryRun: Module Error: " + info.Name)
-			} else {
-				err = errors.New("DryRun: unknown Module Error")
-			}
-			return nil, nil, err
-		}
-		bt, _ := json.Marshal(result.Result.E)
-		return nil, nil, errors.New(string(bt))
+		return nil, nil, fmt.Errorf("DryRun: %w", client.WrapDispatchError(result.Result.E))
 	}
 
 	// 获取返回值
 	returnValue = &result.Result.V
+	if client.IsDebug() {
+		util.LogWithPurple("[           data ]", "0x"+hex.EncodeToString(returnValue.Data))
+	}
+
+	// 判断是否执行错误，回滚数据解码为合约错误
+	if returnValue.Flags == 1 {
+		return nil, nil, DecodeContractRevert[T](returnValue.Data)
+	}
 	if len(returnValue.Data) == 0 {
 		return nil, nil, errors.New("DryRun: returnValue.Data is empty, maybe delegate_call failed with Revive.ContractTrapped")
 	}
 
 	data := new(T)
 
-	if client.Debug {
-		util.LogWithPurple("[           data ]", "0x"+hex.EncodeToString(returnValue.Data))
-	}
-
 	// pallet-revive ExecReturnValue.Data 为合约原始返回，无 selector 前缀时直接解码
 	err = scale.NewDecoder(bytes.NewReader(returnValue.Data)).Decode(data)
 	if err != nil {
 		return nil, nil, errors.New("DryRun scale.NewDecoder.Decode: " + err.Error())
 	}
 
-	// 判断是否执行错误
-	if returnValue.Flags == 1 {
-		return data, nil, ErrContractReverted
-	}
-
 	var storageDeposit types.U128
 	if result.StorageDeposit.IsCharge {
 		storageDeposit = result.StorageDeposit.AsChargeField0
@@ -119,21 +143,45 @@ func CallInk(
 	storage_deposit_limit types.U128,
 	contractInput util.InkContractInput,
 	__ink_params ExecParams,
-) error {
+) (*TxReceipt, error) {
+	return CallInkContext(context.Background(), contractIns, gas_limit, storage_deposit_limit, contractInput, __ink_params)
+}
+
+// Call contract use substrate api with context
+func CallInkContext(
+	ctx context.Context,
+	contractIns Ink,
+	gas_limit types.Weight,
+	storage_deposit_limit types.U128,
+	contractInput util.InkContractInput,
+	__ink_params ExecParams,
+) (*TxReceipt, error) {
 	inputBt, err := contractInput.Encode()
 	if err != nil {
-		return errors.New("contractInput.Encode: " + err.Error())
+		return nil, errors.New("contractInput.Encode: " + err.Error())
 	}
 
 	client := contractIns.Client()
 	addres := contractIns.ContractAddress()
 
-	if client.Debug {
+	if client.IsDebug() {
 		util.LogWithYellow("[         RefTime ]", gas_limit.RefTime.Int64())
 		util.LogWithYellow("[       ProofSize ]", gas_limit.ProofSize.Int64())
 		util.LogWithYellow("[    DepositLimit ]", storage_deposit_limit.Int.String())
 	}
 
+	if __ink_params.Eth != nil {
+		if __ink_params.Multisig != nil || __ink_params.Proxy != nil {
+			return nil, errors.New("multisig and proxy can not be used with Ethereum transaction")
+		}
+		return client.SubmitEthTransactionContext(ctx, __ink_params.Signer, EthTransaction{
+			To:           &addres,
+			Value:        __ink_params.PayAmount,
+			Data:         inputBt,
+			EthTxOptions: *__ink_params.Eth,
+		}, __ink_params.SubmitOptions)
+	}
+
 	storageDepositLimit := big.NewInt(0)
 	if storage_deposit_limit.Int != nil {
 		storageDepositLimit = storage_deposit_limit.Int
@@ -152,10 +200,22 @@ func CallInk(
 
 	call, err := (runtimeCall).AsCall()
 	if err != nil {
-		return errors.New("(runtimeCall).AsCall() error: " + err.Error())
+		return nil, errors.New("(runtimeCall).AsCall() error: " + err.Error())
+	}
+
+	// 多签账户也可以作为代理，先包装为代理调用再由多签账户提交
+	// multisig account can also be a proxy, the call is wrapped for proxy before it is submitted by multisig account
+	if __ink_params.Proxy != nil {
+		call, err = client.ProxyCall(*__ink_params.Proxy, call)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if __ink_params.Multisig != nil {
+		return client.AsMultiContext(ctx, __ink_params.Signer, __ink_params.Multisig, call, types.Weight{}, __ink_params.SubmitOptions)
 	}
 
-	return client.SignAndSubmit(__ink_params.Signer, call, true, 0)
+	return client.SignAndSubmitWithOptions(ctx, __ink_params.Signer, call, __ink_params.SubmitOptions)
 }
 
 func CallOfTransaction(
@@ -173,7 +233,7 @@ func CallOfTransaction(
 	client := contractIns.Client()
 	addres := contractIns.ContractAddress()
 
-	if client.Debug {
+	if client.IsDebug() {
 		util.LogWithYellow("[ Call contract ]", addres.Hex())
 		util.LogWithYellow("[        method ]", contractInput.Selector)
 		util.LogWithYellow("[          args ]", "0x"+hex.EncodeToString(inputBt))
@@ -209,6 +269,12 @@ type DryRunParams struct {
 	PayAmount           types.U128
 	GasLimit            util.Option[types.Weight]
 	StorageDepositLimit util.Option[types.U128]
+	// Origin 的签名类型，用于估算交易费用，默认为 sr25519
+	// key type of Origin which is used to estimate the fee of transaction, sr25519 by default
+	KeyType uint8
+	// 生成代码的 DryRun* 方法是否估算交易费用，DefaultParamWithOrigin 中为 false
+	// whether generated DryRun* methods estimate the fee of transaction, false in DefaultParamWithOrigin
+	EstimateFee bool
 }
 
 func DefaultParamWithOrigin(origin types.AccountID) DryRunParams {
@@ -221,19 +287,74 @@ func DefaultParamWithOrigin(origin types.AccountID) DryRunParams {
 	return defaultParam
 }
 
+// 使用签名者账户和签名类型的默认预执行参数
+// Default DryRunParams with account and key type of signer
+func DefaultParamWithSigner(signer SignerType) DryRunParams {
+	param := DefaultParamWithOrigin(signer.AccountID())
+	param.KeyType = signer.SignType()
+	return param
+}
+
 // DryRun return gas consumed
 type DryRunReturnGas struct {
 	GasConsumed    types.Weight
 	GasRequired    types.Weight
 	StorageDeposit types.U128
+	// 交易费用，DryRunParams.EstimateFee 为 true 时由生成代码的 DryRun* 方法填充
+	// fee of transaction, filled by generated DryRun* methods when DryRunParams.EstimateFee is true
+	Fee *FeeEstimate
 }
 
 // Call param of Call
 type ExecParams struct {
-	Signer         SignerType
-	PayAmount      types.U128
-	UntilFinalized bool
-	Nonce          uint64
+	Signer    SignerType
+	PayAmount types.U128
+	// 不为 nil 时通过 Revive.eth_transact 以以太坊交易提交，Signer 需为 ecdsa 签名者
+	// submit as Ethereum transaction by Revive.eth_transact when it is not nil, Signer must be ecdsa signer
+	Eth *EthTxOptions
+	// 不为 nil 时以多签账户调用合约，Signer 为其中一个签名者，通过 Multisig.as_multi 提交
+	// call contract from multisig account when it is not nil, Signer is one of signatories and it is submitted by Multisig.as_multi
+	Multisig *Multisig
+	// 不为 nil 时 Signer 作为代理以 Proxy.Real 的身份调用合约，通过 Proxy.proxy 提交
+	// Signer calls contract on behalf of Proxy.Real as its proxy when it is not nil, it is submitted by Proxy.proxy
+	Proxy *ProxyOptions
+	SubmitOptions
+}
+
+// 交易来源账户，以太坊交易为以太坊地址的回退账户，多签交易为多签账户，代理交易为被代理的账户。
+// 已映射的以太坊地址需通过 OriginContext 解析
+// Origin account of transaction, it is the fallback account of Ethereum address for Ethereum transaction,
+// the multisig account for multisig transaction and the real account for proxy transaction.
+// The account of a mapped Ethereum address is resolved by OriginContext
+func (p ExecParams) Origin() types.AccountID {
+	if p.Proxy != nil {
+		return p.Proxy.Real
+	}
+	if p.Multisig != nil {
+		return p.Multisig.AccountID()
+	}
+	if ethSigner, ok := p.Signer.(EthSignerType); ok && p.Eth != nil {
+		if address, err := ethSigner.EthAddress(); err == nil {
+			return util.AccountIDFromH160(address)
+		}
+	}
+	return p.Signer.AccountID()
+}
+
+// 交易来源账户，以太坊交易为 InkClient.EthAccountIDContext 解析的账户，已映射的地址为原账户
+// Origin account of transaction, the account of Ethereum transaction is resolved by InkClient.EthAccountIDContext,
+// which is the original account for mapped address
+func (p ExecParams) OriginContext(ctx context.Context, client InkClient) (types.AccountID, error) {
+	if p.Proxy == nil && p.Multisig == nil && p.Eth != nil {
+		if ethSigner, ok := p.Signer.(EthSignerType); ok {
+			address, err := ethSigner.EthAddress()
+			if err != nil {
+				return types.AccountID{}, err
+			}
+			return client.EthAccountIDContext(ctx, address)
+		}
+	}
+	return p.Origin(), nil
 }
 
 // Call param of Call
@@ -242,12 +363,19 @@ type DeployParams struct {
 	Signer SignerType
 	Code   util.InkCode
 	Salt   util.Option[[32]byte]
+	SubmitOptions
+}
+
+func (c *ChainClient) UploadInkCode(code []byte, signer SignerType, opts SubmitOptions) (*types.H256, *TxReceipt, error) {
+	return c.UploadInkCodeContext(context.Background(), code, signer, opts)
 }
 
-func (c *ChainClient) UploadInkCode(code []byte, signer SignerType) (*types.H256, error) {
+// Upload contract code with context
+func (c *ChainClient) UploadInkCodeContext(ctx context.Context, code []byte, signer SignerType, opts SubmitOptions) (*types.H256, *TxReceipt, error) {
 	resultWrap := util.Result[util.UploadResult, gtypes.DispatchError]{}
 	origin := signer.AccountID()
-	err := c.CallRuntimeApi(
+	err := c.CallRuntimeApiContext(
+		ctx,
 		"ReviveApi",
 		"upload_code",
 		[]any{
@@ -256,50 +384,44 @@ func (c *ChainClient) UploadInkCode(code []byte, signer SignerType) (*types.H256
 		&resultWrap,
 	)
 	if err != nil {
-		return nil, errors.New("CallRuntimeApi: " + err.Error())
+		return nil, nil, errors.New("CallRuntimeApi: " + err.Error())
 	}
 
 	if resultWrap.IsErr {
-		if resultWrap.E.IsModule {
-			merr := resultWrap.E.AsModuleField0
-			info, ierr := c.GetErrorInfo(merr.Index, merr.Error)
-			if ierr == nil {
-				err = errors.New("DryRun: Module Error: " + info.Name)
-			} else {
-				err = errors.New("DryRun: unknown Module Error")
-			}
-			return nil, err
-		}
-		bt, _ := json.Marshal(resultWrap.E)
-		return nil, errors.New(string(bt))
+		return nil, nil, fmt.Errorf("DryRun: %w", c.WrapDispatchError(resultWrap.E))
 	}
 
 	result, err := resultWrap.UnWrap()
 	if err != nil {
-		return nil, errors.New("UnWrap: " + err.Error())
+		return nil, nil, errors.New("UnWrap: " + err.Error())
 	}
 
 	runtimeCall := revive.MakeUploadCodeCall(code, types.NewUCompact(result.Deposit.Int))
 	call, err := (runtimeCall).AsCall()
 	if err != nil {
-		return nil, errors.New("(runtimeCall).AsCall() error: " + err.Error())
+		return nil, nil, errors.New("(runtimeCall).AsCall() error: " + err.Error())
 	}
 
-	err = c.SignAndSubmit(signer, call, true, 0)
+	receipt, err := c.SignAndSubmitWithOptions(ctx, signer, call, opts)
 	if err != nil {
-		return nil, errors.New("SignAndSubmit error: " + err.Error())
+		return nil, receipt, fmt.Errorf("SignAndSubmit: %w", err)
 	}
 
-	return &result.CodeHash, nil
+	return &result.CodeHash, receipt, nil
+}
+
+func (c *ChainClient) DeployContract(code util.InkCode, signer SignerType, payAmount types.U128, args util.InkContractInput, salt util.Option[[32]byte], opts SubmitOptions) (*types.H160, *TxReceipt, error) {
+	return c.DeployContractContext(context.Background(), code, signer, payAmount, args, salt, opts)
 }
 
-func (c *ChainClient) DeployContract(code util.InkCode, signer SignerType, payAmount types.U128, args util.InkContractInput, salt util.Option[[32]byte]) (*types.H160, error) {
+// Deploy contract with context
+func (c *ChainClient) DeployContractContext(ctx context.Context, code util.InkCode, signer SignerType, payAmount types.U128, args util.InkContractInput, salt util.Option[[32]byte], opts SubmitOptions) (*types.H160, *TxReceipt, error) {
 	resultWrap := util.ContractInitResult{}
 	origin := signer.AccountID()
 
 	argData, err := args.Encode()
 	if err != nil {
-		return nil, errors.New("args.Encode: " + err.Error())
+		return nil, nil, errors.New("args.Encode: " + err.Error())
 	}
 
 	if c.Debug {
@@ -308,7 +430,8 @@ func (c *ChainClient) DeployContract(code util.InkCode, signer SignerType, payAm
 		util.LogWithPurple("[          args ]", "0x"+hex.EncodeToString(argData))
 	}
 
-	err = c.CallRuntimeApi(
+	err = c.CallRuntimeApiContext(
+		ctx,
 		"ReviveApi",
 		"instantiate",
 		[]any{
@@ -323,33 +446,22 @@ func (c *ChainClient) DeployContract(code util.InkCode, signer SignerType, payAm
 		&resultWrap,
 	)
 	if err != nil {
-		return nil, errors.New("CallRuntimeApi: " + err.Error())
+		return nil, nil, errors.New("CallRuntimeApi: " + err.Error())
 	}
 
 	/// check runtime_api error
 	if resultWrap.Result.IsErr {
-		if resultWrap.Result.E.IsModule {
-			merr := resultWrap.Result.E.AsModuleField0
-			info, ierr := c.GetErrorInfo(merr.Index, merr.Error)
-			if ierr == nil {
-				err = errors.New("DryRun: Module Error: " + info.Name)
-			} else {
-				err = errors.New("DryRun: unknown Module Error")
-			}
-			return nil, err
-		}
-		bt, _ := json.Marshal(resultWrap.Result.E)
-		return nil, errors.New(string(bt))
+		return nil, nil, fmt.Errorf("DryRun: %w", c.WrapDispatchError(resultWrap.Result.E))
 	}
 
 	result, err := resultWrap.Result.UnWrap()
 	if err != nil {
-		return nil, errors.New("UnWrap: " + err.Error())
+		return nil, nil, errors.New("UnWrap: " + err.Error())
 	}
 
 	// 判断是否执行错误
 	if result.Result.Flags == 1 {
-		return nil, ErrContractReverted
+		return nil, nil, DecodeContractRevert[util.NullTuple](result.Result.Data)
 	}
 
 	// init salt
@@ -407,14 +519,14 @@ func (c *ChainClient) DeployContract(code util.InkCode, signer SignerType, payAm
 	}
 	call, err := (runtimeCall).AsCall()
 	if err != nil {
-		return nil, errors.New("(runtimeCall).AsCall() error: " + err.Error())
+		return nil, nil, errors.New("(runtimeCall).AsCall() error: " + err.Error())
 	}
 
 	// submit call
-	err = c.SignAndSubmit(signer, call, true, 0)
+	receipt, err := c.SignAndSubmitWithOptions(ctx, signer, call, opts)
 	if err != nil {
-		return nil, errors.New("SignAndSubmit error: " + err.Error())
+		return nil, receipt, fmt.Errorf("SignAndSubmit: %w", err)
 	}
 
-	return &result.AccountID, nil
+	return &result.AccountID, receipt, nil
 }
@@ -0,0 +1,126 @@
+package ink
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/wetee-dao/ink.go/pallet/proxy"
+	gtypes "github.com/wetee-dao/ink.go/pallet/types"
+	"github.com/wetee-dao/ink.go/util"
+)
+
+type mockInkClient struct {
+	data    []byte
+	calls   []types.Call
+	origins []types.AccountID
+	// 已映射的以太坊地址
+	// mapped Ethereum addresses
+	mapped map[types.H160]types.AccountID
+}
+
+func (m *mockInkClient) IsDebug() bool { return false }
+
+func (m *mockInkClient) CallRuntimeApiContext(ctx context.Context, pallet, method string, args []any, result any) error {
+	r, ok := result.(*util.ContractResult)
+	if !ok || pallet != "ReviveApi" || method != "call" {
+		return errors.New("unexpected runtime api " + pallet + "_" + method)
+	}
+	m.origins = append(m.origins, args[0].(types.AccountID))
+	r.WeightRequired = gtypes.Weight{RefTime: types.NewUCompactFromUInt(100), ProofSize: types.NewUCompactFromUInt(10)}
+	r.Result.V = util.ExecReturnValue{Data: m.data}
+	return nil
+}
+
+func (m *mockInkClient) WrapDispatchError(d gtypes.DispatchError) *DispatchError {
+	return &DispatchError{Err: d}
+}
+
+func (m *mockInkClient) SignAndSubmitWithOptions(ctx context.Context, signer SignerType, call types.Call, opts SubmitOptions) (*TxReceipt, error) {
+	m.calls = append(m.calls, call)
+	return &TxReceipt{Success: true}, nil
+}
+
+func (m *mockInkClient) SubmitEthTransactionContext(ctx context.Context, signer SignerType, tx EthTransaction, opts SubmitOptions) (*TxReceipt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (m *mockInkClient) AsMultiContext(ctx context.Context, signer SignerType, multisig *Multisig, call types.Call, maxWeight types.Weight, opts SubmitOptions) (*TxReceipt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (m *mockInkClient) ProxyCall(opts ProxyOptions, call types.Call) (types.Call, error) {
+	return proxyCall(&gtypes.Meta, opts, call)
+}
+
+func (m *mockInkClient) EstimateFeeContext(ctx context.Context, call types.Call, signer SignerType) (*FeeEstimate, error) {
+	return &FeeEstimate{}, nil
+}
+
+func (m *mockInkClient) GetContractStorageContext(ctx context.Context, address types.H160, key []byte) ([]byte, error) {
+	return nil, nil
+}
+
+func (m *mockInkClient) WatchContractEventsContext(ctx context.Context, address types.H160, topic *types.Hash) (*EventSubscription[InkEvent], error) {
+	return nil, errors.New("not supported")
+}
+
+func (m *mockInkClient) EthAccountIDContext(ctx context.Context, address types.H160) (types.AccountID, error) {
+	if account, ok := m.mapped[address]; ok {
+		return account, nil
+	}
+	return util.AccountIDFromH160(address), nil
+}
+
+type mockInk struct {
+	client InkClient
+}
+
+func (c *mockInk) Client() InkClient           { return c.client }
+func (c *mockInk) ContractAddress() types.H160 { return types.H160{1} }
+
+func TestInkWithMockClient(t *testing.T) {
+	data, _ := codec.Encode(uint32(7))
+	client := &mockInkClient{data: data}
+	contract := &mockInk{client: client}
+	input := util.InkContractInput{Selector: "0x12345678", Args: []any{uint32(1)}}
+
+	v, gas, err := DryRunInk[uint32](contract, types.AccountID{}, types.NewU128(*big.NewInt(0)), util.NewNone[types.Weight](), util.NewNone[types.U128](), input)
+	if err != nil || *v != 7 || gas.GasRequired.RefTime.Int64() != 100 {
+		t.Fatalf("DryRunInk: %v %v %v", v, gas, err)
+	}
+
+	signer, err := Ed25519PairFromSecret("//Alice", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipt, err := CallInk(contract, gas.GasRequired, gas.StorageDeposit, input, ExecParams{Signer: &signer, PayAmount: types.NewU128(*big.NewInt(0))})
+	if err != nil || !receipt.Success || len(client.calls) != 1 {
+		t.Fatalf("CallInk: %v %v", receipt, err)
+	}
+
+	// 代理调用包装为 Proxy.proxy
+	// proxy call is wrapped in Proxy.proxy
+	realAccount := types.AccountID{2}
+	params := ExecParams{Signer: &signer, PayAmount: types.NewU128(*big.NewInt(0)), Proxy: &ProxyOptions{Real: realAccount}}
+	if params.Origin() != realAccount {
+		t.Errorf("origin of proxy call %x", params.Origin())
+	}
+	if _, err := CallInk(contract, gas.GasRequired, gas.StorageDeposit, input, params); err != nil || len(client.calls) != 2 {
+		t.Fatalf("CallInk with proxy: %v", err)
+	}
+	var contractCall gtypes.RuntimeCall
+	encodedCall, _ := codec.Encode(client.calls[0])
+	if err := codec.Decode(encodedCall, &contractCall); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := codec.Encode(proxy.MakeProxyCall(gtypes.MultiAddress{IsId: true, AsIdField0: realAccount}, gtypes.OptionTProxyType{IsNone: true}, contractCall))
+	got, _ := codec.Encode(client.calls[1])
+	if !bytes.Equal(got, want) {
+		t.Errorf("proxy call %x, want %x", got, want)
+	}
+}
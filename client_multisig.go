@@ -0,0 +1,229 @@
+package ink
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/wetee-dao/ink.go/pallet/multisig"
+	gtypes "github.com/wetee-dao/ink.go/pallet/types"
+	"github.com/wetee-dao/ink.go/util"
+	"golang.org/x/crypto/blake2b"
+)
+
+// 多签账户，由签名者和阈值确定
+// Multisig account, it is determined by signatories and threshold
+type Multisig struct {
+	// 排序后的签名者
+	// sorted signatories
+	Signatories []types.AccountID
+	// 执行调用所需的批准数
+	// number of approvals needed to dispatch call
+	Threshold uint16
+}
+
+// 创建多签账户，签名者会被排序
+// Create multisig account, signatories are sorted
+func NewMultisig(threshold uint16, signatories ...types.AccountID) (*Multisig, error) {
+	sorted := slices.Clone(signatories)
+	slices.SortFunc(sorted, func(a, b types.AccountID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			return nil, fmt.Errorf("duplicate signatory %x", sorted[i])
+		}
+	}
+	if threshold == 0 || int(threshold) > len(sorted) {
+		return nil, fmt.Errorf("threshold %d is out of range of %d signatories", threshold, len(sorted))
+	}
+
+	return &Multisig{Signatories: sorted, Threshold: threshold}, nil
+}
+
+// 多签账户地址，与 pallet-multisig 的 multi_account_id 相同
+// Account of multisig, same as multi_account_id of pallet-multisig
+func (m *Multisig) AccountID() types.AccountID {
+	signatories, _ := codec.Encode(m.Signatories)
+	threshold, _ := codec.Encode(m.Threshold)
+
+	data := append([]byte("modlpy/utilisuba"), signatories...)
+	data = append(data, threshold...)
+	return types.AccountID(blake2b.Sum256(data))
+}
+
+// 除签名者外的其他签名者，按顺序排列
+// Sorted signatories other than signer
+func (m *Multisig) otherSignatories(signer types.AccountID) ([][32]byte, error) {
+	others := make([][32]byte, 0, len(m.Signatories))
+	found := false
+	for _, s := range m.Signatories {
+		if s == signer {
+			found = true
+			continue
+		}
+		others = append(others, s)
+	}
+	if !found {
+		return nil, fmt.Errorf("%x is not a signatory of multisig", signer)
+	}
+
+	return others, nil
+}
+
+// 调用的哈希，用于标识多签操作
+// Hash of call, it identifies the multisig operation
+func CallHash(call types.Call) ([32]byte, error) {
+	encodedCall, err := codec.Encode(call)
+	if err != nil {
+		return [32]byte{}, errors.New("Codec.Encode error: " + err.Error())
+	}
+	return blake2b.Sum256(encodedCall), nil
+}
+
+// 查询进行中的多签操作，操作不存在时返回 false
+// Get the open multisig operation of call hash, false is returned when it does not exist
+func (c *ChainClient) MultisigOperation(m *Multisig, callHash [32]byte) (*gtypes.Multisig, bool, error) {
+	return c.MultisigOperationContext(context.Background(), m, callHash)
+}
+
+// 查询进行中的多签操作
+// Get the open multisig operation of call hash with context
+func (c *ChainClient) MultisigOperationContext(ctx context.Context, m *Multisig, callHash [32]byte) (*gtypes.Multisig, bool, error) {
+	key, err := multisig.MakeMultisigsStorageKey(m.AccountID(), callHash)
+	if err != nil {
+		return nil, false, err
+	}
+
+	op := &gtypes.Multisig{}
+	ok, err := c.getStorageContext(ctx, key, op, nil)
+	if err != nil {
+		return nil, false, errors.New("Multisig.Multisigs: " + err.Error())
+	}
+
+	return op, ok, nil
+}
+
+// 批准多签调用但不执行，第一次批准时创建多签操作，之后的批准使用链上的时间点
+// Approve multisig call without dispatching it, the first approval opens the operation
+// and later approvals use its timepoint on chain
+func (c *ChainClient) ApproveAsMulti(signer SignerType, m *Multisig, call types.Call, opts SubmitOptions) (*TxReceipt, error) {
+	return c.ApproveAsMultiContext(context.Background(), signer, m, call, opts)
+}
+
+// 批准多签调用但不执行
+// Approve multisig call without dispatching it with context
+func (c *ChainClient) ApproveAsMultiContext(ctx context.Context, signer SignerType, m *Multisig, call types.Call, opts SubmitOptions) (*TxReceipt, error) {
+	others, err := m.otherSignatories(signer.AccountID())
+	if err != nil {
+		return nil, err
+	}
+	callHash, err := CallHash(call)
+	if err != nil {
+		return nil, err
+	}
+	timepoint, err := c.multisigTimepoint(ctx, m, callHash, signer.AccountID())
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Debug {
+		account := m.AccountID()
+		util.LogWithPurple("[   Approve multi ]", account.ToHexString())
+		util.LogWithPurple("[       call hash ]", types.NewHash(callHash[:]).Hex())
+	}
+
+	// max_weight 只在执行调用时使用，approve_as_multi 不会执行调用
+	// max_weight is only used when dispatching, which approve_as_multi never does
+	runtimeCall := multisig.MakeApproveAsMultiCall(m.Threshold, others, timepoint, callHash, gtypes.Weight{
+		RefTime:   types.NewUCompactFromUInt(0),
+		ProofSize: types.NewUCompactFromUInt(0),
+	})
+	approveCall, err := runtimeCall.AsCall()
+	if err != nil {
+		return nil, errors.New("(runtimeCall).AsCall() error: " + err.Error())
+	}
+
+	return c.SignAndSubmitWithOptions(ctx, signer, approveCall, opts)
+}
+
+// 批准多签调用，达到阈值时以多签账户执行调用，阈值为 1 时立即执行。
+// maxWeight 为 0 时使用以多签账户估算的调用权重
+// Approve multisig call and dispatch it from multisig account when threshold is reached, it is dispatched at once when threshold is 1.
+// The weight of call estimated with multisig account is used when maxWeight is zero
+func (c *ChainClient) AsMulti(signer SignerType, m *Multisig, call types.Call, maxWeight types.Weight, opts SubmitOptions) (*TxReceipt, error) {
+	return c.AsMultiContext(context.Background(), signer, m, call, maxWeight, opts)
+}
+
+// 批准多签调用，达到阈值时执行调用
+// Approve multisig call and dispatch it when threshold is reached with context
+func (c *ChainClient) AsMultiContext(ctx context.Context, signer SignerType, m *Multisig, call types.Call, maxWeight types.Weight, opts SubmitOptions) (*TxReceipt, error) {
+	others, err := m.otherSignatories(signer.AccountID())
+	if err != nil {
+		return nil, err
+	}
+
+	// 调用直接编码在多签调用中，不需要解码为 RuntimeCall
+	// call is encoded into multisig call as it is, so it is never decoded to RuntimeCall
+	var multiCall types.Call
+	if m.Threshold == 1 {
+		multiCall, err = types.NewCall(c.Meta, "Multisig.as_multi_threshold_1", others, call)
+	} else {
+		var callHash [32]byte
+		callHash, err = CallHash(call)
+		if err != nil {
+			return nil, err
+		}
+		var timepoint gtypes.OptionTTimepoint
+		timepoint, err = c.multisigTimepoint(ctx, m, callHash, signer.AccountID())
+		if err != nil {
+			return nil, err
+		}
+
+		weight := gtypes.Weight{RefTime: maxWeight.RefTime, ProofSize: maxWeight.ProofSize}
+		refTime := big.Int(maxWeight.RefTime)
+		proofSize := big.Int(maxWeight.ProofSize)
+		if refTime.Sign() == 0 && proofSize.Sign() == 0 {
+			fee, err := c.estimateFee(ctx, call, m.AccountID(), signer.SignType(), SubmitOptions{})
+			if err != nil {
+				return nil, err
+			}
+			weight = fee.Weight
+		}
+
+		if c.Debug {
+			account := m.AccountID()
+			util.LogWithPurple("[        As multi ]", account.ToHexString())
+			util.LogWithPurple("[       call hash ]", types.NewHash(callHash[:]).Hex())
+		}
+
+		multiCall, err = types.NewCall(c.Meta, "Multisig.as_multi", m.Threshold, others, timepoint, call, weight)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("new multisig call error: %w", err)
+	}
+
+	return c.SignAndSubmitWithOptions(ctx, signer, multiCall, opts)
+}
+
+// 链上多签操作的时间点，操作不存在时为 None
+// Timepoint of the multisig operation on chain, None when it is not opened
+func (c *ChainClient) multisigTimepoint(ctx context.Context, m *Multisig, callHash [32]byte, signer types.AccountID) (gtypes.OptionTTimepoint, error) {
+	op, ok, err := c.MultisigOperationContext(ctx, m, callHash)
+	if err != nil {
+		return gtypes.OptionTTimepoint{}, err
+	}
+	if !ok {
+		return gtypes.OptionTTimepoint{IsNone: true}, nil
+	}
+	if slices.Contains(op.Approvals, [32]byte(signer)) {
+		return gtypes.OptionTTimepoint{}, fmt.Errorf("%x has already approved the multisig operation", signer)
+	}
+
+	return gtypes.OptionTTimepoint{IsSome: true, AsSomeField0: op.When}, nil
+}
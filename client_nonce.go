@@ -0,0 +1,112 @@
+package ink
+
+import (
+	"context"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// nonce 管理器，在本地为同一账户分配 nonce，多个 goroutine 可以并发提交交易
+// Nonce manager, reserves nonces of an account locally so that many goroutines
+// can pipeline transactions of the same signer without waiting for each other
+type NonceManager struct {
+	mu       sync.Mutex
+	accounts map[types.AccountID]*accountNonce
+	fetch    func(ctx context.Context, account types.AccountID) (uint64, error)
+}
+
+type accountNonce struct {
+	mu     sync.Mutex
+	synced bool
+	next   uint64
+	// 已分配但未归还或完成的 nonce，重新同步时不会再次分配
+	// nonces reserved but not released or done yet, they are not reserved again after resyncing
+	pending map[uint64]struct{}
+}
+
+// 创建 nonce 管理器，fetch 用于从链上同步账户的下一个 nonce
+// Create nonce manager, fetch returns the next nonce of account from the chain
+func NewNonceManager(fetch func(ctx context.Context, account types.AccountID) (uint64, error)) *NonceManager {
+	return &NonceManager{
+		accounts: make(map[types.AccountID]*accountNonce),
+		fetch:    fetch,
+	}
+}
+
+func (m *NonceManager) account(account types.AccountID) *accountNonce {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	a, ok := m.accounts[account]
+	if !ok {
+		a = &accountNonce{pending: map[uint64]struct{}{}}
+		m.accounts[account] = a
+	}
+	return a
+}
+
+// 分配下一个 nonce，本地未同步时先从链上同步，同步后的 nonce 不小于未完成的 nonce
+// 分配的 nonce 需要通过 Release 或 Done 结束
+// Reserve the next nonce of account, sync it from the chain first if needed,
+// the synced nonce is above all pending ones. The reserved nonce must be ended by Release or Done
+func (m *NonceManager) Next(ctx context.Context, account types.AccountID) (uint64, error) {
+	a := m.account(account)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.synced {
+		next, err := m.fetch(ctx, account)
+		if err != nil {
+			return 0, err
+		}
+		for nonce := range a.pending {
+			next = max(next, nonce+1)
+		}
+		a.next = next
+		a.synced = true
+	}
+
+	nonce := a.next
+	a.next++
+	a.pending[nonce] = struct{}{}
+	return nonce, nil
+}
+
+// 归还未提交到链上的 nonce
+// 不是最后分配的 nonce 时无法归还，下次分配前重新同步
+// Release a nonce which is not submitted to the chain,
+// the account is resynced on next reservation if nonce is not the last reserved one
+func (m *NonceManager) Release(account types.AccountID, nonce uint64) {
+	a := m.account(account)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.pending, nonce)
+	if a.synced && a.next == nonce+1 {
+		a.next = nonce
+		return
+	}
+	a.synced = false
+}
+
+// 结束已提交交易的 nonce，交易上链、失败或不再等待其结果时调用
+// End the nonce of a submitted transaction, called when it is included, fails or is no longer watched
+func (m *NonceManager) Done(account types.AccountID, nonce uint64) {
+	a := m.account(account)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.pending, nonce)
+}
+
+// 标记账户需要重新同步 nonce，用于交易失败、被丢弃或被替换时，未完成的 nonce 不会再次分配
+// Mark account to be resynced on next reservation, used when transaction fails, is dropped or usurped,
+// pending nonces are not reserved again
+func (m *NonceManager) Resync(account types.AccountID) {
+	a := m.account(account)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.synced = false
+}
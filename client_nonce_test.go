@@ -0,0 +1,116 @@
+package ink
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+func TestNonceManager(t *testing.T) {
+	chainNonce := uint64(5)
+	fetches := 0
+	m := NewNonceManager(func(ctx context.Context, account types.AccountID) (uint64, error) {
+		fetches++
+		return chainNonce, nil
+	})
+
+	var account types.AccountID
+	ctx := context.Background()
+
+	// concurrent reservations never share a nonce
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	seen := map[uint64]bool{}
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n, err := m.Next(ctx, account)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if seen[n] {
+				t.Errorf("nonce %d reserved twice", n)
+			}
+			seen[n] = true
+		}()
+	}
+	wg.Wait()
+	if len(seen) != 20 || !seen[5] || !seen[24] || fetches != 1 {
+		t.Fatalf("unexpected nonces %v, fetches %d", seen, fetches)
+	}
+
+	// the last reserved nonce can be given back
+	n, _ := m.Next(ctx, account)
+	m.Release(account, n)
+	if n2, _ := m.Next(ctx, account); n2 != n {
+		t.Fatalf("released nonce %d is not reused, got %d", n, n2)
+	}
+
+	// resync fetches from the chain again once no nonce is pending
+	for n := range seen {
+		m.Done(account, n)
+	}
+	m.Done(account, n)
+	chainNonce = 7
+	m.Resync(account)
+	if n, _ := m.Next(ctx, account); n != 7 || fetches != 2 {
+		t.Fatalf("nonce after resync %d, fetches %d", n, fetches)
+	}
+}
+
+func TestNonceManagerConcurrentResync(t *testing.T) {
+	// 链上 nonce 落后于本地已分配的 nonce，如交易还未进入交易池
+	// nonce of chain is behind the reserved ones, e.g. the transactions are not in the pool yet
+	m := NewNonceManager(func(ctx context.Context, account types.AccountID) (uint64, error) {
+		return 0, nil
+	})
+
+	var account types.AccountID
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	held := map[uint64]bool{}
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 20 {
+				n, err := m.Next(ctx, account)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				mu.Lock()
+				if held[n] {
+					t.Errorf("nonce %d reserved while it is held", n)
+				}
+				held[n] = true
+				mu.Unlock()
+
+				switch (i + j) % 3 {
+				case 0:
+					m.Resync(account)
+				case 1:
+					mu.Lock()
+					delete(held, n)
+					mu.Unlock()
+					m.Release(account, n)
+					continue
+				}
+
+				mu.Lock()
+				delete(held, n)
+				mu.Unlock()
+				m.Done(account, n)
+			}
+		}()
+	}
+	wg.Wait()
+}
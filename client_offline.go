@@ -0,0 +1,281 @@
+package ink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic/extensions"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/wetee-dao/ink.go/util"
+)
+
+// 待离线签名的交易，包含签名所需的全部链上数据，可以 JSON 序列化后带到离线机器签名
+// Extrinsic to be signed offline, it contains all chain data needed for signing,
+// it can be serialized to JSON and signed on an offline machine
+type UnsignedTx struct {
+	// SCALE 编码的调用
+	// SCALE encoded call
+	Call hexutil.Bytes `json:"call"`
+	// 签名账户，nonce 属于该账户
+	// signing account, the nonce belongs to it
+	Account types.AccountID    `json:"account"`
+	Nonce   uint64             `json:"nonce"`
+	Tip     *big.Int           `json:"tip"`
+	Era     types.ExtrinsicEra `json:"era"`
+	// era 的起始区块，永久有效时为创世区块
+	// checkpoint block of era, the genesis block when immortal
+	BlockHash types.Hash `json:"block_hash"`
+	// era 起始区块的高度，永久有效时为 0
+	// number of checkpoint block of era, 0 when immortal
+	BlockNumber        uint64     `json:"block_number"`
+	SpecVersion        uint32     `json:"spec_version"`
+	TransactionVersion uint32     `json:"transaction_version"`
+	GenesisHash        types.Hash `json:"genesis_hash"`
+}
+
+// 在线构建待离线签名的交易，opts.Nonce 为 0 时使用链上账户的下一个 nonce（包含交易池中的交易），
+// opts 中只使用 Nonce、Tip 和 Era。离线签名可能需要很长时间，opts.Era 为 nil 时交易永久有效而不是使用 ChainClient.DefaultEra
+// Build extrinsic to be signed offline, the next nonce of account on chain (including transactions in pool) is used when opts.Nonce is 0,
+// only Nonce, Tip and Era of opts are used. Offline signing may take long, so the extrinsic is immortal
+// instead of using ChainClient.DefaultEra when opts.Era is nil
+func (c *ChainClient) NewUnsignedTx(account types.AccountID, call types.Call, opts SubmitOptions) (*UnsignedTx, error) {
+	return c.NewUnsignedTxContext(context.Background(), account, call, opts)
+}
+
+// 在线构建待离线签名的交易
+// Build extrinsic to be signed offline with context
+func (c *ChainClient) NewUnsignedTxContext(ctx context.Context, account types.AccountID, call types.Call, opts SubmitOptions) (*UnsignedTx, error) {
+	encodedCall, err := codec.Encode(call)
+	if err != nil {
+		return nil, errors.New("Codec.Encode error: " + err.Error())
+	}
+
+	nonce := opts.Nonce
+	if nonce == 0 {
+		nonce, err = c.accountNextIndexContext(ctx, account)
+		if err != nil {
+			return nil, errors.New("System.AccountNextIndex error: " + err.Error())
+		}
+	}
+
+	era := opts.Era
+	if era == nil {
+		era = ImmortalEra()
+	}
+	tx, err := c.unsignedTx(ctx, nonce, opts.Tip, era)
+	if err != nil {
+		return nil, err
+	}
+	tx.Call = encodedCall
+	tx.Account = account
+
+	return tx, nil
+}
+
+// 交易的签名参数
+// Signing options of extrinsic
+func (t *UnsignedTx) SigningOptions() []extrinsic.SigningOption {
+	tip := t.Tip
+	if tip == nil {
+		tip = big.NewInt(0)
+	}
+
+	return []extrinsic.SigningOption{
+		extrinsic.WithEra(t.Era, t.BlockHash),
+		extrinsic.WithNonce(types.NewUCompactFromUInt(t.Nonce)),
+		extrinsic.WithTip(types.NewUCompact(tip)),
+		extrinsic.WithSpecVersion(types.U32(t.SpecVersion)),
+		extrinsic.WithTransactionVersion(types.U32(t.TransactionVersion)),
+		extrinsic.WithGenesisHash(t.GenesisHash),
+		extrinsic.WithMetadataMode(extensions.CheckMetadataModeDisabled, extensions.CheckMetadataHash{Hash: types.NewEmptyOption[types.H256]()}),
+		extrinsic.WithAssetID(types.NewEmptyOption[types.AssetID]()),
+	}
+}
+
+// 未签名的交易
+// Unsigned extrinsic
+func (t *UnsignedTx) Extrinsic() (Extrinsic, error) {
+	if len(t.Call) < 2 {
+		return Extrinsic{}, errors.New("call index is missing")
+	}
+
+	return NewExtrinsic(types.Call{
+		CallIndex: types.CallIndex{SectionIndex: t.Call[0], MethodIndex: t.Call[1]},
+		Args:      types.Args(t.Call[2:]),
+	}), nil
+}
+
+// 使用缓存的元数据解码交易摘要，签名前用于核对交易内容，有限期交易的摘要包含过期区块高度
+// Decode summary of extrinsic with cached metadata, it is used to review the extrinsic before signing,
+// the summary of mortal extrinsic contains the block number it expires at
+func (t *UnsignedTx) Summary(meta *types.Metadata) (*PayloadSummary, error) {
+	ext, err := t.Extrinsic()
+	if err != nil {
+		return nil, err
+	}
+	payload, err := ext.signingPayload(meta, t.SigningOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	calls, err := registry.NewFactory().CreateCallRegistry(meta)
+	if err != nil {
+		return nil, errors.New("CreateCallRegistry: " + err.Error())
+	}
+
+	summary, err := NewPayloadSummary(calls, payload)
+	if err != nil {
+		return nil, err
+	}
+	if summary.Period > 0 {
+		summary.ExpiresAt = t.BlockNumber + summary.Period
+	}
+
+	return summary, nil
+}
+
+// 离线签名，只需要缓存的元数据（如 codec.EncodeToHex(chainClient.Meta) 保存的元数据），返回十六进制的已签名交易
+// Sign offline with cached metadata only (such as metadata saved by codec.EncodeToHex(chainClient.Meta)),
+// the hex signed extrinsic is returned
+func (t *UnsignedTx) Sign(signer SignerType, meta *types.Metadata) (string, error) {
+	if signer.AccountID() != t.Account {
+		return "", fmt.Errorf("signer %x is not the account %x of extrinsic", signer.AccountID(), t.Account)
+	}
+
+	// 交易和元数据来自不同的运行时版本时，调用可能按错误的元数据编码
+	// call may be encoded with wrong metadata when extrinsic and metadata come from different runtime versions
+	version, err := RuntimeVersionOf(meta)
+	if err != nil {
+		return "", err
+	}
+	if uint32(version.SpecVersion) != t.SpecVersion || uint32(version.TransactionVersion) != t.TransactionVersion {
+		return "", fmt.Errorf("versions %d/%d of extrinsic do not match %d/%d of metadata",
+			t.SpecVersion, t.TransactionVersion, version.SpecVersion, version.TransactionVersion)
+	}
+
+	ext, err := t.Extrinsic()
+	if err != nil {
+		return "", err
+	}
+	if err := ext.Sign(signer, meta, t.SigningOptions()...); err != nil {
+		return "", err
+	}
+
+	return codec.EncodeToHex(ext)
+}
+
+// 提交十六进制的已签名交易并等待结果，如离线签名的交易
+// Submit hex signed extrinsic and wait for its status, such as extrinsic signed offline
+func (c *ChainClient) SubmitRaw(raw string, untilFinalized bool) (*TxReceipt, error) {
+	return c.SubmitRawContext(context.Background(), raw, SubmitOptions{UntilFinalized: untilFinalized})
+}
+
+// 提交十六进制的已签名交易并等待结果，opts 中只使用 UntilFinalized 和 Timeout
+// Submit hex signed extrinsic and wait for its status with context, only UntilFinalized and Timeout of opts are used
+func (c *ChainClient) SubmitRawContext(ctx context.Context, raw string, opts SubmitOptions) (*TxReceipt, error) {
+	extBytes, err := codec.HexDecodeString(raw)
+	if err != nil {
+		return nil, errors.New("decode extrinsic: " + err.Error())
+	}
+	account, nonce, signed := decodeExtrinsicSigner(extBytes)
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = DefaultSubmitTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if c.Debug {
+		util.LogWithYellow("[      Submit raw ]", raw)
+	}
+
+	receipt, err := c.submitAndWatchBytes(ctx, extBytes, account, nonce, false, opts.UntilFinalized)
+
+	// nonce 不是由 ChainClient.Nonces 分配的，需要重新同步
+	// the nonce is not reserved from ChainClient.Nonces, so it is synced again
+	if signed {
+		c.Nonces.Resync(account)
+	}
+
+	return receipt, err
+}
+
+// 解码已签名交易的签名账户和 nonce，未签名或地址不是 AccountId 时返回 false
+// Decode signer and nonce of signed extrinsic, false is returned when it is unsigned or the address is not an AccountId
+func decodeExtrinsicSigner(extBytes []byte) (types.AccountID, uint64, bool) {
+	var xt struct {
+		Length    types.UCompact
+		Version   byte
+		Signer    types.MultiAddress
+		Signature types.MultiSignature
+		Era       types.ExtrinsicEra
+		Nonce     types.UCompact
+	}
+	if err := codec.Decode(extBytes, &xt); err != nil {
+		return types.AccountID{}, 0, false
+	}
+	if xt.Version&extrinsic.BitSigned == 0 || !xt.Signer.IsID {
+		return types.AccountID{}, 0, false
+	}
+
+	nonce := big.Int(xt.Nonce)
+	return xt.Signer.AsID, nonce.Uint64(), true
+}
+
+// 元数据中 System.Version 常量记录的运行时版本，可在离线时使用
+// Runtime version recorded by the System.Version constant of metadata, it is available offline
+func RuntimeVersionOf(meta *types.Metadata) (*types.RuntimeVersion, error) {
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if string(pallet.Name) != "System" {
+			continue
+		}
+		for _, constant := range pallet.Constants {
+			if string(constant.Name) != "Version" {
+				continue
+			}
+
+			// sp_version::RuntimeVersion 的 SCALE 编码，与 RPC 返回的 JSON 字段不同
+			// SCALE encoding of sp_version::RuntimeVersion, it differs from the JSON fields returned by RPC
+			var v struct {
+				SpecName         types.Text
+				ImplName         types.Text
+				AuthoringVersion types.U32
+				SpecVersion      types.U32
+				ImplVersion      types.U32
+				Apis             []struct {
+					ID      [8]byte
+					Version types.U32
+				}
+				TransactionVersion types.U32
+			}
+			if err := codec.Decode(constant.Value, &v); err != nil {
+				return nil, errors.New("decode System.Version: " + err.Error())
+			}
+
+			apis := make([]types.RuntimeVersionAPI, 0, len(v.Apis))
+			for _, api := range v.Apis {
+				apis = append(apis, types.RuntimeVersionAPI{APIID: hexutil.Encode(api.ID[:]), Version: api.Version})
+			}
+			return &types.RuntimeVersion{
+				APIs:               apis,
+				AuthoringVersion:   v.AuthoringVersion,
+				ImplName:           string(v.ImplName),
+				ImplVersion:        v.ImplVersion,
+				SpecName:           string(v.SpecName),
+				SpecVersion:        v.SpecVersion,
+				TransactionVersion: v.TransactionVersion,
+			}, nil
+		}
+	}
+
+	return nil, errors.New("constant System.Version not found")
+}
@@ -1,40 +1,374 @@
 package ink
 
 import (
-	"os"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
 
 	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/wetee-dao/ink.go/util"
 )
 
-func (p *ChainClient) Api() *gsrpc.SubstrateAPI {
+var ErrNoConnection = errors.New("chain client: all blockchain connections lost")
+
+var errGenesisMismatch = errors.New("genesis hash is not match")
+
+// 连接池事件类型
+// Type of connection pool event
+type PoolEventType uint8
+
+const (
+	// 连接健康检查失败
+	// a connection failed the health check and is closed
+	PoolConnLost PoolEventType = iota
+	// 连接重连成功
+	// a connection is reconnected
+	PoolConnRestored
+	// 所有连接都不可用
+	// all connections are lost, calls fail with ErrNoConnection
+	PoolDown
+	// 从所有连接不可用中恢复
+	// at least one connection is available again after PoolDown
+	PoolRecovered
+)
+
+func (t PoolEventType) String() string {
+	switch t {
+	case PoolConnLost:
+		return "conn lost"
+	case PoolConnRestored:
+		return "conn restored"
+	case PoolDown:
+		return "pool down"
+	case PoolRecovered:
+		return "pool recovered"
+	}
+	return "unknown"
+}
+
+// 连接池事件
+// Event of connection pool
+type PoolEvent struct {
+	Type PoolEventType
+	URL  string
+	// 可用连接数
+	// number of healthy connections after the event
+	Healthy int
+	Total   int
+	Err     error
+}
+
+// 连接池参数
+// Options of connection pool
+type PoolOptions struct {
+	// 健康检查间隔
+	// interval of background health checks
+	HealthCheckInterval time.Duration
+	// 健康检查超时时间
+	// timeout of a single health check
+	HealthCheckTimeout time.Duration
+	// 重连退避时间，每次失败翻倍直到 MaxBackoff
+	// reconnect backoff, doubled on each failure until MaxBackoff
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// 连接池事件回调，在后台 goroutine 中调用
+	// hook of pool events, called from background goroutines
+	OnEvent func(PoolEvent)
+	// 连接节点的传输层，nil 时使用 WebsocketTransport
+	// transport to connect nodes, WebsocketTransport is used when it is nil
+	Transport Transport
+}
+
+// 默认连接池参数
+// Default options of connection pool
+func DefaultPoolOptions() PoolOptions {
+	return PoolOptions{
+		HealthCheckInterval: 15 * time.Second,
+		HealthCheckTimeout:  5 * time.Second,
+		MinBackoff:          time.Second,
+		MaxBackoff:          time.Minute,
+	}
+}
+
+type poolConn struct {
+	url string
+	// nil when the connection is lost
+	api *gsrpc.SubstrateAPI
+}
+
+// 区块链连接池
+// Pool of blockchain connections
+type connPool struct {
+	mu      sync.Mutex
+	conns   []*poolConn
+	next    int
+	down    bool
+	genesis types.Hash
+	opts    PoolOptions
+	debug   bool
+
+	quit      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+func newConnPool(urls []string, opts PoolOptions, debug bool) *connPool {
+	defaults := DefaultPoolOptions()
+	if opts.HealthCheckInterval <= 0 {
+		opts.HealthCheckInterval = defaults.HealthCheckInterval
+	}
+	if opts.HealthCheckTimeout <= 0 {
+		opts.HealthCheckTimeout = defaults.HealthCheckTimeout
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = defaults.MinBackoff
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = max(defaults.MaxBackoff, opts.MinBackoff)
+	}
+	if opts.Transport == nil {
+		opts.Transport = WebsocketTransport{}
+	}
+
+	conns := make([]*poolConn, 0, len(urls))
+	for _, url := range urls {
+		conns = append(conns, &poolConn{url: url})
+	}
+
+	return &connPool{
+		conns: conns,
+		opts:  opts,
+		debug: debug,
+		quit:  make(chan struct{}),
+	}
+}
+
+// 启动健康检查，连接失败的 url 在后台重连
+// Start health checks, urls failed to connect are reconnected in background
+func (p *connPool) start() {
+	p.mu.Lock()
+	for _, conn := range p.conns {
+		if conn.api == nil {
+			p.wg.Add(1)
+			go p.reconnect(conn)
+		}
+	}
+	p.mu.Unlock()
+
+	p.wg.Add(1)
+	go p.healthLoop()
+}
+
+// 轮询获取可用连接
+// Get a healthy connection in round robin
+func (p *connPool) get() (*gsrpc.SubstrateAPI, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	tries := 0
 	poolSize := len(p.conns)
+	for range poolSize {
+		index := p.next
+		p.next = (p.next + 1) % poolSize
+		if api := p.conns[index].api; api != nil {
+			if p.debug {
+				util.LogWithBlue("INK GET API", index, "POOL LEN", poolSize)
+			}
+			return api, nil
+		}
+	}
+
+	return nil, ErrNoConnection
+}
+
+func (p *connPool) healthLoop() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.opts.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.checkAll()
+		case <-p.quit:
+			return
+		}
+	}
+}
+
+// 检查所有在线连接，失败的连接关闭并后台重连
+// Check all alive connections, failed ones are closed and reconnected in background
+func (p *connPool) checkAll() {
+	p.mu.Lock()
+	alive := make([]*poolConn, 0, len(p.conns))
+	apis := make([]*gsrpc.SubstrateAPI, 0, len(p.conns))
+	for _, conn := range p.conns {
+		if conn.api != nil {
+			alive = append(alive, conn)
+			apis = append(apis, conn.api)
+		}
+	}
+	p.mu.Unlock()
+
+	for i, conn := range alive {
+		ctx, cancel := context.WithTimeout(context.Background(), p.opts.HealthCheckTimeout)
+		var header types.Header
+		err := apis[i].Client.CallContext(ctx, &header, "chain_getHeader")
+		cancel()
+		if err != nil {
+			p.markLost(conn, apis[i], err)
+		}
+	}
+}
+
+func (p *connPool) markLost(conn *poolConn, api *gsrpc.SubstrateAPI, err error) {
+	p.mu.Lock()
+	if conn.api != api {
+		p.mu.Unlock()
+		return
+	}
+	conn.api = nil
+	healthy := p.healthyLocked()
+	down := healthy == 0 && !p.down
+	if down {
+		p.down = true
+	}
+	p.mu.Unlock()
+
+	api.Client.Close()
+	util.LogWithRed("blockchain connection lost", conn.url, err.Error())
+	p.emit(PoolEvent{Type: PoolConnLost, URL: conn.url, Healthy: healthy, Total: len(p.conns), Err: err})
+	if down {
+		util.LogWithRed("All blockchain connections lost")
+		p.emit(PoolEvent{Type: PoolDown, Healthy: 0, Total: len(p.conns), Err: ErrNoConnection})
+	}
+
+	select {
+	case <-p.quit:
+		return
+	default:
+	}
+	p.wg.Add(1)
+	go p.reconnect(conn)
+}
 
-	for tries < poolSize {
-		conn := p.conns[p.currIndex]
-		_, err := conn.RPC.Chain.GetHeaderLatest()
+// 按指数退避重连
+// Reconnect with exponential backoff
+func (p *connPool) reconnect(conn *poolConn) {
+	defer p.wg.Done()
+
+	backoff := p.opts.MinBackoff
+	for {
+		select {
+		case <-time.After(backoff):
+		case <-p.quit:
+			return
+		}
+
+		api, err := p.dial(conn.url)
 		if err == nil {
-			if p.Debug {
-				util.LogWithBlue("INK GET API", p.currIndex, "POOL LEN", poolSize)
+			p.mu.Lock()
+			select {
+			case <-p.quit:
+				p.mu.Unlock()
+				api.Client.Close()
+				return
+			default:
 			}
-			p.currIndex = (p.currIndex + 1) % poolSize
-			tries++
-			return conn
+			conn.api = api
+			healthy := p.healthyLocked()
+			recovered := p.down
+			p.down = false
+			p.mu.Unlock()
+
+			util.LogWithGreen("blockchain connection restored", conn.url)
+			p.emit(PoolEvent{Type: PoolConnRestored, URL: conn.url, Healthy: healthy, Total: len(p.conns)})
+			if recovered {
+				p.emit(PoolEvent{Type: PoolRecovered, Healthy: healthy, Total: len(p.conns)})
+			}
+			return
 		}
 
-		p.CloseConn(tries)
+		backoff = min(backoff*2, p.opts.MaxBackoff)
+		if p.debug {
+			util.LogWithRed("reconnect "+conn.url, err.Error(), "retry in", backoff.String())
+		}
 	}
+}
+
+// 连接 url 并检查创世区块
+// Connect to url and check its genesis hash
+func (p *connPool) dial(url string) (*gsrpc.SubstrateAPI, error) {
+	api, err := newSubstrateAPI(p.opts.Transport, url)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := api.RPC.Chain.GetBlockHash(0)
+	if err != nil {
+		api.Client.Close()
+		return nil, err
+	}
+
+	p.mu.Lock()
+	genesis := p.genesis
+	p.mu.Unlock()
+	if hash != genesis {
+		api.Client.Close()
+		return nil, fmt.Errorf("url %s: %w", url, errGenesisMismatch)
+	}
+
+	return api, nil
+}
 
-	util.LogWithRed("All blockchain connections lost")
-	os.Exit(0)
-	return nil
+func (p *connPool) healthyLocked() int {
+	healthy := 0
+	for _, conn := range p.conns {
+		if conn.api != nil {
+			healthy++
+		}
+	}
+	return healthy
 }
 
-func (p *ChainClient) CloseConn(index int) {
-	p.conns[index].Client.Close()
-	p.conns = append(p.conns[:index], p.conns[index+1:]...)
+func (p *connPool) emit(e PoolEvent) {
+	if p.opts.OnEvent != nil {
+		p.opts.OnEvent(e)
+	}
+}
+
+// 关闭连接池
+// Stop health checks and close all connections
+func (p *connPool) close() {
+	p.closeOnce.Do(func() {
+		close(p.quit)
+		p.wg.Wait()
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		for _, conn := range p.conns {
+			if conn.api != nil {
+				conn.api.Client.Close()
+				conn.api = nil
+			}
+		}
+	})
+}
+
+// 获取可用连接，所有连接不可用时返回 ErrNoConnection
+// Get a healthy connection, ErrNoConnection is returned when all connections are lost
+func (c *ChainClient) Api() (*gsrpc.SubstrateAPI, error) {
+	return c.pool.get()
+}
+
+// 连接池状态
+// Number of healthy connections and total connections of pool
+func (c *ChainClient) PoolStatus() (healthy int, total int) {
+	c.pool.mu.Lock()
+	defer c.pool.mu.Unlock()
+
+	return c.pool.healthyLocked(), len(c.pool.conns)
 }
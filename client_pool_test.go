@@ -0,0 +1,29 @@
+package ink
+
+import (
+	"errors"
+	"testing"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+)
+
+func TestConnPoolGet(t *testing.T) {
+	pool := newConnPool([]string{"ws://a", "ws://b", "ws://c"}, PoolOptions{}, false)
+	a, c := &gsrpc.SubstrateAPI{}, &gsrpc.SubstrateAPI{}
+	pool.conns[0].api = a
+	pool.conns[2].api = c
+
+	// lost connections are skipped in round robin
+	for _, want := range []*gsrpc.SubstrateAPI{a, c, a, c} {
+		api, err := pool.get()
+		if err != nil || api != want {
+			t.Fatalf("get() = %p, %v, want %p", api, err, want)
+		}
+	}
+
+	pool.conns[0].api = nil
+	pool.conns[2].api = nil
+	if _, err := pool.get(); !errors.Is(err, ErrNoConnection) {
+		t.Fatalf("get() error = %v, want ErrNoConnection", err)
+	}
+}
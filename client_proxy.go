@@ -0,0 +1,118 @@
+package ink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/wetee-dao/ink.go/pallet/proxy"
+	gtypes "github.com/wetee-dao/ink.go/pallet/types"
+	"github.com/wetee-dao/ink.go/util"
+)
+
+// 代理调用参数，Signer 作为代理以 Real 账户的身份调用
+// Options of proxy call, Signer calls on behalf of Real as its proxy
+type ProxyOptions struct {
+	// 被代理的账户，即调用的来源账户
+	// account proxied for, which is the origin of call
+	Real types.AccountID
+	// 指定检查的代理类型，nil 时使用第一个允许该调用的代理类型
+	// proxy type checked for the call, the first proxy type allowing the call is used when it is nil
+	ForceProxyType *gtypes.ProxyType
+}
+
+// 将调用包装为 Proxy.proxy，如 CallOf* 生成的调用，调用索引来自当前链上的元数据
+// Wrap call in Proxy.proxy, such as the call built by CallOf*, the call index comes from current metadata of chain
+func (c *ChainClient) ProxyCall(opts ProxyOptions, call types.Call) (types.Call, error) {
+	return proxyCall(c.Meta, opts, call)
+}
+
+func proxyCall(meta *types.Metadata, opts ProxyOptions, call types.Call) (types.Call, error) {
+	forceProxyType := gtypes.OptionTProxyType{IsNone: true}
+	if opts.ForceProxyType != nil {
+		forceProxyType = gtypes.OptionTProxyType{IsSome: true, AsSomeField0: opts.ForceProxyType}
+	}
+
+	// 调用直接编码在代理调用中，不需要解码为 RuntimeCall
+	// call is encoded into proxy call as it is, so it is never decoded to RuntimeCall
+	proxyCall, err := types.NewCall(meta, "Proxy.proxy", gtypes.MultiAddress{IsId: true, AsIdField0: opts.Real}, forceProxyType, call)
+	if err != nil {
+		return types.Call{}, fmt.Errorf("new proxy call error: %w", err)
+	}
+
+	return proxyCall, nil
+}
+
+// 添加代理账户，delegate 可以按 proxyType 的权限以签名者的身份调用
+// Add proxy account, delegate can call on behalf of signer with permissions of proxyType
+func (c *ChainClient) AddProxy(signer SignerType, delegate types.AccountID, proxyType gtypes.ProxyType, delay uint32, opts SubmitOptions) (*TxReceipt, error) {
+	return c.AddProxyContext(context.Background(), signer, delegate, proxyType, delay, opts)
+}
+
+// 添加代理账户
+// Add proxy account with context
+func (c *ChainClient) AddProxyContext(ctx context.Context, signer SignerType, delegate types.AccountID, proxyType gtypes.ProxyType, delay uint32, opts SubmitOptions) (*TxReceipt, error) {
+	if c.Debug {
+		util.LogWithPurple("[       Add proxy ]", delegate.ToHexString())
+	}
+
+	runtimeCall := proxy.MakeAddProxyCall(gtypes.MultiAddress{IsId: true, AsIdField0: delegate}, proxyType, delay)
+	call, err := runtimeCall.AsCall()
+	if err != nil {
+		return nil, errors.New("(runtimeCall).AsCall() error: " + err.Error())
+	}
+
+	return c.SignAndSubmitWithOptions(ctx, signer, call, opts)
+}
+
+// 移除代理账户，proxyType 和 delay 需与添加时相同
+// Remove proxy account, proxyType and delay must be the same as they were added with
+func (c *ChainClient) RemoveProxy(signer SignerType, delegate types.AccountID, proxyType gtypes.ProxyType, delay uint32, opts SubmitOptions) (*TxReceipt, error) {
+	return c.RemoveProxyContext(context.Background(), signer, delegate, proxyType, delay, opts)
+}
+
+// 移除代理账户
+// Remove proxy account with context
+func (c *ChainClient) RemoveProxyContext(ctx context.Context, signer SignerType, delegate types.AccountID, proxyType gtypes.ProxyType, delay uint32, opts SubmitOptions) (*TxReceipt, error) {
+	if c.Debug {
+		util.LogWithPurple("[    Remove proxy ]", delegate.ToHexString())
+	}
+
+	runtimeCall := proxy.MakeRemoveProxyCall(gtypes.MultiAddress{IsId: true, AsIdField0: delegate}, proxyType, delay)
+	call, err := runtimeCall.AsCall()
+	if err != nil {
+		return nil, errors.New("(runtimeCall).AsCall() error: " + err.Error())
+	}
+
+	return c.SignAndSubmitWithOptions(ctx, signer, call, opts)
+}
+
+// 查询账户的代理账户及其押金
+// Get proxies of account and the deposit held for them
+func (c *ChainClient) Proxies(account types.AccountID) ([]gtypes.ProxyDefinition, types.U128, error) {
+	return c.ProxiesContext(context.Background(), account)
+}
+
+// 查询账户的代理账户及其押金
+// Get proxies of account and the deposit held for them with context
+func (c *ChainClient) ProxiesContext(ctx context.Context, account types.AccountID) ([]gtypes.ProxyDefinition, types.U128, error) {
+	deposit := types.NewU128(*big.NewInt(0))
+
+	key, err := proxy.MakeProxiesStorageKey(account)
+	if err != nil {
+		return nil, deposit, err
+	}
+
+	var ret gtypes.TupleOfProxyDefinitionSliceU128
+	ok, err := c.getStorageContext(ctx, key, &ret, nil)
+	if err != nil {
+		return nil, deposit, errors.New("Proxy.Proxies: " + err.Error())
+	}
+	if !ok {
+		return []gtypes.ProxyDefinition{}, deposit, nil
+	}
+
+	return ret.Elem0, ret.Elem1, nil
+}
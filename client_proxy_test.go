@@ -0,0 +1,37 @@
+package ink
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	gtypes "github.com/wetee-dao/ink.go/pallet/types"
+)
+
+func TestProxyCallMeta(t *testing.T) {
+	// 运行时升级后 Proxy 模块的索引变化
+	// index of Proxy pallet changes after runtime upgrade
+	var meta types.Metadata
+	encoded, _ := codec.Encode(gtypes.Meta)
+	if err := codec.Decode(encoded, &meta); err != nil {
+		t.Fatal(err)
+	}
+	for i, pallet := range meta.AsMetadataV14.Pallets {
+		if string(pallet.Name) == "Proxy" {
+			meta.AsMetadataV14.Pallets[i].Index = 99
+		}
+	}
+
+	remark, err := types.NewCall(&meta, "System.remark", []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &ChainClient{Meta: &meta}
+	call, err := client.ProxyCall(ProxyOptions{Real: types.AccountID{2}}, remark)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.CallIndex.SectionIndex != 99 {
+		t.Errorf("proxy call index %+v", call.CallIndex)
+	}
+}
@@ -0,0 +1,53 @@
+package ink
+
+import (
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	gtypes "github.com/wetee-dao/ink.go/pallet/types"
+)
+
+// 交易回执
+// Receipt of extrinsic
+type TxReceipt struct {
+	// blake2b_256 of encoded extrinsic
+	ExtrinsicHash types.Hash
+	// block which the extrinsic is included in
+	BlockHash   types.Hash
+	BlockNumber types.BlockNumber
+	// index of extrinsic in block
+	ExtrinsicIndex uint32
+	// events emitted by the extrinsic (Phase.ApplyExtrinsic == ExtrinsicIndex)
+	Events []gtypes.EventRecord
+	// actual weight of the extrinsic
+	Weight gtypes.Weight
+	// actual fee paid, from TransactionPayment.TransactionFeePaid
+	Fee types.U128
+	Tip types.U128
+	// whether the extrinsic is dispatched successfully
+	Success bool
+	// whether the block is finalized
+	Finalized bool
+}
+
+func newTxReceipt(extHash types.Hash, blockHash types.Hash, blockNumber types.BlockNumber, index uint32) *TxReceipt {
+	return &TxReceipt{
+		ExtrinsicHash:  extHash,
+		BlockHash:      blockHash,
+		BlockNumber:    blockNumber,
+		ExtrinsicIndex: index,
+		Events:         make([]gtypes.EventRecord, 0),
+		Fee:            types.NewU128(*big.NewInt(0)),
+		Tip:            types.NewU128(*big.NewInt(0)),
+	}
+}
+
+// 获取合约事件
+// Get Revive.ContractEmitted events of contract in receipt
+func (r *TxReceipt) InkEvents(address types.H160) []InkEvent {
+	events := FilterInkEvents(r.Events, address, nil)
+	for i := range events {
+		events[i].BlockHash = r.BlockHash
+	}
+	return events
+}
@@ -0,0 +1,197 @@
+package ink
+
+import (
+	"context"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/vedhavyas/go-subkey/v2"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/block"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/wetee-dao/ink.go/pallet/system"
+	gtypes "github.com/wetee-dao/ink.go/pallet/types"
+)
+
+// 支持 context 的 rpc 调用，gsrpc 的 RPC 接口不支持 context
+// Context aware rpc calls, the RPC interfaces of gsrpc do not accept context.Context
+
+// Call rpc method on a healthy connection of pool, args are followed by blockHash if it is not nil
+func (c *ChainClient) callContext(ctx context.Context, result any, method string, blockHash *types.Hash, args ...any) error {
+	api, err := c.Api()
+	if err != nil {
+		return err
+	}
+	return client.CallWithBlockHashContext(ctx, api.Client, result, method, blockHash, args...)
+}
+
+// Subscribe rpc notifications on a healthy connection of pool
+func (c *ChainClient) subscribeContext(ctx context.Context, namespace, subscribeMethodSuffix, unsubscribeMethodSuffix, notificationMethodSuffix string, channel any, args ...any) (*gethrpc.ClientSubscription, error) {
+	api, err := c.Api()
+	if err != nil {
+		return nil, err
+	}
+	return api.Client.Subscribe(ctx, namespace, subscribeMethodSuffix, unsubscribeMethodSuffix, notificationMethodSuffix, channel, args...)
+}
+
+// Get header of block, latest block when blockHash is nil
+func (c *ChainClient) getHeaderContext(ctx context.Context, blockHash *types.Hash) (*types.Header, error) {
+	var header types.Header
+	err := c.callContext(ctx, &header, "chain_getHeader", blockHash)
+	if err != nil {
+		return nil, err
+	}
+	return &header, nil
+}
+
+// Get hash of block number
+func (c *ChainClient) getBlockHashContext(ctx context.Context, blockNumber uint64) (types.Hash, error) {
+	var res string
+	err := c.callContext(ctx, &res, "chain_getBlockHash", nil, blockNumber)
+	if err != nil {
+		return types.Hash{}, err
+	}
+	return types.NewHashFromHexString(res)
+}
+
+// Get hash of latest finalized block
+func (c *ChainClient) getFinalizedHeadContext(ctx context.Context) (types.Hash, error) {
+	var res string
+	err := c.callContext(ctx, &res, "chain_getFinalizedHead", nil)
+	if err != nil {
+		return types.Hash{}, err
+	}
+	return types.NewHashFromHexString(res)
+}
+
+// Get block, latest block when blockHash is nil
+func (c *ChainClient) getBlockContext(ctx context.Context, blockHash *types.Hash) (*block.SignedBlock, error) {
+	var res block.SignedBlock
+	err := c.callContext(ctx, &res, "chain_getBlock", blockHash)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Get metadata of latest block
+func (c *ChainClient) getMetadataContext(ctx context.Context) (*types.Metadata, error) {
+	var res string
+	err := c.callContext(ctx, &res, "state_getMetadata", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata types.Metadata
+	err = codec.DecodeFromHex(res, &metadata)
+	return &metadata, err
+}
+
+// Get runtime version of latest block
+func (c *ChainClient) getRuntimeVersionContext(ctx context.Context) (*types.RuntimeVersion, error) {
+	var runtimeVersion types.RuntimeVersion
+	err := c.callContext(ctx, &runtimeVersion, "state_getRuntimeVersion", nil)
+	if err != nil {
+		return nil, err
+	}
+	return &runtimeVersion, nil
+}
+
+// Get storage raw data, latest block when blockHash is nil
+func (c *ChainClient) getStorageRawContext(ctx context.Context, key types.StorageKey, blockHash *types.Hash) (types.StorageDataRaw, error) {
+	var res string
+	err := c.callContext(ctx, &res, "state_getStorage", blockHash, key.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := codec.HexDecodeString(res)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewStorageDataRaw(bz), nil
+}
+
+// Get storage and decode it into target, ok is false if the value is empty
+func (c *ChainClient) getStorageContext(ctx context.Context, key types.StorageKey, target any, blockHash *types.Hash) (ok bool, err error) {
+	raw, err := c.getStorageRawContext(ctx, key, blockHash)
+	if err != nil {
+		return false, err
+	}
+	if len(raw) == 0 {
+		return false, nil
+	}
+	return true, codec.Decode(raw, target)
+}
+
+// Get storage keys with prefix
+func (c *ChainClient) getKeysContext(ctx context.Context, prefix types.StorageKey, blockHash *types.Hash) ([]types.StorageKey, error) {
+	var res []string
+	err := c.callContext(ctx, &res, "state_getKeys", blockHash, prefix.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]types.StorageKey, len(res))
+	for i, r := range res {
+		err = codec.DecodeFromHex(r, &keys[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// Query storage of keys at block
+func (c *ChainClient) queryStorageAtContext(ctx context.Context, keys []types.StorageKey, blockHash *types.Hash) ([]types.StorageChangeSet, error) {
+	hexKeys := make([]string, len(keys))
+	for i, key := range keys {
+		hexKeys[i] = key.Hex()
+	}
+
+	var res []types.StorageChangeSet
+	err := c.callContext(ctx, &res, "state_queryStorageAt", blockHash, hexKeys)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Get System.Events of block
+func (c *ChainClient) getEventsContext(ctx context.Context, blockHash types.Hash) ([]gtypes.EventRecord, error) {
+	key, err := system.MakeEventsStorageKey()
+	if err != nil {
+		return nil, err
+	}
+
+	var events []gtypes.EventRecord
+	_, err = c.getStorageContext(ctx, key, &events, &blockHash)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// Get next nonce of account, including transactions in the pool
+func (c *ChainClient) accountNextIndexContext(ctx context.Context, account types.AccountID) (uint64, error) {
+	var res uint64
+	err := c.callContext(ctx, &res, "system_accountNextIndex", nil, subkey.SS58Encode(account[:], 42))
+	if err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+// Submit extrinsic and watch its status
+func (c *ChainClient) submitAndWatchContext(ctx context.Context, extBytes []byte) (*gethrpc.ClientSubscription, chan types.ExtrinsicStatus, error) {
+	hexEncodedExtrinsic := codec.HexEncodeToString(extBytes)
+
+	ch := make(chan types.ExtrinsicStatus)
+	sub, err := c.subscribeContext(ctx, "author", "submitAndWatchExtrinsic", "unwatchExtrinsic", "extrinsicUpdate", ch, hexEncodedExtrinsic)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sub, ch, nil
+}
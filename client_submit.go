@@ -0,0 +1,282 @@
+package ink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/wetee-dao/ink.go/util"
+	"golang.org/x/crypto/blake2b"
+)
+
+// 默认交易提交超时时间
+// Default timeout of submitting transaction
+var DefaultSubmitTimeout = 120 * time.Second
+
+// ChainClient 默认交易有效区块数
+// Default mortal period of ChainClient
+var DefaultMortalPeriod uint64 = 64
+
+// 交易提交参数
+// Options of submitting transaction
+type SubmitOptions struct {
+	// 等待区块最终确认，否则交易进入区块即返回
+	// wait until the block is finalized, otherwise return when the extrinsic is in block
+	UntilFinalized bool
+	// 为 0 时由 ChainClient.Nonces 分配
+	// reserved from ChainClient.Nonces when it is 0
+	Nonce uint64
+	// 小费，用于提高交易优先级，未设置时使用 ChainClient.DefaultTip
+	// tip to the block author, increases the priority of transaction, ChainClient.DefaultTip is used when it is unset
+	Tip types.U128
+	// 交易有效期，nil 时使用 ChainClient.DefaultEra
+	// era of transaction, ChainClient.DefaultEra is used when it is nil
+	Era *Era
+	// 等待交易的超时时间，为 0 时使用 DefaultSubmitTimeout，小于 0 时只受 ctx 控制
+	// timeout of waiting, DefaultSubmitTimeout is used when it is 0, only ctx is used when it is negative
+	Timeout time.Duration
+}
+
+// 交易有效期，起始区块为最新的最终确认区块
+// Era of transaction, it starts from the latest finalized block
+type Era struct {
+	// 有效区块数，会被调整为 4 到 65536 之间的 2 的幂，为 0 时永久有效
+	// number of blocks the transaction is valid for, rounded up to a power of two in [4, 65536], 0 means immortal
+	Period uint64
+}
+
+// 计算 mortal era 及其起始区块
+// Mortal era of period which starts at or before current block, same as Era::mortal in substrate
+func newMortalEra(period uint64, current uint64) (types.ExtrinsicEra, uint64) {
+	p := uint64(4)
+	for p < period && p < 65536 {
+		p <<= 1
+	}
+
+	phase := current % p
+	quantizeFactor := max(p>>12, 1)
+	quantizedPhase := phase / quantizeFactor * quantizeFactor
+
+	encoded := uint16(min(max(bits.TrailingZeros64(p)-1, 1), 15)) | uint16(quantizedPhase/quantizeFactor)<<4
+	era := types.ExtrinsicEra{
+		IsMortalEra: true,
+		AsMortalEra: types.MortalEra{First: byte(encoded), Second: byte(encoded >> 8)},
+	}
+
+	return era, current - phase + quantizedPhase
+}
+
+// 永久有效的交易
+// Immortal era, the transaction never expires
+func ImmortalEra() *Era {
+	return &Era{}
+}
+
+// 在 period 个区块内有效的交易
+// Mortal era, the transaction is valid for period blocks
+func MortalEra(period uint64) *Era {
+	return &Era{Period: period}
+}
+
+// 构建签名参数，未设置的 tip 和 era 使用 ChainClient 的默认值
+// Signing options of extrinsic, unset tip and era fallback to the defaults of ChainClient
+func (c *ChainClient) signingOptions(ctx context.Context, nonce uint64, tip types.U128, era *Era) ([]extrinsic.SigningOption, error) {
+	tx, err := c.unsignedTx(ctx, nonce, tip, era)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx.SigningOptions(), nil
+}
+
+// 构建签名字段，未设置的 tip 和 era 使用 ChainClient 的默认值
+// Signed fields of extrinsic, unset tip and era fallback to the defaults of ChainClient
+func (c *ChainClient) unsignedTx(ctx context.Context, nonce uint64, tip types.U128, era *Era) (*UnsignedTx, error) {
+	if era == nil {
+		era = c.DefaultEra
+	}
+	if tip.Int == nil {
+		tip = c.DefaultTip
+	}
+
+	tx := &UnsignedTx{
+		Nonce:              nonce,
+		Tip:                big.NewInt(0),
+		Era:                types.ExtrinsicEra{IsImmortalEra: true},
+		BlockHash:          c.Hash,
+		SpecVersion:        uint32(c.Runtime.SpecVersion),
+		TransactionVersion: uint32(c.Runtime.TransactionVersion),
+		GenesisHash:        c.Hash,
+	}
+	if tip.Int != nil {
+		tx.Tip = tip.Int
+	}
+
+	if era != nil && era.Period > 0 {
+		// 以最终确认区块为检查点，避免分叉导致交易失效
+		// checkpoint on finalized block, so that the transaction survives reorgs
+		finalized, err := c.getFinalizedHeadContext(ctx)
+		if err != nil {
+			return nil, errors.New("Chain.GetFinalizedHead error: " + err.Error())
+		}
+		header, err := c.getHeaderContext(ctx, &finalized)
+		if err != nil {
+			return nil, errors.New("Chain.GetHeader error: " + err.Error())
+		}
+
+		mortal, birth := newMortalEra(era.Period, uint64(header.Number))
+		checkpoint, err := c.getBlockHashContext(ctx, birth)
+		if err != nil {
+			return nil, errors.New("Chain.GetBlockHash error: " + err.Error())
+		}
+		tx.Era, tx.BlockHash, tx.BlockNumber = mortal, checkpoint, birth
+	}
+
+	return tx, nil
+}
+
+// 按提交参数签名并提交交易
+// Sign and submit transaction with options
+func (c *ChainClient) SignAndSubmitWithOptions(ctx context.Context, signer SignerType, call types.Call, opts SubmitOptions) (*TxReceipt, error) {
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = DefaultSubmitTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	account := signer.AccountID()
+	nonce := opts.Nonce
+	managed := nonce == 0
+	if managed {
+		var err error
+		nonce, err = c.Nonces.Next(ctx, account)
+		if err != nil {
+			return nil, errors.New("NonceManager.Next error: " + err.Error())
+		}
+	}
+
+	ext := NewExtrinsic(call)
+	signOpts, err := c.signingOptions(ctx, nonce, opts.Tip, opts.Era)
+	if err == nil {
+		err = ext.SignContext(ctx, signer, c.Meta, signOpts...)
+	}
+	if err != nil {
+		// 交易未提交，归还 nonce
+		if managed {
+			c.Nonces.Release(account, nonce)
+		}
+		return nil, err
+	}
+
+	return c.submitAndWatch(ctx, ext.Extrinsic, account, nonce, managed, opts.UntilFinalized)
+}
+
+// 提交已签名交易并等待结果
+// Submit signed extrinsic and wait for its status
+func (c *ChainClient) submitAndWatch(ctx context.Context, xt extrinsic.Extrinsic, account types.AccountID, nonce uint64, managed bool, untilFinalized bool) (*TxReceipt, error) {
+	extBytes, err := codec.Encode(xt)
+	if err != nil {
+		if managed {
+			c.Nonces.Release(account, nonce)
+		}
+		return nil, errors.New("Codec.Encode error: " + err.Error())
+	}
+
+	return c.submitAndWatchBytes(ctx, extBytes, account, nonce, managed, untilFinalized)
+}
+
+// 提交 SCALE 编码的交易并等待结果
+// Submit SCALE encoded extrinsic and wait for its status
+func (c *ChainClient) submitAndWatchBytes(ctx context.Context, extBytes []byte, account types.AccountID, nonce uint64, managed bool, untilFinalized bool) (*TxReceipt, error) {
+	if managed {
+		defer c.Nonces.Done(account, nonce)
+	}
+
+	sub, statusCh, err := c.submitAndWatchContext(ctx, extBytes)
+	if err != nil {
+		if managed {
+			c.Nonces.Resync(account)
+		}
+		return nil, errors.New("Author.SubmitAndWatchExtrinsic error: " + err.Error())
+	}
+	defer sub.Unsubscribe()
+
+	hash := blake2b.Sum256(extBytes)
+
+	for {
+		select {
+		case status := <-statusCh:
+			if status.IsInBlock {
+				receipt, err := c.checkExtrinsic(ctx, hash, status.AsInBlock)
+				if err != nil {
+					return receipt, err
+				}
+
+				if receipt != nil && receipt.Success && c.Debug {
+					util.LogWithGreen("[Extrinsic]", "InBlock", receipt.BlockHash.Hex())
+				}
+
+				if receipt != nil && receipt.Success && !untilFinalized {
+					return receipt, nil
+				}
+			} else if status.IsFinalized {
+				receipt, err := c.checkExtrinsic(ctx, hash, status.AsFinalized)
+				if err != nil {
+					return receipt, err
+				}
+				if receipt != nil && receipt.Success {
+					receipt.Finalized = true
+					if c.Debug {
+						util.LogWithGreen("[Extrinsic]", "Finalized", receipt.BlockHash.Hex())
+						fmt.Println()
+					}
+					return receipt, nil
+				}
+			} else if status.IsDropped || status.IsUsurped || status.IsInvalid {
+				// 交易不会再上链，nonce 需要重新同步
+				c.Nonces.Resync(account)
+				util.LogWithRed("SubmitAndWatchExtrinsic", extrinsicStatusName(status))
+				return nil, fmt.Errorf("extrinsic %s %s, nonce %d", types.Hash(hash).Hex(), extrinsicStatusName(status), nonce)
+			}
+		case err := <-sub.Err():
+			// 交易状态未知，nonce 需要重新同步
+			// status of the extrinsic is unknown, so the nonce is synced again
+			if managed {
+				c.Nonces.Resync(account)
+			}
+			if c.Debug {
+				util.LogWithRed("SubmitAndWatchExtrinsic ERROR", err.Error())
+			}
+
+			return nil, err
+		case <-ctx.Done():
+			if managed {
+				c.Nonces.Resync(account)
+			}
+			util.LogWithRed("SubmitAndWatchExtrinsic ERROR:", ctx.Err().Error())
+			return nil, fmt.Errorf("SubmitAndWatchExtrinsic extrinsic hash %s: %w", types.Hash(hash).Hex(), ctx.Err())
+		}
+	}
+}
+
+func extrinsicStatusName(status types.ExtrinsicStatus) string {
+	switch {
+	case status.IsDropped:
+		return "dropped"
+	case status.IsUsurped:
+		return "usurped"
+	case status.IsInvalid:
+		return "invalid"
+	}
+	return "unknown"
+}
@@ -0,0 +1,24 @@
+package ink
+
+import (
+	"testing"
+)
+
+func TestMortalEra(t *testing.T) {
+	cases := []struct {
+		period, current, birth uint64
+		first, second          byte
+	}{
+		{64, 42, 42, 165, 2},
+		{50, 42, 42, 165, 2},
+		{32768, 20000, 20000, 78, 156},
+		{65536, 70001, 70000, 127, 17},
+	}
+
+	for _, c := range cases {
+		era, birth := newMortalEra(c.period, c.current)
+		if !era.IsMortalEra || era.AsMortalEra.First != c.first || era.AsMortalEra.Second != c.second || birth != c.birth {
+			t.Fatalf("newMortalEra(%d, %d) = %v %d", c.period, c.current, era.AsMortalEra, birth)
+		}
+	}
+}
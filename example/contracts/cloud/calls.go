@@ -1,7 +1,7 @@
 package cloud
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"math/big"
 
@@ -10,18 +10,128 @@ import (
 	"github.com/wetee-dao/ink.go/util"
 )
 
-func DeployCloudWithNew(subnet_addr types.H160, pod_contract_code_hash types.H256, __ink_params chain.DeployParams) (*types.H160, error) {
-	return __ink_params.Client.DeployContract(
-		__ink_params.Code, __ink_params.Signer, types.NewU128(*big.NewInt(0)),
+func DeployCloudWithNew(subnet_addr types.H160, pod_contract_code_hash types.H256, __ink_params chain.DeployParams) (*types.H160, *chain.TxReceipt, error) {
+	return DeployCloudWithNewContext(context.Background(), subnet_addr, pod_contract_code_hash, __ink_params)
+}
+
+func DeployCloudWithNewContext(ctx context.Context, subnet_addr types.H160, pod_contract_code_hash types.H256, __ink_params chain.DeployParams) (*types.H160, *chain.TxReceipt, error) {
+	return __ink_params.Client.DeployContractContext(
+		ctx, __ink_params.Code, __ink_params.Signer, types.NewU128(*big.NewInt(0)),
 		util.InkContractInput{
 			Selector: "0x9bae9d5e",
 			Args:     []any{subnet_addr, pod_contract_code_hash},
 		},
 		__ink_params.Salt,
+		__ink_params.SubmitOptions,
 	)
 }
 
-func InitCloudContract(client *chain.ChainClient, address string) (*Cloud, error) {
+// CloudAPI is the interface of Cloud contract, it can be mocked in tests
+type CloudAPI interface {
+	chain.Ink
+	DryRunSetPodContract(pod_contract types.H256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunSetPodContractContext(ctx context.Context, pod_contract types.H256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecSetPodContract(pod_contract types.H256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecSetPodContractContext(ctx context.Context, pod_contract types.H256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfSetPodContract(pod_contract types.H256, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfSetPodContractContext(ctx context.Context, pod_contract types.H256, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunSetMintInterval(t uint32, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunSetMintIntervalContext(ctx context.Context, t uint32, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecSetMintInterval(t uint32, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecSetMintIntervalContext(ctx context.Context, t uint32, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfSetMintInterval(t uint32, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfSetMintIntervalContext(ctx context.Context, t uint32, __ink_params chain.DryRunParams) (*types.Call, error)
+	QueryMintInterval(__ink_params chain.DryRunParams) (*uint32, *chain.DryRunReturnGas, error)
+	QueryMintIntervalContext(ctx context.Context, __ink_params chain.DryRunParams) (*uint32, *chain.DryRunReturnGas, error)
+	QuerySubnetAddress(__ink_params chain.DryRunParams) (*types.H160, *chain.DryRunReturnGas, error)
+	QuerySubnetAddressContext(ctx context.Context, __ink_params chain.DryRunParams) (*types.H160, *chain.DryRunReturnGas, error)
+	DryRunCreatePod(name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunCreatePodContext(ctx context.Context, name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecCreatePod(name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecCreatePodContext(ctx context.Context, name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfCreatePod(name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfCreatePodContext(ctx context.Context, name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunStartPod(pod_id uint64, pod_key util.AccountId, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunStartPodContext(ctx context.Context, pod_id uint64, pod_key util.AccountId, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecStartPod(pod_id uint64, pod_key util.AccountId, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecStartPodContext(ctx context.Context, pod_id uint64, pod_key util.AccountId, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfStartPod(pod_id uint64, pod_key util.AccountId, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfStartPodContext(ctx context.Context, pod_id uint64, pod_key util.AccountId, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunMintPod(pod_id uint64, report types.H256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunMintPodContext(ctx context.Context, pod_id uint64, report types.H256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecMintPod(pod_id uint64, report types.H256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecMintPodContext(ctx context.Context, pod_id uint64, report types.H256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfMintPod(pod_id uint64, report types.H256, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfMintPodContext(ctx context.Context, pod_id uint64, report types.H256, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunStopPod(pod_id uint64, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunStopPodContext(ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecStopPod(pod_id uint64, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecStopPodContext(ctx context.Context, pod_id uint64, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfStopPod(pod_id uint64, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfStopPodContext(ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunRestartPod(pod_id uint64, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunRestartPodContext(ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecRestartPod(pod_id uint64, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecRestartPodContext(ctx context.Context, pod_id uint64, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfRestartPod(pod_id uint64, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfRestartPodContext(ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunEditContainer(pod_id uint64, containers []ContainerInput, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunEditContainerContext(ctx context.Context, pod_id uint64, containers []ContainerInput, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecEditContainer(pod_id uint64, containers []ContainerInput, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecEditContainerContext(ctx context.Context, pod_id uint64, containers []ContainerInput, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfEditContainer(pod_id uint64, containers []ContainerInput, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfEditContainerContext(ctx context.Context, pod_id uint64, containers []ContainerInput, __ink_params chain.DryRunParams) (*types.Call, error)
+	QueryPodLen(__ink_params chain.DryRunParams) (*uint64, *chain.DryRunReturnGas, error)
+	QueryPodLenContext(ctx context.Context, __ink_params chain.DryRunParams) (*uint64, *chain.DryRunReturnGas, error)
+	QueryPods(start util.Option[uint64], size uint64, __ink_params chain.DryRunParams) (*[]Tuple_106, *chain.DryRunReturnGas, error)
+	QueryPodsContext(ctx context.Context, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams) (*[]Tuple_106, *chain.DryRunReturnGas, error)
+	QueryUserPodLen(__ink_params chain.DryRunParams) (*uint32, *chain.DryRunReturnGas, error)
+	QueryUserPodLenContext(ctx context.Context, __ink_params chain.DryRunParams) (*uint32, *chain.DryRunReturnGas, error)
+	QueryUserPods(start util.Option[uint32], size uint32, __ink_params chain.DryRunParams) (*[]Tuple_106, *chain.DryRunReturnGas, error)
+	QueryUserPodsContext(ctx context.Context, start util.Option[uint32], size uint32, __ink_params chain.DryRunParams) (*[]Tuple_106, *chain.DryRunReturnGas, error)
+	QueryWorkerPodsVersion(worker_id uint64, __ink_params chain.DryRunParams) (*[]Tuple_112, *chain.DryRunReturnGas, error)
+	QueryWorkerPodsVersionContext(ctx context.Context, worker_id uint64, __ink_params chain.DryRunParams) (*[]Tuple_112, *chain.DryRunReturnGas, error)
+	QueryWorkerPods(worker_id uint64, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams) (*[]Tuple_106, *chain.DryRunReturnGas, error)
+	QueryWorkerPodsContext(ctx context.Context, worker_id uint64, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams) (*[]Tuple_106, *chain.DryRunReturnGas, error)
+	QueryPod(pod_id uint64, __ink_params chain.DryRunParams) (*util.Option[Tuple_115], *chain.DryRunReturnGas, error)
+	QueryPodContext(ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams) (*util.Option[Tuple_115], *chain.DryRunReturnGas, error)
+	QueryPodsByIds(pod_ids []uint64, __ink_params chain.DryRunParams) (*[]Tuple_119, *chain.DryRunReturnGas, error)
+	QueryPodsByIdsContext(ctx context.Context, pod_ids []uint64, __ink_params chain.DryRunParams) (*[]Tuple_119, *chain.DryRunReturnGas, error)
+	QueryWorkerPodLen(worker_id uint64, __ink_params chain.DryRunParams) (*uint64, *chain.DryRunReturnGas, error)
+	QueryWorkerPodLenContext(ctx context.Context, worker_id uint64, __ink_params chain.DryRunParams) (*uint64, *chain.DryRunReturnGas, error)
+	QueryUserSecrets(user types.H160, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams) (*[]Tuple_122, *chain.DryRunReturnGas, error)
+	QueryUserSecretsContext(ctx context.Context, user types.H160, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams) (*[]Tuple_122, *chain.DryRunReturnGas, error)
+	QuerySecret(user types.H160, index uint64, __ink_params chain.DryRunParams) (*util.Option[Secret], *chain.DryRunReturnGas, error)
+	QuerySecretContext(ctx context.Context, user types.H160, index uint64, __ink_params chain.DryRunParams) (*util.Option[Secret], *chain.DryRunReturnGas, error)
+	DryRunInitSecret(name []byte, __ink_params chain.DryRunParams) (*util.Result[uint64, Error], *chain.DryRunReturnGas, error)
+	DryRunInitSecretContext(ctx context.Context, name []byte, __ink_params chain.DryRunParams) (*util.Result[uint64, Error], *chain.DryRunReturnGas, error)
+	ExecInitSecret(name []byte, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecInitSecretContext(ctx context.Context, name []byte, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfInitSecret(name []byte, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfInitSecretContext(ctx context.Context, name []byte, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunUpdateSecret(user types.H160, index uint64, hash types.H256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunUpdateSecretContext(ctx context.Context, user types.H160, index uint64, hash types.H256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecUpdateSecret(user types.H160, index uint64, hash types.H256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecUpdateSecretContext(ctx context.Context, user types.H160, index uint64, hash types.H256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfUpdateSecret(user types.H160, index uint64, hash types.H256, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfUpdateSecretContext(ctx context.Context, user types.H160, index uint64, hash types.H256, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunDelSecret(index uint64, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunDelSecretContext(ctx context.Context, index uint64, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecDelSecret(index uint64, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecDelSecretContext(ctx context.Context, index uint64, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfDelSecret(index uint64, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfDelSecretContext(ctx context.Context, index uint64, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunSetCode(code_hash types.H256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunSetCodeContext(ctx context.Context, code_hash types.H256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecSetCode(code_hash types.H256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecSetCodeContext(ctx context.Context, code_hash types.H256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfSetCode(code_hash types.H256, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfSetCodeContext(ctx context.Context, code_hash types.H256, __ink_params chain.DryRunParams) (*types.Call, error)
+}
+
+var _ CloudAPI = (*Cloud)(nil)
+
+func InitCloudContract(client chain.InkClient, address string) (*Cloud, error) {
 	contractAddress, err := util.HexToH160(address)
 	if err != nil {
 		return nil, err
@@ -33,11 +143,11 @@ func InitCloudContract(client *chain.ChainClient, address string) (*Cloud, error
 }
 
 type Cloud struct {
-	ChainClient *chain.ChainClient
+	ChainClient chain.InkClient
 	Address     types.H160
 }
 
-func (c *Cloud) Client() *chain.ChainClient {
+func (c *Cloud) Client() chain.InkClient {
 	return c.ChainClient
 }
 
@@ -48,11 +158,18 @@ func (c *Cloud) ContractAddress() types.H160 {
 func (c *Cloud) DryRunSetPodContract(
 	pod_contract types.H256, __ink_params chain.DryRunParams,
 ) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunSetPodContractContext(context.Background(), pod_contract, __ink_params)
+}
+
+func (c *Cloud) DryRunSetPodContractContext(
+	ctx context.Context, pod_contract types.H256, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "set_pod_contract")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[util.NullTuple, Error]](
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -63,11 +180,29 @@ func (c *Cloud) DryRunSetPodContract(
 			Args:     []any{pod_contract},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0xeebfb380",
+				Args:     []any{pod_contract},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
@@ -75,14 +210,26 @@ func (c *Cloud) DryRunSetPodContract(
 
 func (c *Cloud) ExecSetPodContract(
 	pod_contract types.H256, __ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.ExecSetPodContractContext(context.Background(), pod_contract, __ink_params)
+}
+
+func (c *Cloud) ExecSetPodContractContext(
+	ctx context.Context, pod_contract types.H256, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunSetPodContract(pod_contract, _param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunSetPodContractContext(ctx, pod_contract, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -97,7 +244,14 @@ func (c *Cloud) ExecSetPodContract(
 func (c *Cloud) CallOfSetPodContract(
 	pod_contract types.H256, __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunSetPodContract(pod_contract, __ink_params)
+	return c.CallOfSetPodContractContext(context.Background(), pod_contract, __ink_params)
+}
+
+func (c *Cloud) CallOfSetPodContractContext(
+	ctx context.Context, pod_contract types.H256, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunSetPodContractContext(ctx, pod_contract, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -116,11 +270,18 @@ func (c *Cloud) CallOfSetPodContract(
 func (c *Cloud) DryRunSetMintInterval(
 	t uint32, __ink_params chain.DryRunParams,
 ) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunSetMintIntervalContext(context.Background(), t, __ink_params)
+}
+
+func (c *Cloud) DryRunSetMintIntervalContext(
+	ctx context.Context, t uint32, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "set_mint_interval")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[util.NullTuple, Error]](
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -131,11 +292,29 @@ func (c *Cloud) DryRunSetMintInterval(
 			Args:     []any{t},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x936793ec",
+				Args:     []any{t},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
@@ -143,14 +322,26 @@ func (c *Cloud) DryRunSetMintInterval(
 
 func (c *Cloud) ExecSetMintInterval(
 	t uint32, __ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.ExecSetMintIntervalContext(context.Background(), t, __ink_params)
+}
+
+func (c *Cloud) ExecSetMintIntervalContext(
+	ctx context.Context, t uint32, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunSetMintInterval(t, _param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunSetMintIntervalContext(ctx, t, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -165,7 +356,14 @@ func (c *Cloud) ExecSetMintInterval(
 func (c *Cloud) CallOfSetMintInterval(
 	t uint32, __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunSetMintInterval(t, __ink_params)
+	return c.CallOfSetMintIntervalContext(context.Background(), t, __ink_params)
+}
+
+func (c *Cloud) CallOfSetMintIntervalContext(
+	ctx context.Context, t uint32, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunSetMintIntervalContext(ctx, t, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -184,11 +382,18 @@ func (c *Cloud) CallOfSetMintInterval(
 func (c *Cloud) QueryMintInterval(
 	__ink_params chain.DryRunParams,
 ) (*uint32, *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.QueryMintIntervalContext(context.Background(), __ink_params)
+}
+
+func (c *Cloud) QueryMintIntervalContext(
+	ctx context.Context, __ink_params chain.DryRunParams,
+) (*uint32, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "mint_interval")
 	}
-	v, gas, err := chain.DryRunInk[uint32](
+	v, gas, err := chain.DryRunInkContext[uint32](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -199,7 +404,7 @@ func (c *Cloud) QueryMintInterval(
 			Args:     []any{},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	return v, gas, nil
@@ -208,11 +413,18 @@ func (c *Cloud) QueryMintInterval(
 func (c *Cloud) QuerySubnetAddress(
 	__ink_params chain.DryRunParams,
 ) (*types.H160, *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.QuerySubnetAddressContext(context.Background(), __ink_params)
+}
+
+func (c *Cloud) QuerySubnetAddressContext(
+	ctx context.Context, __ink_params chain.DryRunParams,
+) (*types.H160, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "subnet_address")
 	}
-	v, gas, err := chain.DryRunInk[types.H160](
+	v, gas, err := chain.DryRunInkContext[types.H160](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -223,7 +435,7 @@ func (c *Cloud) QuerySubnetAddress(
 			Args:     []any{},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	return v, gas, nil
@@ -232,11 +444,18 @@ func (c *Cloud) QuerySubnetAddress(
 func (c *Cloud) DryRunCreatePod(
 	name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.DryRunParams,
 ) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunCreatePodContext(context.Background(), name, pod_type, tee_type, containers, region_id, level, worker_id, __ink_params)
+}
+
+func (c *Cloud) DryRunCreatePodContext(
+	ctx context.Context, name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "create_pod")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[util.NullTuple, Error]](
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -247,11 +466,29 @@ func (c *Cloud) DryRunCreatePod(
 			Args:     []any{name, pod_type, tee_type, containers, region_id, level, worker_id},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x080c3dfd",
+				Args:     []any{name, pod_type, tee_type, containers, region_id, level, worker_id},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
@@ -259,14 +496,26 @@ func (c *Cloud) DryRunCreatePod(
 
 func (c *Cloud) ExecCreatePod(
 	name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.ExecCreatePodContext(context.Background(), name, pod_type, tee_type, containers, region_id, level, worker_id, __ink_params)
+}
+
+func (c *Cloud) ExecCreatePodContext(
+	ctx context.Context, name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunCreatePod(name, pod_type, tee_type, containers, region_id, level, worker_id, _param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunCreatePodContext(ctx, name, pod_type, tee_type, containers, region_id, level, worker_id, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -281,7 +530,14 @@ func (c *Cloud) ExecCreatePod(
 func (c *Cloud) CallOfCreatePod(
 	name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunCreatePod(name, pod_type, tee_type, containers, region_id, level, worker_id, __ink_params)
+	return c.CallOfCreatePodContext(context.Background(), name, pod_type, tee_type, containers, region_id, level, worker_id, __ink_params)
+}
+
+func (c *Cloud) CallOfCreatePodContext(
+	ctx context.Context, name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunCreatePodContext(ctx, name, pod_type, tee_type, containers, region_id, level, worker_id, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -300,11 +556,18 @@ func (c *Cloud) CallOfCreatePod(
 func (c *Cloud) DryRunStartPod(
 	pod_id uint64, pod_key util.AccountId, __ink_params chain.DryRunParams,
 ) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunStartPodContext(context.Background(), pod_id, pod_key, __ink_params)
+}
+
+func (c *Cloud) DryRunStartPodContext(
+	ctx context.Context, pod_id uint64, pod_key util.AccountId, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "start_pod")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[util.NullTuple, Error]](
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -315,11 +578,29 @@ func (c *Cloud) DryRunStartPod(
 			Args:     []any{pod_id, pod_key},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0xc9f85a2d",
+				Args:     []any{pod_id, pod_key},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
@@ -327,14 +608,26 @@ func (c *Cloud) DryRunStartPod(
 
 func (c *Cloud) ExecStartPod(
 	pod_id uint64, pod_key util.AccountId, __ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.ExecStartPodContext(context.Background(), pod_id, pod_key, __ink_params)
+}
+
+func (c *Cloud) ExecStartPodContext(
+	ctx context.Context, pod_id uint64, pod_key util.AccountId, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunStartPod(pod_id, pod_key, _param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunStartPodContext(ctx, pod_id, pod_key, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -349,7 +642,14 @@ func (c *Cloud) ExecStartPod(
 func (c *Cloud) CallOfStartPod(
 	pod_id uint64, pod_key util.AccountId, __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunStartPod(pod_id, pod_key, __ink_params)
+	return c.CallOfStartPodContext(context.Background(), pod_id, pod_key, __ink_params)
+}
+
+func (c *Cloud) CallOfStartPodContext(
+	ctx context.Context, pod_id uint64, pod_key util.AccountId, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunStartPodContext(ctx, pod_id, pod_key, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -368,11 +668,18 @@ func (c *Cloud) CallOfStartPod(
 func (c *Cloud) DryRunMintPod(
 	pod_id uint64, report types.H256, __ink_params chain.DryRunParams,
 ) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunMintPodContext(context.Background(), pod_id, report, __ink_params)
+}
+
+func (c *Cloud) DryRunMintPodContext(
+	ctx context.Context, pod_id uint64, report types.H256, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "mint_pod")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[util.NullTuple, Error]](
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -383,11 +690,29 @@ func (c *Cloud) DryRunMintPod(
 			Args:     []any{pod_id, report},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x8ca4b83c",
+				Args:     []any{pod_id, report},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
@@ -395,14 +720,26 @@ func (c *Cloud) DryRunMintPod(
 
 func (c *Cloud) ExecMintPod(
 	pod_id uint64, report types.H256, __ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.ExecMintPodContext(context.Background(), pod_id, report, __ink_params)
+}
+
+func (c *Cloud) ExecMintPodContext(
+	ctx context.Context, pod_id uint64, report types.H256, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunMintPod(pod_id, report, _param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunMintPodContext(ctx, pod_id, report, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -417,7 +754,14 @@ func (c *Cloud) ExecMintPod(
 func (c *Cloud) CallOfMintPod(
 	pod_id uint64, report types.H256, __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunMintPod(pod_id, report, __ink_params)
+	return c.CallOfMintPodContext(context.Background(), pod_id, report, __ink_params)
+}
+
+func (c *Cloud) CallOfMintPodContext(
+	ctx context.Context, pod_id uint64, report types.H256, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunMintPodContext(ctx, pod_id, report, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -436,11 +780,18 @@ func (c *Cloud) CallOfMintPod(
 func (c *Cloud) DryRunStopPod(
 	pod_id uint64, __ink_params chain.DryRunParams,
 ) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunStopPodContext(context.Background(), pod_id, __ink_params)
+}
+
+func (c *Cloud) DryRunStopPodContext(
+	ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "stop_pod")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[util.NullTuple, Error]](
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -451,11 +802,29 @@ func (c *Cloud) DryRunStopPod(
 			Args:     []any{pod_id},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x29879008",
+				Args:     []any{pod_id},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
@@ -463,14 +832,26 @@ func (c *Cloud) DryRunStopPod(
 
 func (c *Cloud) ExecStopPod(
 	pod_id uint64, __ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.ExecStopPodContext(context.Background(), pod_id, __ink_params)
+}
+
+func (c *Cloud) ExecStopPodContext(
+	ctx context.Context, pod_id uint64, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunStopPod(pod_id, _param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunStopPodContext(ctx, pod_id, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -485,7 +866,14 @@ func (c *Cloud) ExecStopPod(
 func (c *Cloud) CallOfStopPod(
 	pod_id uint64, __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunStopPod(pod_id, __ink_params)
+	return c.CallOfStopPodContext(context.Background(), pod_id, __ink_params)
+}
+
+func (c *Cloud) CallOfStopPodContext(
+	ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunStopPodContext(ctx, pod_id, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -504,11 +892,18 @@ func (c *Cloud) CallOfStopPod(
 func (c *Cloud) DryRunRestartPod(
 	pod_id uint64, __ink_params chain.DryRunParams,
 ) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunRestartPodContext(context.Background(), pod_id, __ink_params)
+}
+
+func (c *Cloud) DryRunRestartPodContext(
+	ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "restart_pod")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[util.NullTuple, Error]](
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -519,11 +914,29 @@ func (c *Cloud) DryRunRestartPod(
 			Args:     []any{pod_id},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x0b40460c",
+				Args:     []any{pod_id},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
@@ -531,14 +944,26 @@ func (c *Cloud) DryRunRestartPod(
 
 func (c *Cloud) ExecRestartPod(
 	pod_id uint64, __ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.ExecRestartPodContext(context.Background(), pod_id, __ink_params)
+}
+
+func (c *Cloud) ExecRestartPodContext(
+	ctx context.Context, pod_id uint64, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunRestartPod(pod_id, _param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunRestartPodContext(ctx, pod_id, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -553,7 +978,14 @@ func (c *Cloud) ExecRestartPod(
 func (c *Cloud) CallOfRestartPod(
 	pod_id uint64, __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunRestartPod(pod_id, __ink_params)
+	return c.CallOfRestartPodContext(context.Background(), pod_id, __ink_params)
+}
+
+func (c *Cloud) CallOfRestartPodContext(
+	ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunRestartPodContext(ctx, pod_id, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -572,11 +1004,18 @@ func (c *Cloud) CallOfRestartPod(
 func (c *Cloud) DryRunEditContainer(
 	pod_id uint64, containers []ContainerInput, __ink_params chain.DryRunParams,
 ) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunEditContainerContext(context.Background(), pod_id, containers, __ink_params)
+}
+
+func (c *Cloud) DryRunEditContainerContext(
+	ctx context.Context, pod_id uint64, containers []ContainerInput, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "edit_container")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[util.NullTuple, Error]](
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -587,11 +1026,29 @@ func (c *Cloud) DryRunEditContainer(
 			Args:     []any{pod_id, containers},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x50e8c63b",
+				Args:     []any{pod_id, containers},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
@@ -599,14 +1056,26 @@ func (c *Cloud) DryRunEditContainer(
 
 func (c *Cloud) ExecEditContainer(
 	pod_id uint64, containers []ContainerInput, __ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.ExecEditContainerContext(context.Background(), pod_id, containers, __ink_params)
+}
+
+func (c *Cloud) ExecEditContainerContext(
+	ctx context.Context, pod_id uint64, containers []ContainerInput, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunEditContainer(pod_id, containers, _param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunEditContainerContext(ctx, pod_id, containers, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -621,7 +1090,14 @@ func (c *Cloud) ExecEditContainer(
 func (c *Cloud) CallOfEditContainer(
 	pod_id uint64, containers []ContainerInput, __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunEditContainer(pod_id, containers, __ink_params)
+	return c.CallOfEditContainerContext(context.Background(), pod_id, containers, __ink_params)
+}
+
+func (c *Cloud) CallOfEditContainerContext(
+	ctx context.Context, pod_id uint64, containers []ContainerInput, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunEditContainerContext(ctx, pod_id, containers, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -640,11 +1116,18 @@ func (c *Cloud) CallOfEditContainer(
 func (c *Cloud) QueryPodLen(
 	__ink_params chain.DryRunParams,
 ) (*uint64, *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.QueryPodLenContext(context.Background(), __ink_params)
+}
+
+func (c *Cloud) QueryPodLenContext(
+	ctx context.Context, __ink_params chain.DryRunParams,
+) (*uint64, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "pod_len")
 	}
-	v, gas, err := chain.DryRunInk[uint64](
+	v, gas, err := chain.DryRunInkContext[uint64](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -655,7 +1138,7 @@ func (c *Cloud) QueryPodLen(
 			Args:     []any{},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	return v, gas, nil
@@ -664,11 +1147,18 @@ func (c *Cloud) QueryPodLen(
 func (c *Cloud) QueryPods(
 	start util.Option[uint64], size uint64, __ink_params chain.DryRunParams,
 ) (*[]Tuple_106, *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.QueryPodsContext(context.Background(), start, size, __ink_params)
+}
+
+func (c *Cloud) QueryPodsContext(
+	ctx context.Context, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams,
+) (*[]Tuple_106, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "pods")
 	}
-	v, gas, err := chain.DryRunInk[[]Tuple_106](
+	v, gas, err := chain.DryRunInkContext[[]Tuple_106](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -679,7 +1169,7 @@ func (c *Cloud) QueryPods(
 			Args:     []any{start, size},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	return v, gas, nil
@@ -688,11 +1178,18 @@ func (c *Cloud) QueryPods(
 func (c *Cloud) QueryUserPodLen(
 	__ink_params chain.DryRunParams,
 ) (*uint32, *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.QueryUserPodLenContext(context.Background(), __ink_params)
+}
+
+func (c *Cloud) QueryUserPodLenContext(
+	ctx context.Context, __ink_params chain.DryRunParams,
+) (*uint32, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "user_pod_len")
 	}
-	v, gas, err := chain.DryRunInk[uint32](
+	v, gas, err := chain.DryRunInkContext[uint32](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -703,7 +1200,7 @@ func (c *Cloud) QueryUserPodLen(
 			Args:     []any{},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	return v, gas, nil
@@ -712,11 +1209,18 @@ func (c *Cloud) QueryUserPodLen(
 func (c *Cloud) QueryUserPods(
 	start util.Option[uint32], size uint32, __ink_params chain.DryRunParams,
 ) (*[]Tuple_106, *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.QueryUserPodsContext(context.Background(), start, size, __ink_params)
+}
+
+func (c *Cloud) QueryUserPodsContext(
+	ctx context.Context, start util.Option[uint32], size uint32, __ink_params chain.DryRunParams,
+) (*[]Tuple_106, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "user_pods")
 	}
-	v, gas, err := chain.DryRunInk[[]Tuple_106](
+	v, gas, err := chain.DryRunInkContext[[]Tuple_106](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -727,7 +1231,7 @@ func (c *Cloud) QueryUserPods(
 			Args:     []any{start, size},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	return v, gas, nil
@@ -736,11 +1240,18 @@ func (c *Cloud) QueryUserPods(
 func (c *Cloud) QueryWorkerPodsVersion(
 	worker_id uint64, __ink_params chain.DryRunParams,
 ) (*[]Tuple_112, *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.QueryWorkerPodsVersionContext(context.Background(), worker_id, __ink_params)
+}
+
+func (c *Cloud) QueryWorkerPodsVersionContext(
+	ctx context.Context, worker_id uint64, __ink_params chain.DryRunParams,
+) (*[]Tuple_112, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "worker_pods_version")
 	}
-	v, gas, err := chain.DryRunInk[[]Tuple_112](
+	v, gas, err := chain.DryRunInkContext[[]Tuple_112](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -751,7 +1262,7 @@ func (c *Cloud) QueryWorkerPodsVersion(
 			Args:     []any{worker_id},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	return v, gas, nil
@@ -760,11 +1271,18 @@ func (c *Cloud) QueryWorkerPodsVersion(
 func (c *Cloud) QueryWorkerPods(
 	worker_id uint64, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams,
 ) (*[]Tuple_106, *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.QueryWorkerPodsContext(context.Background(), worker_id, start, size, __ink_params)
+}
+
+func (c *Cloud) QueryWorkerPodsContext(
+	ctx context.Context, worker_id uint64, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams,
+) (*[]Tuple_106, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "worker_pods")
 	}
-	v, gas, err := chain.DryRunInk[[]Tuple_106](
+	v, gas, err := chain.DryRunInkContext[[]Tuple_106](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -775,7 +1293,7 @@ func (c *Cloud) QueryWorkerPods(
 			Args:     []any{worker_id, start, size},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	return v, gas, nil
@@ -784,11 +1302,18 @@ func (c *Cloud) QueryWorkerPods(
 func (c *Cloud) QueryPod(
 	pod_id uint64, __ink_params chain.DryRunParams,
 ) (*util.Option[Tuple_115], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.QueryPodContext(context.Background(), pod_id, __ink_params)
+}
+
+func (c *Cloud) QueryPodContext(
+	ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams,
+) (*util.Option[Tuple_115], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "pod")
 	}
-	v, gas, err := chain.DryRunInk[util.Option[Tuple_115]](
+	v, gas, err := chain.DryRunInkContext[util.Option[Tuple_115]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -799,7 +1324,7 @@ func (c *Cloud) QueryPod(
 			Args:     []any{pod_id},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	return v, gas, nil
@@ -808,11 +1333,18 @@ func (c *Cloud) QueryPod(
 func (c *Cloud) QueryPodsByIds(
 	pod_ids []uint64, __ink_params chain.DryRunParams,
 ) (*[]Tuple_119, *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.QueryPodsByIdsContext(context.Background(), pod_ids, __ink_params)
+}
+
+func (c *Cloud) QueryPodsByIdsContext(
+	ctx context.Context, pod_ids []uint64, __ink_params chain.DryRunParams,
+) (*[]Tuple_119, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "pods_by_ids")
 	}
-	v, gas, err := chain.DryRunInk[[]Tuple_119](
+	v, gas, err := chain.DryRunInkContext[[]Tuple_119](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -823,7 +1355,7 @@ func (c *Cloud) QueryPodsByIds(
 			Args:     []any{pod_ids},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	return v, gas, nil
@@ -832,11 +1364,18 @@ func (c *Cloud) QueryPodsByIds(
 func (c *Cloud) QueryWorkerPodLen(
 	worker_id uint64, __ink_params chain.DryRunParams,
 ) (*uint64, *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.QueryWorkerPodLenContext(context.Background(), worker_id, __ink_params)
+}
+
+func (c *Cloud) QueryWorkerPodLenContext(
+	ctx context.Context, worker_id uint64, __ink_params chain.DryRunParams,
+) (*uint64, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "worker_pod_len")
 	}
-	v, gas, err := chain.DryRunInk[uint64](
+	v, gas, err := chain.DryRunInkContext[uint64](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -847,7 +1386,7 @@ func (c *Cloud) QueryWorkerPodLen(
 			Args:     []any{worker_id},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	return v, gas, nil
@@ -856,11 +1395,18 @@ func (c *Cloud) QueryWorkerPodLen(
 func (c *Cloud) QueryUserSecrets(
 	user types.H160, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams,
 ) (*[]Tuple_122, *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.QueryUserSecretsContext(context.Background(), user, start, size, __ink_params)
+}
+
+func (c *Cloud) QueryUserSecretsContext(
+	ctx context.Context, user types.H160, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams,
+) (*[]Tuple_122, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "user_secrets")
 	}
-	v, gas, err := chain.DryRunInk[[]Tuple_122](
+	v, gas, err := chain.DryRunInkContext[[]Tuple_122](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -871,7 +1417,7 @@ func (c *Cloud) QueryUserSecrets(
 			Args:     []any{user, start, size},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	return v, gas, nil
@@ -880,11 +1426,18 @@ func (c *Cloud) QueryUserSecrets(
 func (c *Cloud) QuerySecret(
 	user types.H160, index uint64, __ink_params chain.DryRunParams,
 ) (*util.Option[Secret], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.QuerySecretContext(context.Background(), user, index, __ink_params)
+}
+
+func (c *Cloud) QuerySecretContext(
+	ctx context.Context, user types.H160, index uint64, __ink_params chain.DryRunParams,
+) (*util.Option[Secret], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "secret")
 	}
-	v, gas, err := chain.DryRunInk[util.Option[Secret]](
+	v, gas, err := chain.DryRunInkContext[util.Option[Secret]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -895,7 +1448,7 @@ func (c *Cloud) QuerySecret(
 			Args:     []any{user, index},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	return v, gas, nil
@@ -904,11 +1457,18 @@ func (c *Cloud) QuerySecret(
 func (c *Cloud) DryRunInitSecret(
 	name []byte, __ink_params chain.DryRunParams,
 ) (*util.Result[uint64, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunInitSecretContext(context.Background(), name, __ink_params)
+}
+
+func (c *Cloud) DryRunInitSecretContext(
+	ctx context.Context, name []byte, __ink_params chain.DryRunParams,
+) (*util.Result[uint64, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "init_secret")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[uint64, Error]](
+	v, gas, err := chain.DryRunInkContext[util.Result[uint64, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -919,11 +1479,29 @@ func (c *Cloud) DryRunInitSecret(
 			Args:     []any{name},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x0b67c2ff",
+				Args:     []any{name},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
@@ -931,14 +1509,26 @@ func (c *Cloud) DryRunInitSecret(
 
 func (c *Cloud) ExecInitSecret(
 	name []byte, __ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.ExecInitSecretContext(context.Background(), name, __ink_params)
+}
+
+func (c *Cloud) ExecInitSecretContext(
+	ctx context.Context, name []byte, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunInitSecret(name, _param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunInitSecretContext(ctx, name, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -953,7 +1543,14 @@ func (c *Cloud) ExecInitSecret(
 func (c *Cloud) CallOfInitSecret(
 	name []byte, __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunInitSecret(name, __ink_params)
+	return c.CallOfInitSecretContext(context.Background(), name, __ink_params)
+}
+
+func (c *Cloud) CallOfInitSecretContext(
+	ctx context.Context, name []byte, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunInitSecretContext(ctx, name, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -972,11 +1569,18 @@ func (c *Cloud) CallOfInitSecret(
 func (c *Cloud) DryRunUpdateSecret(
 	user types.H160, index uint64, hash types.H256, __ink_params chain.DryRunParams,
 ) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunUpdateSecretContext(context.Background(), user, index, hash, __ink_params)
+}
+
+func (c *Cloud) DryRunUpdateSecretContext(
+	ctx context.Context, user types.H160, index uint64, hash types.H256, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "update_secret")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[util.NullTuple, Error]](
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -987,11 +1591,29 @@ func (c *Cloud) DryRunUpdateSecret(
 			Args:     []any{user, index, hash},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x4972e7e8",
+				Args:     []any{user, index, hash},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
@@ -999,14 +1621,26 @@ func (c *Cloud) DryRunUpdateSecret(
 
 func (c *Cloud) ExecUpdateSecret(
 	user types.H160, index uint64, hash types.H256, __ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.ExecUpdateSecretContext(context.Background(), user, index, hash, __ink_params)
+}
+
+func (c *Cloud) ExecUpdateSecretContext(
+	ctx context.Context, user types.H160, index uint64, hash types.H256, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunUpdateSecret(user, index, hash, _param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunUpdateSecretContext(ctx, user, index, hash, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -1021,7 +1655,14 @@ func (c *Cloud) ExecUpdateSecret(
 func (c *Cloud) CallOfUpdateSecret(
 	user types.H160, index uint64, hash types.H256, __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunUpdateSecret(user, index, hash, __ink_params)
+	return c.CallOfUpdateSecretContext(context.Background(), user, index, hash, __ink_params)
+}
+
+func (c *Cloud) CallOfUpdateSecretContext(
+	ctx context.Context, user types.H160, index uint64, hash types.H256, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunUpdateSecretContext(ctx, user, index, hash, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -1040,11 +1681,18 @@ func (c *Cloud) CallOfUpdateSecret(
 func (c *Cloud) DryRunDelSecret(
 	index uint64, __ink_params chain.DryRunParams,
 ) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunDelSecretContext(context.Background(), index, __ink_params)
+}
+
+func (c *Cloud) DryRunDelSecretContext(
+	ctx context.Context, index uint64, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "del_secret")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[util.NullTuple, Error]](
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -1055,11 +1703,29 @@ func (c *Cloud) DryRunDelSecret(
 			Args:     []any{index},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x8f1a7248",
+				Args:     []any{index},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
@@ -1067,14 +1733,26 @@ func (c *Cloud) DryRunDelSecret(
 
 func (c *Cloud) ExecDelSecret(
 	index uint64, __ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.ExecDelSecretContext(context.Background(), index, __ink_params)
+}
+
+func (c *Cloud) ExecDelSecretContext(
+	ctx context.Context, index uint64, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunDelSecret(index, _param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunDelSecretContext(ctx, index, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -1089,7 +1767,14 @@ func (c *Cloud) ExecDelSecret(
 func (c *Cloud) CallOfDelSecret(
 	index uint64, __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunDelSecret(index, __ink_params)
+	return c.CallOfDelSecretContext(context.Background(), index, __ink_params)
+}
+
+func (c *Cloud) CallOfDelSecretContext(
+	ctx context.Context, index uint64, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunDelSecretContext(ctx, index, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -1108,11 +1793,18 @@ func (c *Cloud) CallOfDelSecret(
 func (c *Cloud) DryRunSetCode(
 	code_hash types.H256, __ink_params chain.DryRunParams,
 ) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunSetCodeContext(context.Background(), code_hash, __ink_params)
+}
+
+func (c *Cloud) DryRunSetCodeContext(
+	ctx context.Context, code_hash types.H256, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "set_code")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[util.NullTuple, Error]](
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -1123,11 +1815,29 @@ func (c *Cloud) DryRunSetCode(
 			Args:     []any{code_hash},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x694fb50f",
+				Args:     []any{code_hash},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
@@ -1135,14 +1845,26 @@ func (c *Cloud) DryRunSetCode(
 
 func (c *Cloud) ExecSetCode(
 	code_hash types.H256, __ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.ExecSetCodeContext(context.Background(), code_hash, __ink_params)
+}
+
+func (c *Cloud) ExecSetCodeContext(
+	ctx context.Context, code_hash types.H256, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunSetCode(code_hash, _param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunSetCodeContext(ctx, code_hash, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -1157,7 +1879,14 @@ func (c *Cloud) ExecSetCode(
 func (c *Cloud) CallOfSetCode(
 	code_hash types.H256, __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunSetCode(code_hash, __ink_params)
+	return c.CallOfSetCodeContext(context.Background(), code_hash, __ink_params)
+}
+
+func (c *Cloud) CallOfSetCodeContext(
+	ctx context.Context, code_hash types.H256, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunSetCodeContext(ctx, code_hash, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -1,7 +1,7 @@
 package pod
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"math/big"
 
@@ -10,18 +10,66 @@ import (
 	"github.com/wetee-dao/ink.go/util"
 )
 
-func DeployPodWithNew(id uint64, owner types.H160, __ink_params chain.DeployParams) (*types.H160, error) {
-	return __ink_params.Client.DeployContract(
-		__ink_params.Code, __ink_params.Signer, types.NewU128(*big.NewInt(0)),
+func DeployPodWithNew(id uint64, owner types.H160, __ink_params chain.DeployParams) (*types.H160, *chain.TxReceipt, error) {
+	return DeployPodWithNewContext(context.Background(), id, owner, __ink_params)
+}
+
+func DeployPodWithNewContext(ctx context.Context, id uint64, owner types.H160, __ink_params chain.DeployParams) (*types.H160, *chain.TxReceipt, error) {
+	return __ink_params.Client.DeployContractContext(
+		ctx, __ink_params.Code, __ink_params.Signer, types.NewU128(*big.NewInt(0)),
 		util.InkContractInput{
 			Selector: "0x9bae9d5e",
 			Args:     []any{id, owner},
 		},
 		__ink_params.Salt,
+		__ink_params.SubmitOptions,
 	)
 }
 
-func InitPodContract(client *chain.ChainClient, address string) (*Pod, error) {
+// PodAPI is the interface of Pod contract, it can be mocked in tests
+type PodAPI interface {
+	chain.Ink
+	DryRunCloud(__ink_params chain.DryRunParams) (*types.H160, *chain.DryRunReturnGas, error)
+	DryRunCloudContext(ctx context.Context, __ink_params chain.DryRunParams) (*types.H160, *chain.DryRunReturnGas, error)
+	ExecCloud(__ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecCloudContext(ctx context.Context, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfCloud(__ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfCloudContext(ctx context.Context, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunApprove(value util.Option[types.U256], __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunApproveContext(ctx context.Context, value util.Option[types.U256], __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecApprove(value util.Option[types.U256], __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecApproveContext(ctx context.Context, value util.Option[types.U256], __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfApprove(value util.Option[types.U256], __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfApproveContext(ctx context.Context, value util.Option[types.U256], __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunPayForWoker(worker types.H160, amount types.U256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunPayForWokerContext(ctx context.Context, worker types.H160, amount types.U256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecPayForWoker(worker types.H160, amount types.U256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecPayForWokerContext(ctx context.Context, worker types.H160, amount types.U256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfPayForWoker(worker types.H160, amount types.U256, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfPayForWokerContext(ctx context.Context, worker types.H160, amount types.U256, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunCharge(__ink_params chain.DryRunParams) (*util.NullTuple, *chain.DryRunReturnGas, error)
+	DryRunChargeContext(ctx context.Context, __ink_params chain.DryRunParams) (*util.NullTuple, *chain.DryRunReturnGas, error)
+	ExecCharge(__ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecChargeContext(ctx context.Context, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfCharge(__ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfChargeContext(ctx context.Context, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunWithdraw(amount types.U256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunWithdrawContext(ctx context.Context, amount types.U256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecWithdraw(amount types.U256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecWithdrawContext(ctx context.Context, amount types.U256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfWithdraw(amount types.U256, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfWithdrawContext(ctx context.Context, amount types.U256, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunSetCode(code_hash types.H256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunSetCodeContext(ctx context.Context, code_hash types.H256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecSetCode(code_hash types.H256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecSetCodeContext(ctx context.Context, code_hash types.H256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfSetCode(code_hash types.H256, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfSetCodeContext(ctx context.Context, code_hash types.H256, __ink_params chain.DryRunParams) (*types.Call, error)
+}
+
+var _ PodAPI = (*Pod)(nil)
+
+func InitPodContract(client chain.InkClient, address string) (*Pod, error) {
 	contractAddress, err := util.HexToH160(address)
 	if err != nil {
 		return nil, err
@@ -33,11 +81,11 @@ func InitPodContract(client *chain.ChainClient, address string) (*Pod, error) {
 }
 
 type Pod struct {
-	ChainClient *chain.ChainClient
+	ChainClient chain.InkClient
 	Address     types.H160
 }
 
-func (c *Pod) Client() *chain.ChainClient {
+func (c *Pod) Client() chain.InkClient {
 	return c.ChainClient
 }
 
@@ -48,11 +96,18 @@ func (c *Pod) ContractAddress() types.H160 {
 func (c *Pod) DryRunCloud(
 	__ink_params chain.DryRunParams,
 ) (*types.H160, *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunCloudContext(context.Background(), __ink_params)
+}
+
+func (c *Pod) DryRunCloudContext(
+	ctx context.Context, __ink_params chain.DryRunParams,
+) (*types.H160, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "cloud")
 	}
-	v, gas, err := chain.DryRunInk[types.H160](
+	v, gas, err := chain.DryRunInkContext[types.H160](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -63,22 +118,52 @@ func (c *Pod) DryRunCloud(
 			Args:     []any{},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0xb24fd0f6",
+				Args:     []any{},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	return v, gas, nil
 }
 
 func (c *Pod) ExecCloud(
 	__ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.ExecCloudContext(context.Background(), __ink_params)
+}
+
+func (c *Pod) ExecCloudContext(
+	ctx context.Context, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunCloud(_param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunCloudContext(ctx, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -93,7 +178,14 @@ func (c *Pod) ExecCloud(
 func (c *Pod) CallOfCloud(
 	__ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunCloud(__ink_params)
+	return c.CallOfCloudContext(context.Background(), __ink_params)
+}
+
+func (c *Pod) CallOfCloudContext(
+	ctx context.Context, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunCloudContext(ctx, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -112,11 +204,18 @@ func (c *Pod) CallOfCloud(
 func (c *Pod) DryRunApprove(
 	value util.Option[types.U256], __ink_params chain.DryRunParams,
 ) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunApproveContext(context.Background(), value, __ink_params)
+}
+
+func (c *Pod) DryRunApproveContext(
+	ctx context.Context, value util.Option[types.U256], __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "approve")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[util.NullTuple, Error]](
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -127,11 +226,29 @@ func (c *Pod) DryRunApprove(
 			Args:     []any{value},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x681266a0",
+				Args:     []any{value},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
@@ -139,14 +256,26 @@ func (c *Pod) DryRunApprove(
 
 func (c *Pod) ExecApprove(
 	value util.Option[types.U256], __ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.ExecApproveContext(context.Background(), value, __ink_params)
+}
+
+func (c *Pod) ExecApproveContext(
+	ctx context.Context, value util.Option[types.U256], __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunApprove(value, _param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunApproveContext(ctx, value, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -161,7 +290,14 @@ func (c *Pod) ExecApprove(
 func (c *Pod) CallOfApprove(
 	value util.Option[types.U256], __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunApprove(value, __ink_params)
+	return c.CallOfApproveContext(context.Background(), value, __ink_params)
+}
+
+func (c *Pod) CallOfApproveContext(
+	ctx context.Context, value util.Option[types.U256], __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunApproveContext(ctx, value, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -180,11 +316,18 @@ func (c *Pod) CallOfApprove(
 func (c *Pod) DryRunPayForWoker(
 	worker types.H160, amount types.U256, __ink_params chain.DryRunParams,
 ) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunPayForWokerContext(context.Background(), worker, amount, __ink_params)
+}
+
+func (c *Pod) DryRunPayForWokerContext(
+	ctx context.Context, worker types.H160, amount types.U256, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "pay_for_woker")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[util.NullTuple, Error]](
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -195,11 +338,29 @@ func (c *Pod) DryRunPayForWoker(
 			Args:     []any{worker, amount},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0xd51e3b30",
+				Args:     []any{worker, amount},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
@@ -207,14 +368,26 @@ func (c *Pod) DryRunPayForWoker(
 
 func (c *Pod) ExecPayForWoker(
 	worker types.H160, amount types.U256, __ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.ExecPayForWokerContext(context.Background(), worker, amount, __ink_params)
+}
+
+func (c *Pod) ExecPayForWokerContext(
+	ctx context.Context, worker types.H160, amount types.U256, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunPayForWoker(worker, amount, _param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunPayForWokerContext(ctx, worker, amount, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -229,7 +402,14 @@ func (c *Pod) ExecPayForWoker(
 func (c *Pod) CallOfPayForWoker(
 	worker types.H160, amount types.U256, __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunPayForWoker(worker, amount, __ink_params)
+	return c.CallOfPayForWokerContext(context.Background(), worker, amount, __ink_params)
+}
+
+func (c *Pod) CallOfPayForWokerContext(
+	ctx context.Context, worker types.H160, amount types.U256, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunPayForWokerContext(ctx, worker, amount, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -248,11 +428,18 @@ func (c *Pod) CallOfPayForWoker(
 func (c *Pod) DryRunCharge(
 	__ink_params chain.DryRunParams,
 ) (*util.NullTuple, *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunChargeContext(context.Background(), __ink_params)
+}
+
+func (c *Pod) DryRunChargeContext(
+	ctx context.Context, __ink_params chain.DryRunParams,
+) (*util.NullTuple, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "charge")
 	}
-	v, gas, err := chain.DryRunInk[util.NullTuple](
+	v, gas, err := chain.DryRunInkContext[util.NullTuple](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -263,22 +450,52 @@ func (c *Pod) DryRunCharge(
 			Args:     []any{},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x1906ffe6",
+				Args:     []any{},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	return v, gas, nil
 }
 
 func (c *Pod) ExecCharge(
 	__ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.ExecChargeContext(context.Background(), __ink_params)
+}
+
+func (c *Pod) ExecChargeContext(
+	ctx context.Context, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunCharge(_param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunChargeContext(ctx, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -293,7 +510,14 @@ func (c *Pod) ExecCharge(
 func (c *Pod) CallOfCharge(
 	__ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunCharge(__ink_params)
+	return c.CallOfChargeContext(context.Background(), __ink_params)
+}
+
+func (c *Pod) CallOfChargeContext(
+	ctx context.Context, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunChargeContext(ctx, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -312,11 +536,18 @@ func (c *Pod) CallOfCharge(
 func (c *Pod) DryRunWithdraw(
 	amount types.U256, __ink_params chain.DryRunParams,
 ) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunWithdrawContext(context.Background(), amount, __ink_params)
+}
+
+func (c *Pod) DryRunWithdrawContext(
+	ctx context.Context, amount types.U256, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "withdraw")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[util.NullTuple, Error]](
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -327,11 +558,29 @@ func (c *Pod) DryRunWithdraw(
 			Args:     []any{amount},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x410fcc9d",
+				Args:     []any{amount},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
@@ -339,14 +588,26 @@ func (c *Pod) DryRunWithdraw(
 
 func (c *Pod) ExecWithdraw(
 	amount types.U256, __ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.ExecWithdrawContext(context.Background(), amount, __ink_params)
+}
+
+func (c *Pod) ExecWithdrawContext(
+	ctx context.Context, amount types.U256, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunWithdraw(amount, _param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunWithdrawContext(ctx, amount, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -361,7 +622,14 @@ func (c *Pod) ExecWithdraw(
 func (c *Pod) CallOfWithdraw(
 	amount types.U256, __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunWithdraw(amount, __ink_params)
+	return c.CallOfWithdrawContext(context.Background(), amount, __ink_params)
+}
+
+func (c *Pod) CallOfWithdrawContext(
+	ctx context.Context, amount types.U256, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunWithdrawContext(ctx, amount, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -380,11 +648,18 @@ func (c *Pod) CallOfWithdraw(
 func (c *Pod) DryRunSetCode(
 	code_hash types.H256, __ink_params chain.DryRunParams,
 ) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunSetCodeContext(context.Background(), code_hash, __ink_params)
+}
+
+func (c *Pod) DryRunSetCodeContext(
+	ctx context.Context, code_hash types.H256, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "set_code")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[util.NullTuple, Error]](
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -395,11 +670,29 @@ func (c *Pod) DryRunSetCode(
 			Args:     []any{code_hash},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x694fb50f",
+				Args:     []any{code_hash},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
@@ -407,14 +700,26 @@ func (c *Pod) DryRunSetCode(
 
 func (c *Pod) ExecSetCode(
 	code_hash types.H256, __ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.ExecSetCodeContext(context.Background(), code_hash, __ink_params)
+}
+
+func (c *Pod) ExecSetCodeContext(
+	ctx context.Context, code_hash types.H256, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunSetCode(code_hash, _param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunSetCodeContext(ctx, code_hash, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -429,7 +734,14 @@ func (c *Pod) ExecSetCode(
 func (c *Pod) CallOfSetCode(
 	code_hash types.H256, __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunSetCode(code_hash, __ink_params)
+	return c.CallOfSetCodeContext(context.Background(), code_hash, __ink_params)
+}
+
+func (c *Pod) CallOfSetCodeContext(
+	ctx context.Context, code_hash types.H256, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunSetCodeContext(ctx, code_hash, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +39,7 @@ func ExampleExtrinsic() {
 		panic(err)
 	}
 
-	err = client.SignAndSubmit(&p, call, true, 0)
+	_, err = client.SignAndSubmit(&p, call, true, 0)
 	if err != nil {
 		printErrorStack(err)
 		return
@@ -31,8 +31,9 @@ func TestDssSubmitTx(t *testing.T) {
 	}
 
 	util.LogWithYellow("MakeMapAccount", pk.Address)
-	err = client.SignAndSubmit(&pk, call, true, 0)
+	receipt, err := client.SignAndSubmit(&pk, call, true, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
+	fmt.Println("block", receipt.BlockNumber, "fee", receipt.Fee.String())
 }
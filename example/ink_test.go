@@ -36,6 +36,7 @@ func TestInk(t *testing.T) {
 
 	// query data
 	data, _, err := contract.QueryUserPods(
+		util.NewNone[uint32](), 100,
 		chain.DefaultParamWithOrigin(p.AccountID()),
 	)
 	if err != nil {
@@ -43,7 +44,10 @@ func TestInk(t *testing.T) {
 	}
 	initLen := len(*data)
 
-	err = contract.ExecCreateUserPod(
+	cpu := true
+	sgx := true
+	_, err = contract.ExecCreatePod(
+		[]byte("test"), cloud.PodType{CPU: &cpu}, cloud.TEEType{SGX: &sgx}, []cloud.Container{}, 0, 0, 0,
 		chain.ExecParams{
 			Signer:    &p,
 			PayAmount: types.NewU128(*big.NewInt(0)),
@@ -55,6 +59,7 @@ func TestInk(t *testing.T) {
 
 	// query data
 	data2, _, err := contract.QueryUserPods(
+		util.NewNone[uint32](), 100,
 		chain.DefaultParamWithOrigin(p.AccountID()),
 	)
 	if err != nil {
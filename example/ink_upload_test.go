@@ -30,7 +30,7 @@ func TestUpload(t *testing.T) {
 		util.LogWithPurple("read file error", err)
 		t.Fatal(err)
 	}
-	res, err := chainClient.UploadInkCode(data, &p)
+	res, _, err := chainClient.UploadInkCode(data, &p, chain.SubmitOptions{UntilFinalized: true})
 	if err != nil {
 		util.LogWithPurple("UploadInkCode", err)
 		t.Fatal(err)
@@ -66,7 +66,7 @@ func TestInitWithCode(t *testing.T) {
 	randomBytes := [32]byte{}
 	copy(randomBytes[:], bytes)
 
-	res, err := pod.DeployPodWithNew(1000, p.H160Address(), chain.DeployParams{
+	res, _, err := pod.DeployPodWithNew(1000, p.H160Address(), chain.DeployParams{
 		Client: chainClient,
 		Signer: &p,
 		Code:   util.InkCode{Upload: &data},
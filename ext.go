@@ -1,6 +1,7 @@
 package ink
 
 import (
+	"context"
 	"crypto/ed25519"
 	"fmt"
 
@@ -26,47 +27,40 @@ type Extrinsic struct {
 }
 
 func (e *Extrinsic) Sign(signer SignerType, meta *types.Metadata, opts ...extrinsic.SigningOption) error {
-	if e.Type() != extrinsic.Version4 {
-		return extrinsic.ErrInvalidVersion.WithMsg("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(), e.Type())
-	}
+	return e.SignContext(context.Background(), signer, meta, opts...)
+}
 
-	encodedMethod, err := codec.Encode(e.Method)
+// 签名交易，ctx 用于远程签名者等需要等待的签名者
+// Sign extrinsic with context, ctx is used by signers that wait, such as remote signers
+func (e *Extrinsic) SignContext(ctx context.Context, signer SignerType, meta *types.Metadata, opts ...extrinsic.SigningOption) error {
+	payload, err := e.signingPayload(meta, opts...)
 	if err != nil {
 		return err
 	}
 
-	fieldValues := extrinsic.SignedFieldValues{}
-	for _, opt := range opts {
-		opt(fieldValues)
-	}
-
-	payload, err := createPayload(meta, encodedMethod)
+	sig, err := payloadSign(ctx, signer, payload) //payload.Sign(signer)
 	if err != nil {
-		return extrinsic.ErrPayloadCreation.Wrap(err)
+		return extrinsic.ErrPayloadSigning.Wrap(err)
 	}
 
-	if err := payload.MutateSignedFields(fieldValues); err != nil {
-		return extrinsic.ErrPayloadMutation.Wrap(err)
-	}
+	return e.attachSignature(signer.AccountID(), signer.SignType(), sig, payload)
+}
 
-	signerPubKey, err := types.NewMultiAddressFromAccountID(signer.Public())
+// 将签名附加到交易，并标记交易为已签名
+// Attach signature to extrinsic and mark the extrinsic as signed
+func (e *Extrinsic) attachSignature(account types.AccountID, keyType uint8, sig []byte, payload *extrinsic.Payload) error {
+	// ecdsa 公钥不是账户，签名账户统一使用 AccountID
+	// the public key of ecdsa is not the account, so signer account is always AccountID
+	signerPubKey, err := types.NewMultiAddressFromAccountID(account[:])
 	if err != nil {
 		return err
 	}
 
-	sig, err := PayloadSign(signer, payload) //payload.Sign(signer)
+	signature, err := NewMultiSignature(keyType, sig)
 	if err != nil {
 		return extrinsic.ErrPayloadSigning.Wrap(err)
 	}
 
-	var signature types.MultiSignature
-	switch signer.SignType() {
-	case 0:
-		signature = types.MultiSignature{IsSr25519: true, AsSr25519: sig}
-	case 1:
-		signature = types.MultiSignature{IsEd25519: true, AsEd25519: sig}
-	}
-
 	extSignature := &extrinsic.Signature{
 		Signer:       signerPubKey,
 		Signature:    signature,
@@ -81,6 +75,35 @@ func (e *Extrinsic) Sign(signer SignerType, meta *types.Metadata, opts ...extrin
 	return nil
 }
 
+// 按签名参数构造交易的待签名负载
+// Build the signing payload of extrinsic with signing options
+func (e *Extrinsic) signingPayload(meta *types.Metadata, opts ...extrinsic.SigningOption) (*extrinsic.Payload, error) {
+	if e.Type() != extrinsic.Version4 {
+		return nil, extrinsic.ErrInvalidVersion.WithMsg("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(), e.Type())
+	}
+
+	encodedMethod, err := codec.Encode(e.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldValues := extrinsic.SignedFieldValues{}
+	for _, opt := range opts {
+		opt(fieldValues)
+	}
+
+	payload, err := createPayload(meta, encodedMethod)
+	if err != nil {
+		return nil, extrinsic.ErrPayloadCreation.Wrap(err)
+	}
+
+	if err := payload.MutateSignedFields(fieldValues); err != nil {
+		return nil, extrinsic.ErrPayloadMutation.Wrap(err)
+	}
+
+	return payload, nil
+}
+
 func createPayload(meta *types.Metadata, encodedCall []byte) (*extrinsic.Payload, error) {
 	payload := &extrinsic.Payload{
 		EncodedCall: encodedCall,
@@ -115,14 +138,37 @@ func createPayload(meta *types.Metadata, encodedCall []byte) (*extrinsic.Payload
 }
 
 func PayloadSign(signer SignerType, p *extrinsic.Payload) (sig types.SignatureHash, err error) {
+	signatureBytes, err := payloadSign(context.Background(), signer, p)
+	if err != nil {
+		return sig, err
+	}
+
+	sig = types.NewSignature(signatureBytes)
+
+	return sig, nil
+}
+
+// 签名交易负载，返回原始签名，ecdsa 签名为 65 字节
+// Sign payload and return the raw signature, ecdsa signature is 65 bytes
+func payloadSign(ctx context.Context, signer SignerType, p *extrinsic.Payload) ([]byte, error) {
+	// 远程签名者等签名前需要查看交易内容，签名由签名者自行验证
+	// signers such as remote signers inspect the extrinsic before signing, signatures are verified by themselves
+	if payloadSigner, ok := signer.(PayloadSignerType); ok {
+		signatureBytes, err := payloadSigner.SignPayloadContext(ctx, p)
+		if err != nil {
+			return nil, extrinsic.ErrPayloadSigning.Wrap(err)
+		}
+		return signatureBytes, nil
+	}
+
 	msg, err := codec.Encode(p)
 	if err != nil {
-		return sig, extrinsic.ErrPayloadEncoding.Wrap(err)
+		return nil, extrinsic.ErrPayloadEncoding.Wrap(err)
 	}
 
 	signatureBytes, err := signer.Sign(msg)
 	if err != nil {
-		return sig, extrinsic.ErrPayloadSigning.Wrap(err)
+		return nil, extrinsic.ErrPayloadSigning.Wrap(err)
 	}
 
 	if signer.SignType() == 1 {
@@ -137,7 +183,29 @@ func PayloadSign(signer SignerType, p *extrinsic.Payload) (sig types.SignatureHa
 		}
 	}
 
-	sig = types.NewSignature(signatureBytes)
+	return signatureBytes, nil
+}
 
-	return sig, nil
+// 按签名类型构造 MultiSignature，0 为 sr25519，1 为 ed25519，2 为 ecdsa
+// Build MultiSignature by key type, 0 is sr25519, 1 is ed25519 and 2 is ecdsa
+func NewMultiSignature(keyType uint8, sig []byte) (types.MultiSignature, error) {
+	switch keyType {
+	case KeyTypeSr25519:
+		if len(sig) != 64 {
+			return types.MultiSignature{}, fmt.Errorf("invalid sr25519 signature length %d", len(sig))
+		}
+		return types.MultiSignature{IsSr25519: true, AsSr25519: types.NewSignature(sig)}, nil
+	case KeyTypeEd25519:
+		if len(sig) != 64 {
+			return types.MultiSignature{}, fmt.Errorf("invalid ed25519 signature length %d", len(sig))
+		}
+		return types.MultiSignature{IsEd25519: true, AsEd25519: types.NewSignature(sig)}, nil
+	case KeyTypeEcdsa:
+		if len(sig) != 65 {
+			return types.MultiSignature{}, fmt.Errorf("invalid ecdsa signature length %d", len(sig))
+		}
+		return types.MultiSignature{IsEcdsa: true, AsEcdsa: types.NewEcdsaSignature(sig)}, nil
+	}
+
+	return types.MultiSignature{}, fmt.Errorf("unknown key type %d", keyType)
 }
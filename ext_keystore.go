@@ -0,0 +1,298 @@
+package ink
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// polkadot-js 密钥文件中 PKCS8 编码的前缀和公钥分隔符
+// Header and divider before public key of PKCS8 encoding in polkadot-js keystore
+var (
+	pkcs8Header  = []byte{48, 83, 2, 1, 1, 48, 5, 6, 3, 43, 101, 112, 4, 34, 4, 32}
+	pkcs8Divider = []byte{161, 35, 3, 33, 0}
+)
+
+// 导出密钥文件的 scrypt 参数，与 polkadot-js 默认值一致
+// Scrypt params of exported keystore, the same as defaults of polkadot-js
+const (
+	keystoreScryptN = 1 << 15
+	keystoreScryptP = 1
+	keystoreScryptR = 8
+)
+
+// polkadot-js / subkey 导出的 JSON 密钥文件
+// JSON keystore exported by polkadot-js / subkey
+type Keystore struct {
+	Encoded  string           `json:"encoded"`
+	Encoding KeystoreEncoding `json:"encoding"`
+	Address  string           `json:"address"`
+	Meta     map[string]any   `json:"meta,omitempty"`
+}
+
+// 密钥文件的编码方式，如 {"content": ["pkcs8", "sr25519"], "type": ["scrypt", "xsalsa20-poly1305"], "version": "3"}
+// Encoding of keystore, such as {"content": ["pkcs8", "sr25519"], "type": ["scrypt", "xsalsa20-poly1305"], "version": "3"}
+type KeystoreEncoding struct {
+	Content []string      `json:"content"`
+	Type    KeystoreTypes `json:"type"`
+	Version string        `json:"version"`
+}
+
+// 密钥文件的加密方式，旧版本（version 2）密钥文件为字符串，如 "xsalsa20-poly1305"
+// Encryption types of keystore, it is a string in old (version 2) keystores, such as "xsalsa20-poly1305"
+type KeystoreTypes []string
+
+func (t *KeystoreTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = KeystoreTypes{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// 解密 polkadot-js / subkey 导出的 JSON 密钥文件，支持 sr25519 和 ed25519 账户
+// Decrypt JSON keystore exported by polkadot-js / subkey, sr25519 and ed25519 accounts are supported
+func SignerFromKeystoreJSON(data []byte, password string) (Signer, error) {
+	var ks Keystore
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return Signer{}, errors.New("decode keystore: " + err.Error())
+	}
+
+	if len(ks.Encoding.Content) < 2 || ks.Encoding.Content[0] != "pkcs8" {
+		return Signer{}, fmt.Errorf("unsupported keystore content %v", ks.Encoding.Content)
+	}
+	// 旧版本密钥文件的 encoded 为十六进制
+	// encoded is hex in old keystores
+	var encoded []byte
+	var err error
+	if strings.HasPrefix(ks.Encoded, "0x") {
+		encoded, err = hex.DecodeString(ks.Encoded[2:])
+	} else {
+		encoded, err = base64.StdEncoding.DecodeString(ks.Encoded)
+	}
+	if err != nil {
+		return Signer{}, errors.New("decode keystore: " + err.Error())
+	}
+
+	decoded, err := decryptKeystore(encoded, ks.Encoding.Type, password)
+	if err != nil {
+		return Signer{}, err
+	}
+
+	var keyType uint8
+	var secretLen int
+	switch ks.Encoding.Content[1] {
+	case "sr25519":
+		keyType, secretLen = KeyTypeSr25519, 64
+	case "ed25519":
+		keyType, secretLen = KeyTypeEd25519, 64
+	default:
+		return Signer{}, fmt.Errorf("unsupported key type %s", ks.Encoding.Content[1])
+	}
+
+	secret, public, err := decodePkcs8(decoded, secretLen)
+	if err != nil {
+		return Signer{}, err
+	}
+
+	var kyr subkey.KeyPair
+	if keyType == KeyTypeSr25519 {
+		// polkadot-js 保存 ed25519 格式的 sr25519 私钥，需要除以 cofactor
+		// polkadot-js keeps sr25519 secret key in ed25519 format, it is divided by cofactor
+		key := divideScalarByCofactor(secret[:32])
+		kyr, err = sr25519.Scheme{}.FromSeed(append(key, secret[32:]...))
+	} else {
+		kyr, err = ed25519.Scheme{}.FromSeed(secret[:32])
+	}
+	if err != nil {
+		return Signer{}, err
+	}
+	if !bytes.Equal(kyr.Public(), public) {
+		return Signer{}, errors.New("public key of keystore does not match the secret key")
+	}
+
+	var network uint16 = 42
+	if ks.Address != "" {
+		network, _, err = subkey.SS58Decode(ks.Address)
+		if err != nil {
+			return Signer{}, errors.New("decode address: " + err.Error())
+		}
+	}
+
+	return Signer{
+		KeyType:   keyType,
+		KeyPair:   kyr,
+		Address:   kyr.SS58Address(network),
+		PublicKey: kyr.Public(),
+	}, nil
+}
+
+// 导出为 polkadot-js 格式的 JSON 密钥文件，使用 scrypt 和 xsalsa20-poly1305 加密
+// Export signer as JSON keystore of polkadot-js format, encrypted by scrypt and xsalsa20-poly1305
+func (e *Signer) KeystoreJSON(password string, name string) ([]byte, error) {
+	var content string
+	var secret []byte
+	switch e.KeyType {
+	case KeyTypeSr25519:
+		content = "sr25519"
+		seed := e.KeyPair.Seed()
+		switch len(seed) {
+		case 32:
+			// 与 schnorrkel ExpandEd25519 相同，ed25519 格式的私钥为未除以 cofactor 的值
+			// the same as ExpandEd25519 of schnorrkel, the key in ed25519 format is not divided by cofactor
+			h := sha512.Sum512(seed)
+			h[0] &= 248
+			h[31] &= 63
+			h[31] |= 64
+			secret = h[:]
+		case 64:
+			secret = append(multiplyScalarByCofactor(seed[:32]), seed[32:]...)
+		default:
+			return nil, errors.New("invalid sr25519 secret key")
+		}
+	case KeyTypeEd25519:
+		content = "ed25519"
+		secret = append(slices.Clone(e.KeyPair.Seed()), e.PublicKey...)
+	default:
+		return nil, fmt.Errorf("unsupported key type %d", e.KeyType)
+	}
+
+	plain := slices.Concat(pkcs8Header, secret, pkcs8Divider, e.PublicKey)
+
+	salt := make([]byte, 32)
+	var nonce [24]byte
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+	key, err := scrypt.Key([]byte(password), salt, keystoreScryptN, keystoreScryptR, keystoreScryptP, 64)
+	if err != nil {
+		return nil, errors.New("scrypt: " + err.Error())
+	}
+	var secretKey [32]byte
+	copy(secretKey[:], key)
+
+	encoded := binary.LittleEndian.AppendUint32(salt, keystoreScryptN)
+	encoded = binary.LittleEndian.AppendUint32(encoded, keystoreScryptP)
+	encoded = binary.LittleEndian.AppendUint32(encoded, keystoreScryptR)
+	encoded = append(encoded, nonce[:]...)
+	encoded = secretbox.Seal(encoded, plain, &nonce, &secretKey)
+
+	return json.Marshal(Keystore{
+		Encoded: base64.StdEncoding.EncodeToString(encoded),
+		Encoding: KeystoreEncoding{
+			Content: []string{"pkcs8", content},
+			Type:    KeystoreTypes{"scrypt", "xsalsa20-poly1305"},
+			Version: "3",
+		},
+		Address: e.Address,
+		Meta: map[string]any{
+			"name":        name,
+			"whenCreated": time.Now().UnixMilli(),
+		},
+	})
+}
+
+// 解密密钥文件，旧版本密钥文件不使用 scrypt，密码补零到 32 字节作为密钥
+// Decrypt keystore, old keystore without scrypt uses password padded to 32 bytes as key
+func decryptKeystore(encoded []byte, encType []string, password string) ([]byte, error) {
+	if !slices.Contains(encType, "xsalsa20-poly1305") {
+		if slices.Contains(encType, "none") {
+			return encoded, nil
+		}
+		return nil, fmt.Errorf("unsupported keystore encryption %v", encType)
+	}
+
+	var key [32]byte
+	if slices.Contains(encType, "scrypt") {
+		if len(encoded) < 44 {
+			return nil, errors.New("invalid keystore: scrypt params are missing")
+		}
+		salt := encoded[:32]
+		n := binary.LittleEndian.Uint32(encoded[32:36])
+		p := binary.LittleEndian.Uint32(encoded[36:40])
+		r := binary.LittleEndian.Uint32(encoded[40:44])
+		derived, err := scrypt.Key([]byte(password), salt, int(n), int(r), int(p), 64)
+		if err != nil {
+			return nil, errors.New("scrypt: " + err.Error())
+		}
+		copy(key[:], derived)
+		encoded = encoded[44:]
+	} else {
+		copy(key[:], password)
+	}
+
+	if len(encoded) < 24 {
+		return nil, errors.New("invalid keystore: nonce is missing")
+	}
+	var nonce [24]byte
+	copy(nonce[:], encoded[:24])
+	decoded, ok := secretbox.Open(nil, encoded[24:], &nonce, &key)
+	if !ok {
+		return nil, errors.New("invalid password or keystore")
+	}
+
+	return decoded, nil
+}
+
+func decodePkcs8(decoded []byte, secretLen int) (secret, public []byte, err error) {
+	if !bytes.HasPrefix(decoded, pkcs8Header) {
+		return nil, nil, errors.New("invalid pkcs8 header")
+	}
+	divider := len(pkcs8Header) + secretLen
+	if len(decoded) < divider+len(pkcs8Divider) || !bytes.Equal(decoded[divider:divider+len(pkcs8Divider)], pkcs8Divider) {
+		return nil, nil, errors.New("invalid pkcs8 divider")
+	}
+
+	return decoded[len(pkcs8Header):divider], decoded[divider+len(pkcs8Divider):], nil
+}
+
+// 小端标量除以 cofactor 8
+// Divide little endian scalar by cofactor 8
+func divideScalarByCofactor(s []byte) []byte {
+	out := slices.Clone(s)
+	low := byte(0)
+	for i := len(out) - 1; i >= 0; i-- {
+		r := out[i] & 0b0000_0111
+		out[i] = out[i]>>3 + low
+		low = r << 5
+	}
+	return out
+}
+
+// 小端标量乘以 cofactor 8
+// Multiply little endian scalar by cofactor 8
+func multiplyScalarByCofactor(s []byte) []byte {
+	out := slices.Clone(s)
+	high := byte(0)
+	for i := range out {
+		r := out[i] & 0b1110_0000
+		out[i] = out[i]<<3 + high
+		high = r >> 5
+	}
+	return out
+}
@@ -0,0 +1,126 @@
+package ink
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+)
+
+func TestKeystoreJSON(t *testing.T) {
+	sr, err := Sr25519PairFromSecret("0xe5be9a5092b81bca64be81d212e7f2f9eba183bb7a90954f7b76361f6edb5c0a", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed, err := Ed25519PairFromSecret("//Alice", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, signer := range []Signer{sr, ed} {
+		data, err := signer.KeystoreJSON("password", "alice")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var ks Keystore
+		if err := json.Unmarshal(data, &ks); err != nil || ks.Encoding.Version != "3" || ks.Address != signer.Address {
+			t.Fatalf("keystore %s %v", data, err)
+		}
+
+		imported, err := SignerFromKeystoreJSON(data, "password")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if imported.AccountID() != signer.AccountID() || imported.Address != signer.Address || imported.KeyType != signer.KeyType {
+			t.Errorf("imported %s, expected %s", imported.Address, signer.Address)
+		}
+
+		msg := []byte("hello")
+		sig, err := imported.Sign(msg)
+		if err != nil || !signer.Verify(msg, sig) {
+			t.Errorf("signature of imported signer: %v", err)
+		}
+
+		// 64 bytes sr25519 secret of imported signer is exported again
+		again, err := imported.KeystoreJSON("password", "alice")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reimported, err := SignerFromKeystoreJSON(again, "password"); err != nil || reimported.AccountID() != signer.AccountID() {
+			t.Errorf("reimported: %v", err)
+		}
+
+		if _, err := SignerFromKeystoreJSON(data, "wrong"); err == nil {
+			t.Error("wrong password is accepted")
+		}
+	}
+}
+
+// @polkadot/keyring 测试账户 Alice 的 secretKey，即 ed25519 格式的 key 和 nonce
+// secretKey of Alice in testing pairs of @polkadot/keyring, which is key in ed25519 format and nonce
+var polkadotJsAliceSecret = "98319d4ff8a9508c4bb0cf0b5a78d760a0b2082c02775e6e82370816fedfff48925a225d97aa00682d6a59b95b18780c10d7032336e88f3442b42361f4a66011"
+
+// polkadot-js 格式的 Alice 密钥文件，PKCS8 前缀和分隔符之间为 polkadotJsAliceSecret，密码为 polkadot
+// Keystores of Alice in polkadot-js format, polkadotJsAliceSecret is between PKCS8 header and divider, the password is polkadot
+var polkadotJsKeystores = map[string]string{
+	"version 3": `{
+		"encoded": "AAcOFRwjKjE4P0ZNVFtiaXB3foWMk5qhqK+2vcTL0tkAgAAAAQAAAAgAAADIx8bFxMPCwcC/vr28u7q5uLe2tbSzsrHaKFPKGfzbkeqssUQ8cmXWGJyMl6xaMkg3ZqVjKcdsDEzHNz9d12SA/qGBNIsMTsErBq2Zrjc7zFKTKfe5CA8+SjUd6xYuUGerjCG1zNfOF4dVN8uHRFdSRFq+xKKwCfXtJeg3QKgPX+ZNDBPOZgw/wPvmzIBnZIrLTesz7Vgh2Sydu2ez",
+		"encoding": {"content": ["pkcs8", "sr25519"], "type": ["scrypt", "xsalsa20-poly1305"], "version": "3"},
+		"address": "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+		"meta": {"genesisHash": "", "name": "alice", "whenCreated": 1600000000000}
+	}`,
+	"version 2": `{
+		"address": "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+		"encoded": "0x0102030405060708090a0b0c0d0e0f101112131415161718cf76e04d1b5a3466365ae9da861732cd1eb3d35873cd79a32ac2250feb4141531e9f7d846df380b2b81aa06b0d046d41da635fc4bd4ea5e967fd994b16af4885f3b10d19059788d52c226312af0744132f7802e58de073e1baee18a7da88726173870b091e431d989f3c2e31d120aae1494e9df0cd73aca122172abd5281f2b796c6f7a66b",
+		"encoding": {"content": ["pkcs8", "sr25519"], "type": "xsalsa20-poly1305", "version": "2"},
+		"meta": {"name": "alice", "whenCreated": 1560000000000}
+	}`,
+}
+
+func TestPolkadotJsKeystore(t *testing.T) {
+	alice, err := Sr25519PairFromSecret("//Alice", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 导出的私钥与 polkadot-js 的相同
+	// exported secret key is the same as the one of polkadot-js
+	data, err := alice.KeystoreJSON("polkadot", "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ks Keystore
+	if err := json.Unmarshal(data, &ks); err != nil {
+		t.Fatal(err)
+	}
+	encoded, _ := base64.StdEncoding.DecodeString(ks.Encoded)
+	decoded, err := decryptKeystore(encoded, ks.Encoding.Type, "polkadot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret, _, err := decodePkcs8(decoded, 64)
+	if err != nil || hex.EncodeToString(secret) != polkadotJsAliceSecret {
+		t.Errorf("exported secret %x %v", secret, err)
+	}
+
+	for version, data := range polkadotJsKeystores {
+		signer, err := SignerFromKeystoreJSON([]byte(data), "polkadot")
+		if err != nil {
+			t.Fatalf("%s: %v", version, err)
+		}
+		if signer.AccountID() != alice.AccountID() || signer.Address != alice.Address || signer.KeyType != KeyTypeSr25519 {
+			t.Errorf("%s: imported %s, expected %s", version, signer.Address, alice.Address)
+		}
+
+		msg := []byte("hello")
+		sig, err := signer.Sign(msg)
+		if err != nil || !alice.Verify(msg, sig) {
+			t.Errorf("%s: signature of imported signer: %v", version, err)
+		}
+
+		if _, err := SignerFromKeystoreJSON([]byte(data), "wrong"); err == nil {
+			t.Errorf("%s: wrong password is accepted", version)
+		}
+	}
+}
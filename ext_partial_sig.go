@@ -1,56 +1,51 @@
 package ink
 
 import (
+	"context"
 	"errors"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic/extensions"
 )
 
-// 签名并提交交易
-// Sign and submit transaction
+// 部分签名交易
+// Partial sign transaction
 func (c *ChainClient) PartialSign(signer PartialSignerType, call types.Call) ([]byte, error) {
-	accountInfo, err := c.GetAccount(signer)
-	if err != nil {
-		return nil, errors.New("GetAccountInfo error: " + err.Error())
-	}
+	return c.PartialSignContext(context.Background(), signer, call)
+}
 
-	ext := NewExtrinsic(call)
-	return ext.PartialSign(signer, c.Meta, extrinsic.WithEra(types.ExtrinsicEra{IsImmortalEra: true}, c.Hash),
-		extrinsic.WithNonce(types.NewUCompactFromUInt(uint64(accountInfo.Nonce))),
-		extrinsic.WithTip(types.NewUCompactFromUInt(0)),
-		extrinsic.WithSpecVersion(c.Runtime.SpecVersion),
-		extrinsic.WithTransactionVersion(c.Runtime.TransactionVersion),
-		extrinsic.WithGenesisHash(c.Hash),
-		extrinsic.WithMetadataMode(extensions.CheckMetadataModeDisabled, extensions.CheckMetadataHash{Hash: types.NewEmptyOption[types.H256]()}),
-		extrinsic.WithAssetID(types.NewEmptyOption[types.AssetID]()),
-	)
+// 部分签名交易
+// Partial sign transaction with context
+func (c *ChainClient) PartialSignContext(ctx context.Context, signer PartialSignerType, call types.Call) ([]byte, error) {
+	return c.PartialSignWithOptions(ctx, signer, call, SubmitOptions{})
 }
 
-func (e *Extrinsic) PartialSign(signer PartialSignerType, meta *types.Metadata, opts ...extrinsic.SigningOption) ([]byte, error) {
-	if e.Type() != extrinsic.Version4 {
-		return nil, extrinsic.ErrInvalidVersion.WithMsg("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(), e.Type())
+// 按提交参数部分签名交易，nonce 为 0 时使用链上账户 nonce
+// Partial sign transaction with options, the on-chain nonce of account is used when nonce is 0
+func (c *ChainClient) PartialSignWithOptions(ctx context.Context, signer PartialSignerType, call types.Call, opts SubmitOptions) ([]byte, error) {
+	nonce := opts.Nonce
+	if nonce == 0 {
+		accountInfo, err := c.GetAccountContext(ctx, signer)
+		if err != nil {
+			return nil, errors.New("GetAccountInfo error: " + err.Error())
+		}
+		nonce = uint64(accountInfo.Nonce)
 	}
 
-	encodedMethod, err := codec.Encode(e.Method)
+	signOpts, err := c.signingOptions(ctx, nonce, opts.Tip, opts.Era)
 	if err != nil {
 		return nil, err
 	}
 
-	fieldValues := extrinsic.SignedFieldValues{}
-	for _, opt := range opts {
-		opt(fieldValues)
-	}
+	ext := NewExtrinsic(call)
+	return ext.PartialSign(signer, c.Meta, signOpts...)
+}
 
-	payload, err := createPayload(meta, encodedMethod)
+func (e *Extrinsic) PartialSign(signer PartialSignerType, meta *types.Metadata, opts ...extrinsic.SigningOption) ([]byte, error) {
+	payload, err := e.signingPayload(meta, opts...)
 	if err != nil {
-		return nil, extrinsic.ErrPayloadCreation.Wrap(err)
-	}
-
-	if err := payload.MutateSignedFields(fieldValues); err != nil {
-		return nil, extrinsic.ErrPayloadMutation.Wrap(err)
+		return nil, err
 	}
 
 	return PayloadPartialSign(signer, payload)
@@ -69,3 +64,178 @@ func PayloadPartialSign(signer PartialSignerType, p *extrinsic.Payload) (sig []b
 
 	return signatureBytes, nil
 }
+
+// 聚合部分签名得到最终签名，如门限签名、MuSig 等方案的聚合算法
+// Combine partial signatures into the final signature, such as aggregation of threshold signatures or MuSig
+type SignatureCombiner interface {
+	Combine(msg []byte, partials [][]byte) ([]byte, error)
+}
+
+// 函数形式的 SignatureCombiner
+// SignatureCombiner of function
+type SignatureCombinerFunc func(msg []byte, partials [][]byte) ([]byte, error)
+
+func (f SignatureCombinerFunc) Combine(msg []byte, partials [][]byte) ([]byte, error) {
+	return f(msg, partials)
+}
+
+// 多方签名的交易，创建时固定 nonce 和 era，各参与方对相同的负载部分签名，聚合后附加到交易并提交
+// Extrinsic signed by multiple parties, nonce and era are pinned when it is created,
+// all parties partial sign the same payload, and the combined signature is attached to the extrinsic before submission
+type PartialSignTx struct {
+	Extrinsic
+	Payload *extrinsic.Payload
+	// 聚合公钥，即签名账户的公钥
+	// aggregated public key, the public key of signing account
+	PublicKey []byte
+	KeyType   uint8
+	Nonce     uint64
+
+	// 创建时的提交参数，提交时使用 UntilFinalized 和 Timeout
+	// submit options of creation, UntilFinalized and Timeout are used when it is submitted
+	opts SubmitOptions
+	// nonce 由 ChainClient.Nonces 分配
+	// nonce is reserved from ChainClient.Nonces
+	managed bool
+	// 已取消的交易不能再聚合或提交
+	// cancelled extrinsic can not be combined or submitted any more
+	cancelled bool
+	client    *ChainClient
+}
+
+// 创建多方签名交易，opts.Nonce 为 0 时由 ChainClient.Nonces 分配。
+// 与 Close 一样，交易未通过 SubmitPartialSignTx 提交时必须调用 CancelPartialSignTx 归还 nonce，否则后续交易会卡在 nonce 空缺之后。
+// 收集部分签名可能需要很长时间，opts.Era 为 nil 时交易永久有效而不是使用 ChainClient.DefaultEra
+// Create extrinsic signed by multiple parties, the nonce is reserved from ChainClient.Nonces when opts.Nonce is 0.
+// Like Close, CancelPartialSignTx must be called to release the nonce when the extrinsic is not submitted by SubmitPartialSignTx,
+// otherwise later transactions are stuck behind the nonce gap. Collecting partial signatures may take long,
+// so the extrinsic is immortal instead of using ChainClient.DefaultEra when opts.Era is nil
+func (c *ChainClient) NewPartialSignTx(ctx context.Context, publicKey []byte, keyType uint8, call types.Call, opts SubmitOptions) (*PartialSignTx, error) {
+	if _, err := publicKeyOf(keyType, publicKey); err != nil {
+		return nil, err
+	}
+
+	era := opts.Era
+	if era == nil {
+		era = ImmortalEra()
+	}
+
+	tx := &PartialSignTx{
+		Extrinsic: NewExtrinsic(call),
+		PublicKey: publicKey,
+		KeyType:   keyType,
+		Nonce:     opts.Nonce,
+		opts:      opts,
+		managed:   opts.Nonce == 0,
+		client:    c,
+	}
+
+	account := tx.AccountID()
+	if tx.managed {
+		nonce, err := c.Nonces.Next(ctx, account)
+		if err != nil {
+			return nil, errors.New("NonceManager.Next error: " + err.Error())
+		}
+		tx.Nonce = nonce
+	}
+
+	signOpts, err := c.signingOptions(ctx, tx.Nonce, opts.Tip, era)
+	if err == nil {
+		tx.Payload, err = tx.signingPayload(c.Meta, signOpts...)
+	}
+	if err != nil {
+		tx.cancel()
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// 签名账户
+// Signing account
+func (t *PartialSignTx) AccountID() types.AccountID {
+	return accountIDOf(t.KeyType, t.PublicKey)
+}
+
+// 导出 SCALE 编码的待签名负载，分发给各参与方签名
+// Export SCALE encoded signing payload, it is sent to all parties to sign
+func (t *PartialSignTx) Message() ([]byte, error) {
+	msg, err := codec.Encode(t.Payload)
+	if err != nil {
+		return nil, extrinsic.ErrPayloadEncoding.Wrap(err)
+	}
+	return msg, nil
+}
+
+// 本地参与方部分签名
+// Partial sign by local party
+func (t *PartialSignTx) PartialSign(signer PartialSignerType) ([]byte, error) {
+	return PayloadPartialSign(signer, t.Payload)
+}
+
+// 聚合部分签名，验证聚合签名后附加到交易。聚合失败时交易被取消并归还 nonce，需重新创建交易
+// Combine partial signatures, the combined signature is verified and attached to extrinsic.
+// The extrinsic is cancelled and its nonce is released when combination fails, a new one must be created
+func (t *PartialSignTx) Combine(combiner SignatureCombiner, partials [][]byte) error {
+	if t.cancelled {
+		return errors.New("combine signatures: extrinsic is cancelled")
+	}
+	msg, err := t.Message()
+	if err != nil {
+		return err
+	}
+
+	sig, err := combiner.Combine(msg, partials)
+	if err != nil {
+		t.cancel()
+		return errors.New("combine signatures: " + err.Error())
+	}
+	if !verifySignature(t.KeyType, t.PublicKey, msg, sig) {
+		t.cancel()
+		return errors.New("combine signatures: invalid signature of aggregated public key")
+	}
+
+	return t.attachSignature(t.AccountID(), t.KeyType, sig, t.Payload)
+}
+
+// 提交已聚合签名的交易并等待结果
+// Submit extrinsic with combined signature and wait for its status
+func (c *ChainClient) SubmitPartialSignTx(ctx context.Context, t *PartialSignTx) (*TxReceipt, error) {
+	if t.cancelled {
+		return nil, errors.New("SubmitPartialSignTx: extrinsic is cancelled")
+	}
+	if !t.IsSigned() {
+		return nil, errors.New("SubmitPartialSignTx: signatures are not combined")
+	}
+
+	timeout := t.opts.Timeout
+	if timeout == 0 {
+		timeout = DefaultSubmitTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// 提交后 nonce 由 submitAndWatch 管理
+	// nonce is managed by submitAndWatch once it is submitted
+	managed := t.managed
+	t.managed = false
+	return c.submitAndWatch(ctx, t.Extrinsic.Extrinsic, t.AccountID(), t.Nonce, managed, t.opts.UntilFinalized)
+}
+
+// 放弃多方签名交易，归还分配的 nonce，每个未提交的交易都必须调用，重复调用无影响
+// Discard extrinsic signed by multiple parties and release the reserved nonce,
+// it must be called for every extrinsic which is not submitted, calling it again has no effect
+func (c *ChainClient) CancelPartialSignTx(t *PartialSignTx) {
+	t.cancel()
+}
+
+func (t *PartialSignTx) cancel() {
+	if t.managed {
+		t.client.Nonces.Release(t.AccountID(), t.Nonce)
+		t.managed = false
+	}
+	t.cancelled = true
+}
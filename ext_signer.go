@@ -3,15 +3,25 @@ package ink
 import (
 	"crypto"
 	goEd25519 "crypto/ed25519"
+	"fmt"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
 	"github.com/vedhavyas/go-subkey/v2/ed25519"
 	"github.com/vedhavyas/go-subkey/v2/sr25519"
 	"github.com/wetee-dao/ink.go/util"
 	"golang.org/x/crypto/blake2b"
 )
 
+// 签名类型
+// Key types of signer
+const (
+	KeyTypeSr25519 uint8 = 0
+	KeyTypeEd25519 uint8 = 1
+	KeyTypeEcdsa   uint8 = 2
+)
+
 type SignerType interface {
 	Public() []byte
 	AccountID() types.AccountID
@@ -29,7 +39,15 @@ type PartialSignerType interface {
 	PartialSign(msg []byte) ([]byte, error)
 }
 
-// Sr25519 Signer or Ed25519 Signer
+// 以太坊交易签名者
+// Signer of Ethereum transactions
+type EthSignerType interface {
+	SignerType
+	EthAddress() (types.H160, error)
+	SignEthHash(hash []byte) ([]byte, error)
+}
+
+// Sr25519, Ed25519 or Ecdsa Signer
 type Signer struct {
 	subkey.KeyPair
 	// Address is an SS58 address
@@ -62,18 +80,63 @@ func (e *Signer) Verify(msg []byte, signature []byte) bool {
 	return e.KeyPair.Verify(msg, signature)
 }
 
+// 账户在 pallet-revive 中的 H160 地址
+// H160 address of account in pallet-revive
 func (e *Signer) H160Address() types.H160 {
-	h160, _ := util.H160FromPublicKey(e.PublicKey)
+	account := e.AccountID()
+	h160, _ := util.H160FromPublicKey(account[:])
 	return h160
 }
 
+// 账户 id，ecdsa 账户为压缩公钥的 blake2_256 哈希
+// Account id, it is blake2_256 hash of compressed public key for ecdsa
 func (e *Signer) AccountID() types.AccountID {
+	return accountIDOf(e.KeyType, e.PublicKey)
+}
+
+// 按签名类型计算公钥对应的账户 id
+// Account id of public key by key type
+func accountIDOf(keyType uint8, public []byte) types.AccountID {
+	if keyType == KeyTypeEcdsa {
+		return types.AccountID(blake2b.Sum256(public))
+	}
+
 	var bt [32]byte
-	copy(bt[:], e.PublicKey)
+	copy(bt[:], public)
 
 	return types.AccountID(bt)
 }
 
+// 按签名类型解析公钥
+// Parse public key by key type
+func publicKeyOf(keyType uint8, public []byte) (subkey.PublicKey, error) {
+	switch keyType {
+	case KeyTypeSr25519:
+		return sr25519.Scheme{}.FromPublicKey(public)
+	case KeyTypeEd25519:
+		return ed25519.Scheme{}.FromPublicKey(public)
+	case KeyTypeEcdsa:
+		return ecdsa.Scheme{}.FromPublicKey(public)
+	}
+
+	return nil, fmt.Errorf("unknown key type %d", keyType)
+}
+
+// 使用公钥验证签名，与 Signer.Verify 相同，超过 256 字节的消息先做 blake2_256 哈希
+// Verify signature with public key, the same as Signer.Verify, messages longer than 256 bytes are hashed by blake2_256
+func verifySignature(keyType uint8, public []byte, msg []byte, signature []byte) bool {
+	pub, err := publicKeyOf(keyType, public)
+	if err != nil {
+		return false
+	}
+	if len(msg) > 256 {
+		h := blake2b.Sum256(msg)
+		msg = h[:]
+	}
+
+	return pub.Verify(msg, signature)
+}
+
 func NewSr25519Pair() (Signer, error) {
 	kyr, err := sr25519.Scheme{}.Generate()
 	if err != nil {
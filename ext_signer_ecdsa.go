@@ -0,0 +1,165 @@
+package ink
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cosmos/go-bip39"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+)
+
+// 以太坊钱包默认派生路径
+// Default derivation path of Ethereum wallets
+const DefaultEthDerivationPath = "m/44'/60'/0'/0/0"
+
+func NewEcdsaPair() (Signer, error) {
+	kyr, err := ecdsa.Scheme{}.Generate()
+	if err != nil {
+		return Signer{}, err
+	}
+
+	return newEcdsaSigner(kyr, 42), nil
+}
+
+// Ecdsa PairFromSecret generates a ecdsa key pair from a hex private key or a substrate secret uri
+func EcdsaPairFromSecret(seedOrPhrase string, network uint16) (Signer, error) {
+	kyr, err := subkey.DeriveKeyPair(ecdsa.Scheme{}, seedOrPhrase)
+	if err != nil {
+		return Signer{}, err
+	}
+
+	return newEcdsaSigner(kyr, network), nil
+}
+
+// 通过 BIP39 助记词和以太坊派生路径（BIP32）生成 ecdsa 密钥，path 为空时使用 DefaultEthDerivationPath，
+// 与 MetaMask 等以太坊钱包生成的私钥一致
+// Ecdsa PairFromMnemonic generates a ecdsa key pair from BIP39 mnemonic and Ethereum derivation path (BIP32),
+// DefaultEthDerivationPath is used when path is empty, the key is the same as Ethereum wallets such as MetaMask
+func EcdsaPairFromMnemonic(mnemonic, password, path string, network uint16) (Signer, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, password)
+	if err != nil {
+		return Signer{}, errors.New("bip39.NewSeed: " + err.Error())
+	}
+
+	if path == "" {
+		path = DefaultEthDerivationPath
+	}
+	key, err := deriveBip32Key(seed, path)
+	if err != nil {
+		return Signer{}, err
+	}
+
+	kyr, err := ecdsa.Scheme{}.FromSeed(key)
+	if err != nil {
+		return Signer{}, err
+	}
+
+	return newEcdsaSigner(kyr, network), nil
+}
+
+func newEcdsaSigner(kyr subkey.KeyPair, network uint16) Signer {
+	return Signer{
+		KeyType:   KeyTypeEcdsa,
+		KeyPair:   kyr,
+		Address:   kyr.SS58Address(network),
+		PublicKey: kyr.Public(),
+	}
+}
+
+// 以太坊地址，为未压缩公钥的 keccak256 哈希后 20 字节，仅 ecdsa 签名者有效
+// 通过 substrate 交易调用合约时，合约中的调用者为 H160Address
+// Ethereum address, the last 20 bytes of keccak256 hash of uncompressed public key, only valid for ecdsa signer.
+// The caller in contracts is H160Address when contracts are called by substrate extrinsics
+func (e *Signer) EthAddress() (types.H160, error) {
+	if e.KeyType != KeyTypeEcdsa {
+		return types.H160{}, errors.New("EthAddress: signer is not ecdsa")
+	}
+
+	pub, err := ethcrypto.DecompressPubkey(e.PublicKey)
+	if err != nil {
+		return types.H160{}, errors.New("DecompressPubkey: " + err.Error())
+	}
+
+	return types.H160(ethcrypto.PubkeyToAddress(*pub)), nil
+}
+
+// BIP32 派生 secp256k1 私钥
+// Derive secp256k1 private key by BIP32
+func deriveBip32Key(seed []byte, path string) ([]byte, error) {
+	segments := strings.Split(strings.TrimSpace(path), "/")
+	if len(segments) == 0 || segments[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path %q", path)
+	}
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	i := mac.Sum(nil)
+	key, chainCode := i[:32], i[32:]
+
+	n := ethcrypto.S256().Params().N
+	for _, segment := range segments[1:] {
+		hardened := strings.HasSuffix(segment, "'") || strings.HasSuffix(segment, "h")
+		index, err := strconv.ParseUint(strings.TrimRight(segment, "'h"), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path %q: %v", path, err)
+		}
+
+		data := make([]byte, 0, 37)
+		if hardened {
+			index += 1 << 31
+			data = append(data, 0)
+			data = append(data, key...)
+		} else {
+			priv, err := ethcrypto.ToECDSA(key)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, ethcrypto.CompressPubkey(&priv.PublicKey)...)
+		}
+		data = binary.BigEndian.AppendUint32(data, uint32(index))
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		i := mac.Sum(nil)
+
+		il := new(big.Int).SetBytes(i[:32])
+		if il.Cmp(n) >= 0 {
+			return nil, errors.New("invalid derived key, try the next index")
+		}
+		child := il.Add(il, new(big.Int).SetBytes(key))
+		child.Mod(child, n)
+		if child.Sign() == 0 {
+			return nil, errors.New("invalid derived key, try the next index")
+		}
+
+		key = child.FillBytes(make([]byte, 32))
+		chainCode = i[32:]
+	}
+
+	return key, nil
+}
+
+// 签名以太坊交易哈希，返回 [R || S || V] 格式的 65 字节签名，V 为 0 或 1，仅 ecdsa 签名者有效
+// Sign hash of Ethereum transaction and return 65 bytes signature in [R || S || V] format where V is 0 or 1,
+// only valid for ecdsa signer
+func (e *Signer) SignEthHash(hash []byte) ([]byte, error) {
+	if e.KeyType != KeyTypeEcdsa {
+		return nil, errors.New("SignEthHash: signer is not ecdsa")
+	}
+
+	priv, err := ethcrypto.ToECDSA(e.KeyPair.Seed())
+	if err != nil {
+		return nil, errors.New("ToECDSA: " + err.Error())
+	}
+
+	return ethcrypto.Sign(hash, priv)
+}
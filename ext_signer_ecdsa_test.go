@@ -0,0 +1,85 @@
+package ink
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic/extensions"
+	"github.com/vedhavyas/go-subkey/v2"
+	gtypes "github.com/wetee-dao/ink.go/pallet/types"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestEcdsaPairFromMnemonic(t *testing.T) {
+	p, err := EcdsaPairFromMnemonic("test test test test test test test test test test test junk", "", "", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if seed := subkey.EncodeHex(p.Seed()); seed != "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80" {
+		t.Errorf("private key %s", seed)
+	}
+	addr, err := p.EthAddress()
+	if err != nil || !strings.EqualFold(addr.Hex(), "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266") {
+		t.Errorf("eth address %s %v", addr.Hex(), err)
+	}
+
+	fromKey, err := EcdsaPairFromSecret("0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromKey.AccountID() != p.AccountID() || fromKey.Address != p.Address {
+		t.Errorf("account %s, expected %s", fromKey.Address, p.Address)
+	}
+	if p.AccountID() != types.AccountID(blake2b.Sum256(p.PublicKey)) {
+		t.Errorf("account id %x", p.AccountID())
+	}
+
+	if _, err := EcdsaPairFromMnemonic("test test test", "", "", 42); err == nil {
+		t.Error("invalid mnemonic is accepted")
+	}
+	if _, err := EcdsaPairFromMnemonic("test test test test test test test test test test test junk", "", "44'/60'", 42); err == nil {
+		t.Error("invalid path is accepted")
+	}
+}
+
+func TestEcdsaSignExtrinsic(t *testing.T) {
+	p, err := EcdsaPairFromSecret("//Alice", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := make([]byte, 300)
+	sig, err := p.Sign(body)
+	if err != nil || len(sig) != 65 || !p.Verify(body, sig) {
+		t.Fatalf("sign: %x %v", sig, err)
+	}
+
+	call, err := types.NewCall(&gtypes.Meta, "System.remark", []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ext := NewExtrinsic(call)
+	err = ext.Sign(&p, &gtypes.Meta,
+		extrinsic.WithEra(types.ExtrinsicEra{IsImmortalEra: true}, types.Hash{}),
+		extrinsic.WithNonce(types.NewUCompactFromUInt(0)),
+		extrinsic.WithTip(types.NewUCompactFromUInt(0)),
+		extrinsic.WithSpecVersion(1),
+		extrinsic.WithTransactionVersion(1),
+		extrinsic.WithGenesisHash(types.Hash{}),
+		extrinsic.WithMetadataMode(extensions.CheckMetadataModeDisabled, extensions.CheckMetadataHash{Hash: types.NewEmptyOption[types.H256]()}),
+		extrinsic.WithAssetID(types.NewEmptyOption[types.AssetID]()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ext.Signature.Signature.IsEcdsa {
+		t.Errorf("signature %+v", ext.Signature.Signature)
+	}
+	if ext.Signature.Signer.AsID != p.AccountID() {
+		t.Errorf("signer %x", ext.Signature.Signer.AsID)
+	}
+}
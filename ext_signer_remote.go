@@ -0,0 +1,582 @@
+package ink
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic/extensions"
+)
+
+// 远程签名请求的默认超时时间
+// Default timeout of remote signing requests
+const DefaultRemoteSignTimeout = 30 * time.Second
+
+// 按交易负载签名的签名者，签名交易时使用 SignPayloadContext 代替 Sign，可以查看签名的交易内容
+// Signer of extrinsic payloads, SignPayloadContext is used instead of Sign when extrinsics are signed, so the signed call can be inspected
+type PayloadSignerType interface {
+	SignerType
+	SignPayloadContext(ctx context.Context, p *extrinsic.Payload) ([]byte, error)
+}
+
+// 远程签名服务的账户信息，GET {endpoint}/account 返回
+// Account of remote signing service, returned by GET {endpoint}/account
+type RemoteAccount struct {
+	// 十六进制公钥
+	// hex public key
+	PublicKey string `json:"public_key"`
+	KeyType   uint8  `json:"key_type"`
+}
+
+// 远程签名请求，POST {endpoint}/sign
+// Request of remote signing, POST {endpoint}/sign
+type RemoteSignRequest struct {
+	// 十六进制公钥
+	// hex public key
+	PublicKey string `json:"public_key"`
+	KeyType   uint8  `json:"key_type"`
+	// 十六进制的待签名数据，交易签名时为 SCALE 编码的 extrinsic.Payload
+	// hex data to sign, it is SCALE encoded extrinsic.Payload when extrinsics are signed
+	Payload string `json:"payload"`
+	// 交易内容摘要，签名非交易数据时为 nil
+	// summary of extrinsic, nil when the data is not an extrinsic payload
+	Summary *PayloadSummary `json:"summary,omitempty"`
+	// 由设置了 Meta 的 RemoteSignerServer 设置，负载无法解码为交易负载，将按任意数据签名
+	// set by RemoteSignerServer with Meta, the payload does not decode as an extrinsic payload and is signed as opaque bytes
+	Opaque bool `json:"-"`
+}
+
+// 远程签名响应
+// Response of remote signing
+type RemoteSignResponse struct {
+	// 十六进制签名
+	// hex signature
+	Signature string `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// 可读的交易负载摘要，供签名服务审核
+// Human-readable summary of extrinsic payload, it is reviewed by signing services
+type PayloadSummary struct {
+	// 调用名称，如 Revive.call
+	// name of call, such as Revive.call
+	Call string `json:"call"`
+	// 解码的调用参数
+	// decoded arguments of call
+	Args map[string]any `json:"args,omitempty"`
+
+	Nonce              uint64 `json:"nonce"`
+	Tip                string `json:"tip"`
+	Immortal           bool   `json:"immortal"`
+	SpecVersion        uint32 `json:"spec_version"`
+	TransactionVersion uint32 `json:"transaction_version"`
+	GenesisHash        string `json:"genesis_hash"`
+
+	// 有限期交易的有效区块数，永久有效时为 0
+	// number of blocks mortal extrinsic is valid for, 0 when immortal
+	Period uint64 `json:"period,omitempty"`
+	// 有限期交易的过期区块高度，只有已知 era 起始区块高度时才设置，如 UnsignedTx.Summary
+	// block number mortal extrinsic expires at, it is only set when the checkpoint block number is known, such as by UnsignedTx.Summary
+	ExpiresAt uint64 `json:"expires_at,omitempty"`
+}
+
+// 通过 HTTP 签名服务签名的签名者，私钥不在本进程中
+// Signer backed by a HTTP signing service, the secret key is not kept in process
+type RemoteSigner struct {
+	// 签名服务地址，如 http://127.0.0.1:8080
+	// endpoint of signing service, such as http://127.0.0.1:8080
+	Endpoint string
+	// 请求头，如认证 token
+	// headers of requests, such as authorization tokens
+	Header http.Header
+	// 为 nil 时使用 http.DefaultClient
+	// http.DefaultClient is used when nil
+	Client *http.Client
+	// 请求超时时间，为 0 时使用 DefaultRemoteSignTimeout
+	// timeout of requests, DefaultRemoteSignTimeout when 0
+	Timeout time.Duration
+	// 用于解码交易摘要的元数据，为 nil 时摘要只包含调用索引
+	// metadata to decode the summary of extrinsics, the summary only contains call index when nil
+	Meta *types.Metadata
+
+	publicKey []byte
+	keyType   uint8
+
+	calls callRegistryCache
+}
+
+// 连接签名服务，并通过 GET {endpoint}/account 读取签名账户
+// Connect to signing service and read the signing account by GET {endpoint}/account
+func NewRemoteSigner(endpoint string, meta *types.Metadata) (*RemoteSigner, error) {
+	return NewRemoteSignerContext(context.Background(), endpoint, meta)
+}
+
+// 连接签名服务
+// Connect to signing service with context
+func NewRemoteSignerContext(ctx context.Context, endpoint string, meta *types.Metadata) (*RemoteSigner, error) {
+	r := &RemoteSigner{
+		Endpoint: strings.TrimSuffix(endpoint, "/"),
+		Meta:     meta,
+	}
+
+	var account RemoteAccount
+	if err := r.do(ctx, http.MethodGet, "/account", nil, &account); err != nil {
+		return nil, errors.New("remote account: " + err.Error())
+	}
+	public, err := hex.DecodeString(strings.TrimPrefix(account.PublicKey, "0x"))
+	if err != nil {
+		return nil, errors.New("remote account: " + err.Error())
+	}
+	if _, err := publicKeyOf(account.KeyType, public); err != nil {
+		return nil, errors.New("remote account: " + err.Error())
+	}
+
+	r.publicKey = public
+	r.keyType = account.KeyType
+	return r, nil
+}
+
+func (r *RemoteSigner) Public() []byte {
+	return r.publicKey
+}
+
+// 账户 id，ecdsa 账户为压缩公钥的 blake2_256 哈希
+// Account id, it is blake2_256 hash of compressed public key for ecdsa
+func (r *RemoteSigner) AccountID() types.AccountID {
+	return accountIDOf(r.keyType, r.publicKey)
+}
+
+func (r *RemoteSigner) SignType() uint8 {
+	return r.keyType
+}
+
+// 远程签名任意数据，签名服务无法得到交易摘要
+// Sign any data remotely, the signing service does not get the summary of extrinsic
+func (r *RemoteSigner) Sign(msg []byte) ([]byte, error) {
+	return r.SignContext(context.Background(), msg)
+}
+
+// 远程签名任意数据
+// Sign any data remotely with context
+func (r *RemoteSigner) SignContext(ctx context.Context, msg []byte) ([]byte, error) {
+	return r.sign(ctx, msg, nil)
+}
+
+// 远程签名交易负载，请求中附带解码的交易摘要
+// Sign extrinsic payload remotely, the decoded summary of extrinsic is sent with the request
+func (r *RemoteSigner) SignPayload(p *extrinsic.Payload) ([]byte, error) {
+	return r.SignPayloadContext(context.Background(), p)
+}
+
+// 远程签名交易负载
+// Sign extrinsic payload remotely with context
+func (r *RemoteSigner) SignPayloadContext(ctx context.Context, p *extrinsic.Payload) ([]byte, error) {
+	msg, err := codec.Encode(p)
+	if err != nil {
+		return nil, extrinsic.ErrPayloadEncoding.Wrap(err)
+	}
+
+	summary, err := r.summary(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.sign(ctx, msg, summary)
+}
+
+// 使用公钥在本地验证签名
+// Verify signature locally with public key
+func (r *RemoteSigner) Verify(msg []byte, signature []byte) bool {
+	return verifySignature(r.keyType, r.publicKey, msg, signature)
+}
+
+func (r *RemoteSigner) sign(ctx context.Context, msg []byte, summary *PayloadSummary) ([]byte, error) {
+	req := RemoteSignRequest{
+		PublicKey: hex.EncodeToString(r.publicKey),
+		KeyType:   r.keyType,
+		Payload:   hex.EncodeToString(msg),
+		Summary:   summary,
+	}
+
+	var resp RemoteSignResponse
+	if err := r.do(ctx, http.MethodPost, "/sign", req, &resp); err != nil {
+		return nil, fmt.Errorf("remote sign: %w", err)
+	}
+	sig, err := hex.DecodeString(strings.TrimPrefix(resp.Signature, "0x"))
+	if err != nil {
+		return nil, errors.New("remote sign: " + err.Error())
+	}
+
+	// 签名服务返回的签名不可信，提交前在本地验证
+	// signature returned by signing service is verified locally before it is submitted
+	if !r.Verify(msg, sig) {
+		return nil, errors.New("remote sign: invalid signature")
+	}
+
+	return sig, nil
+}
+
+func (r *RemoteSigner) summary(p *extrinsic.Payload) (*PayloadSummary, error) {
+	var calls registry.CallRegistry
+	if r.Meta != nil {
+		var err error
+		calls, err = r.calls.get(r.Meta)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewPayloadSummary(calls, p)
+}
+
+// 生成交易负载摘要，calls 为 nil 时摘要只包含调用索引
+// Summary of extrinsic payload, the summary only contains call index when calls is nil
+func NewPayloadSummary(calls registry.CallRegistry, p *extrinsic.Payload) (*PayloadSummary, error) {
+	summary := &PayloadSummary{}
+	if calls != nil {
+		var err error
+		summary.Call, summary.Args, err = DecodeCallSummary(calls, p.EncodedCall)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(p.EncodedCall) >= 2 {
+		summary.Call = fmt.Sprintf("%d.%d", p.EncodedCall[0], p.EncodedCall[1])
+	}
+
+	for _, field := range slices.Concat(p.SignedFields, p.SignedExtraFields) {
+		switch v := field.Value.(type) {
+		case types.UCompact:
+			n := big.Int(v)
+			if field.Name == extrinsic.NonceSignedField {
+				summary.Nonce = n.Uint64()
+			} else if field.Name == extrinsic.TipSignedField {
+				summary.Tip = n.String()
+			}
+		case types.ExtrinsicEra:
+			summary.Immortal = v.IsImmortalEra
+			if v.IsMortalEra {
+				encoded := uint16(v.AsMortalEra.First) | uint16(v.AsMortalEra.Second)<<8
+				summary.Period = 2 << (encoded % 16)
+			}
+		case types.U32:
+			if field.Name == extrinsic.SpecVersionSignedField {
+				summary.SpecVersion = uint32(v)
+			} else if field.Name == extrinsic.TransactionVersionSignedField {
+				summary.TransactionVersion = uint32(v)
+			}
+		case types.Hash:
+			if field.Name == extrinsic.GenesisHashSignedField {
+				summary.GenesisHash = v.Hex()
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+// 按元数据缓存的调用解码器
+// Call decoders cached by metadata
+type callRegistryCache struct {
+	mu    sync.Mutex
+	calls registry.CallRegistry
+	meta  *types.Metadata
+}
+
+// 元数据变化（如运行时升级）后重新生成调用解码器
+// Call decoders are created again when metadata changes, such as runtime upgrades
+func (c *callRegistryCache) get(meta *types.Metadata) (registry.CallRegistry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.calls == nil || c.meta != meta {
+		calls, err := registry.NewFactory().CreateCallRegistry(meta)
+		if err != nil {
+			return nil, errors.New("CreateCallRegistry: " + err.Error())
+		}
+		c.calls = calls
+		c.meta = meta
+	}
+
+	return c.calls, nil
+}
+
+func (r *RemoteSigner) do(ctx context.Context, method, path string, body any, v any) error {
+	timeout := r.Timeout
+	if timeout == 0 {
+		timeout = DefaultRemoteSignTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, r.Endpoint+path, reader)
+	if err != nil {
+		return err
+	}
+	for k, vs := range r.Header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp RemoteSignResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil && errResp.Error != "" {
+			return fmt.Errorf("%s: %s", resp.Status, errResp.Error)
+		}
+		return errors.New(resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// 解码调用，返回调用名称和参数，data 可以是调用或以调用开头的交易负载
+// Decode call and return name and arguments of call, data is the call or the extrinsic payload starting with the call
+func DecodeCallSummary(calls registry.CallRegistry, data []byte) (string, map[string]any, error) {
+	if len(data) < 2 {
+		return "", nil, errors.New("call index is missing")
+	}
+
+	callDecoder, ok := calls[types.CallIndex{SectionIndex: data[0], MethodIndex: data[1]}]
+	if !ok {
+		return "", nil, fmt.Errorf("call %d.%d not found", data[0], data[1])
+	}
+
+	fields, err := callDecoder.Decode(scale.NewDecoder(bytes.NewReader(data[2:])))
+	if err != nil {
+		return "", nil, errors.New("decode call " + callDecoder.Name + ": " + err.Error())
+	}
+
+	args := make(map[string]any, len(fields))
+	for _, field := range fields {
+		args[field.Name] = field.Value
+	}
+
+	return callDecoder.Name, args, nil
+}
+
+// 从 SCALE 编码的交易负载中解码调用和签名字段，签名字段的顺序和类型来自元数据的 signed extensions
+// Decode call and signed fields from SCALE encoded extrinsic payload,
+// order and types of signed fields come from signed extensions of metadata
+func decodePayload(meta *types.Metadata, calls registry.CallRegistry, data []byte) (*extrinsic.Payload, error) {
+	if len(data) < 2 {
+		return nil, errors.New("call index is missing")
+	}
+	callDecoder, ok := calls[types.CallIndex{SectionIndex: data[0], MethodIndex: data[1]}]
+	if !ok {
+		return nil, fmt.Errorf("call %d.%d not found", data[0], data[1])
+	}
+
+	reader := bytes.NewReader(data[2:])
+	decoder := scale.NewDecoder(reader)
+	if _, err := callDecoder.Decode(decoder); err != nil {
+		return nil, errors.New("decode call " + callDecoder.Name + ": " + err.Error())
+	}
+
+	p, err := createPayload(meta, data[:len(data)-reader.Len()])
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range slices.Concat(p.SignedFields, p.SignedExtraFields) {
+		field.Value, err = decodeSignedField(decoder, field.Name)
+		if err != nil {
+			return nil, fmt.Errorf("decode signed field %s: %w", field.Name, err)
+		}
+		field.Mutated = true
+	}
+	if reader.Len() != 0 {
+		return nil, fmt.Errorf("%d trailing bytes of payload", reader.Len())
+	}
+
+	return p, nil
+}
+
+// 按签名字段名称解码字段值，值的类型与签名选项中的相同
+// Decode value of signed field by its name, the value has the same type as in signing options
+func decodeSignedField(decoder *scale.Decoder, name extrinsic.SignedFieldName) (any, error) {
+	switch name {
+	case extrinsic.EraSignedField:
+		var era types.ExtrinsicEra
+		err := decoder.Decode(&era)
+		return era, err
+	case extrinsic.NonceSignedField, extrinsic.TipSignedField:
+		var n types.UCompact
+		err := decoder.Decode(&n)
+		return n, err
+	case extrinsic.AssetIDSignedField:
+		var assetID types.Option[types.AssetID]
+		err := decoder.Decode(&assetID)
+		return assetID, err
+	case extrinsic.CheckMetadataHashModeSignedField:
+		mode, err := decoder.ReadOneByte()
+		return extensions.CheckMetadataMode(mode), err
+	case extrinsic.CheckMetadataHashSignedField:
+		var hash types.Option[types.H256]
+		err := decoder.Decode(&hash)
+		return extensions.CheckMetadataHash{Hash: hash}, err
+	case extrinsic.SpecVersionSignedField, extrinsic.TransactionVersionSignedField:
+		var v types.U32
+		err := decoder.Decode(&v)
+		return v, err
+	case extrinsic.BlockHashSignedField, extrinsic.GenesisHashSignedField:
+		var hash types.Hash
+		err := decoder.Decode(&hash)
+		return hash, err
+	default:
+		return nil, errors.New("unknown signed field")
+	}
+}
+
+// 远程签名服务的参考实现，使用本地签名者签名，可用于测试或作为签名服务的基础
+// Reference implementation of remote signing service, it signs with a local signer and can be used in tests or as base of signing services
+type RemoteSignerServer struct {
+	Signer SignerType
+	// 用于从负载中解码调用和签名字段的元数据，设置后每个负载的摘要都由服务端解码，不信任客户端摘要，
+	// 无法解码为交易负载的数据以 Opaque 标记后交给 Approve
+	// metadata to decode call and signed fields from payload, summary of every payload is decoded by server instead of trusting client summary,
+	// data that does not decode as an extrinsic payload is passed to Approve flagged as Opaque
+	Meta *types.Metadata
+	// 签名前审核请求，返回错误时拒绝签名
+	// review the request before signing, signing is rejected when it returns an error
+	Approve func(req *RemoteSignRequest) error
+
+	calls callRegistryCache
+}
+
+func (s *RemoteSignerServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/account"):
+		writeRemoteJSON(w, http.StatusOK, RemoteAccount{
+			PublicKey: hex.EncodeToString(s.Signer.Public()),
+			KeyType:   s.Signer.SignType(),
+		})
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/sign"):
+		sig, err := s.sign(r)
+		if err != nil {
+			writeRemoteJSON(w, http.StatusBadRequest, RemoteSignResponse{Error: err.Error()})
+			return
+		}
+		writeRemoteJSON(w, http.StatusOK, RemoteSignResponse{Signature: hex.EncodeToString(sig)})
+	default:
+		writeRemoteJSON(w, http.StatusNotFound, RemoteSignResponse{Error: "not found"})
+	}
+}
+
+func (s *RemoteSignerServer) sign(r *http.Request) ([]byte, error) {
+	var req RemoteSignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.New("decode request: " + err.Error())
+	}
+	if req.PublicKey != hex.EncodeToString(s.Signer.Public()) || req.KeyType != s.Signer.SignType() {
+		return nil, errors.New("unknown account")
+	}
+
+	payload, err := hex.DecodeString(strings.TrimPrefix(req.Payload, "0x"))
+	if err != nil {
+		return nil, errors.New("decode payload: " + err.Error())
+	}
+
+	// 摘要由服务端从负载解码，不论客户端是否发送摘要，客户端摘要的签名字段与负载不一致时拒绝签名
+	// summary is decoded from payload by server whether the client sends one or not,
+	// signing is rejected when signed fields of client summary do not match the payload
+	req.Opaque = false
+	if s.Meta != nil {
+		calls, err := s.calls.get(s.Meta)
+		if err != nil {
+			return nil, err
+		}
+		summary, err := decodePayloadSummary(s.Meta, calls, payload)
+		switch {
+		case err == nil:
+			if req.Summary != nil {
+				if err := checkSummary(req.Summary, summary); err != nil {
+					return nil, err
+				}
+			}
+			req.Summary = summary
+		case req.Summary != nil:
+			return nil, errors.New("decode payload: " + err.Error())
+		default:
+			req.Opaque = true
+		}
+	}
+
+	if s.Approve != nil {
+		if err := s.Approve(&req); err != nil {
+			return nil, errors.New("rejected: " + err.Error())
+		}
+	}
+
+	return s.Signer.Sign(payload)
+}
+
+// 从交易负载解码摘要
+// Decode summary from extrinsic payload
+func decodePayloadSummary(meta *types.Metadata, calls registry.CallRegistry, payload []byte) (*PayloadSummary, error) {
+	p, err := decodePayload(meta, calls, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewPayloadSummary(calls, p)
+}
+
+// 检查客户端摘要的签名字段与负载中的一致
+// Check signed fields of client summary are the same as the ones in payload
+func checkSummary(client, payload *PayloadSummary) error {
+	switch {
+	case client.Nonce != payload.Nonce:
+		return fmt.Errorf("nonce %d of summary does not match %d of payload", client.Nonce, payload.Nonce)
+	case client.Immortal != payload.Immortal || client.Period != payload.Period:
+		return fmt.Errorf("era of summary does not match payload, immortal %v period %d", payload.Immortal, payload.Period)
+	case client.GenesisHash != payload.GenesisHash:
+		return fmt.Errorf("genesis hash %s of summary does not match %s of payload", client.GenesisHash, payload.GenesisHash)
+	case client.Tip != payload.Tip:
+		return fmt.Errorf("tip %s of summary does not match %s of payload", client.Tip, payload.Tip)
+	case client.SpecVersion != payload.SpecVersion || client.TransactionVersion != payload.TransactionVersion:
+		return fmt.Errorf("versions %d/%d of summary do not match %d/%d of payload",
+			client.SpecVersion, client.TransactionVersion, payload.SpecVersion, payload.TransactionVersion)
+	}
+	return nil
+}
+
+func writeRemoteJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
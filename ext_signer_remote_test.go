@@ -0,0 +1,155 @@
+package ink
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic/extensions"
+	gtypes "github.com/wetee-dao/ink.go/pallet/types"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	alice, err := Sr25519PairFromSecret("//Alice", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var approved, reviewed *RemoteSignRequest
+	server := httptest.NewServer(&RemoteSignerServer{
+		Signer: &alice,
+		Meta:   &gtypes.Meta,
+		Approve: func(req *RemoteSignRequest) error {
+			reviewed = req
+			if req.Summary == nil || req.Summary.Call != "System.remark" {
+				return errors.New("only remarks are signed")
+			}
+			approved = req
+			return nil
+		},
+	})
+	defer server.Close()
+
+	signer, err := NewRemoteSigner(server.URL, &gtypes.Meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer.AccountID() != alice.AccountID() || signer.SignType() != KeyTypeSr25519 {
+		t.Fatalf("remote account %x", signer.AccountID())
+	}
+
+	opts := []extrinsic.SigningOption{
+		extrinsic.WithEra(types.ExtrinsicEra{IsImmortalEra: true}, types.Hash{}),
+		extrinsic.WithNonce(types.NewUCompactFromUInt(7)),
+		extrinsic.WithTip(types.NewUCompactFromUInt(0)),
+		extrinsic.WithSpecVersion(1),
+		extrinsic.WithTransactionVersion(1),
+		extrinsic.WithGenesisHash(types.Hash{1}),
+		extrinsic.WithMetadataMode(extensions.CheckMetadataModeDisabled, extensions.CheckMetadataHash{Hash: types.NewEmptyOption[types.H256]()}),
+		extrinsic.WithAssetID(types.NewEmptyOption[types.AssetID]()),
+	}
+
+	call, err := types.NewCall(&gtypes.Meta, "System.remark", []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ext := NewExtrinsic(call)
+	if err := ext.Sign(signer, &gtypes.Meta, opts...); err != nil {
+		t.Fatal(err)
+	}
+	if approved == nil || approved.Summary.Nonce != 7 || approved.Summary.GenesisHash != (types.Hash{1}).Hex() || approved.Summary.Args["remark"] == nil {
+		t.Fatalf("summary %+v", approved)
+	}
+
+	// 签名可以由本地账户验证
+	// signature can be verified by local account
+	encodedCall, _ := codec.Encode(call)
+	payload, err := createPayload(&gtypes.Meta, encodedCall)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := payload.MutateSignedFields(signedFields(opts)); err != nil {
+		t.Fatal(err)
+	}
+	msg, _ := codec.Encode(payload)
+	if !ext.Signature.Signature.IsSr25519 || !alice.Verify(msg, ext.Signature.Signature.AsSr25519[:]) {
+		t.Error("invalid remote signature")
+	}
+
+	// 服务端从负载解码签名字段，与负载不一致的摘要被拒绝
+	// server decodes signed fields from payload, summary not matching the payload is rejected
+	summary, err := NewPayloadSummary(nil, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary.Nonce = 8
+	if _, err := signer.sign(context.Background(), msg, summary); err == nil {
+		t.Error("summary with forged nonce is signed")
+	}
+	summary.Nonce = 7
+	summary.Immortal = false
+	if _, err := signer.sign(context.Background(), msg, summary); err == nil {
+		t.Error("summary with forged era is signed")
+	}
+
+	// 客户端不发送摘要时服务端仍解码负载并将摘要交给 Approve
+	// server still decodes the payload and passes the summary to Approve when client sends no summary
+	approved = nil
+	if _, err := signer.sign(context.Background(), msg, nil); err != nil {
+		t.Fatal(err)
+	}
+	if approved == nil || approved.Opaque || approved.Summary.Call != "System.remark" || approved.Summary.Nonce != 7 {
+		t.Fatalf("summary without client summary %+v", approved)
+	}
+	heapPagesCall, err := types.NewCall(&gtypes.Meta, "System.set_heap_pages", types.NewU64(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encodedCall, _ = codec.Encode(heapPagesCall)
+	heapPayload, err := createPayload(&gtypes.Meta, encodedCall)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := heapPayload.MutateSignedFields(signedFields(opts)); err != nil {
+		t.Fatal(err)
+	}
+	heapMsg, _ := codec.Encode(heapPayload)
+	if _, err := signer.sign(context.Background(), heapMsg, nil); err == nil {
+		t.Error("rejected call without summary is signed")
+	}
+	if reviewed == nil || reviewed.Summary == nil || reviewed.Summary.Call != "System.set_heap_pages" {
+		t.Errorf("reviewed %+v", reviewed)
+	}
+
+	// 无法解码为交易负载的数据标记为 Opaque
+	// data that does not decode as an extrinsic payload is flagged as Opaque
+	if _, err := signer.sign(context.Background(), []byte("hello"), nil); err == nil {
+		t.Error("opaque data is signed")
+	}
+	if reviewed == nil || !reviewed.Opaque || reviewed.Summary != nil {
+		t.Errorf("opaque request %+v", reviewed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := signer.SignPayloadContext(ctx, payload); !errors.Is(err, context.Canceled) {
+		t.Errorf("sign with canceled context: %v", err)
+	}
+
+	ext = NewExtrinsic(heapPagesCall)
+	if err := ext.Sign(signer, &gtypes.Meta, opts...); err == nil {
+		t.Error("rejected call is signed")
+	}
+}
+
+func signedFields(opts []extrinsic.SigningOption) extrinsic.SignedFieldValues {
+	vals := extrinsic.SignedFieldValues{}
+	for _, opt := range opts {
+		opt(vals)
+	}
+	return vals
+}
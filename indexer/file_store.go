@@ -0,0 +1,328 @@
+package indexer
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	eventsFile     = "events.jsonl"
+	checkpointFile = "checkpoint.json"
+)
+
+// 文件存储，事件追加写入 JSON lines 文件，查询时使用内存索引
+// 检查点之后的事件（如写入事件后进程退出）在打开时被丢弃
+// Store in files, events are appended to a JSON lines file and queried in memory,
+// events after the checkpoint (such as the process exits after writing events) are dropped when opening
+type FileStore struct {
+	*MemoryStore
+	// 每隔多少个区块同步事件文件并写入检查点，为 0 或 1 时每个区块都同步。
+	// 进程异常退出后从最后写入的检查点重新索引，Close 时写入最新的检查点
+	// number of blocks between syncs of events file and checkpoint writes, every block is synced when it is 0 or 1.
+	// Blocks after the last written checkpoint are indexed again after the process crashes, Close writes the latest checkpoint
+	SyncEvery uint64
+
+	dir      string
+	file     *os.File
+	unsynced uint64
+}
+
+// 打开文件存储，目录不存在时创建
+// Open store in dir, dir is created if it does not exist
+func OpenFileStore(dir string) (*FileStore, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, errors.New("indexer: create store dir: " + err.Error())
+	}
+
+	s := &FileStore{MemoryStore: NewMemoryStore(), dir: dir}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	s.file, err = os.OpenFile(filepath.Join(dir, eventsFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, errors.New("indexer: open events file: " + err.Error())
+	}
+
+	return s, nil
+}
+
+func (s *FileStore) load() error {
+	bt, err := os.ReadFile(filepath.Join(s.dir, checkpointFile))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.New("indexer: read checkpoint: " + err.Error())
+	}
+	if err == nil {
+		var cp blockRecord
+		if err := json.Unmarshal(bt, &cp); err != nil {
+			return errors.New("indexer: decode checkpoint: " + err.Error())
+		}
+		s.checkpoint, err = cp.block()
+		if err != nil {
+			return err
+		}
+		s.hasCP = true
+	}
+
+	f, err := os.Open(filepath.Join(s.dir, eventsFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.New("indexer: open events file: " + err.Error())
+	}
+	defer f.Close()
+
+	dirty := false
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var r eventRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return errors.New("indexer: decode event: " + err.Error())
+		}
+		if !s.hasCP || r.BlockNumber > s.checkpoint.Number {
+			dirty = true
+			continue
+		}
+		e, err := r.event()
+		if err != nil {
+			return err
+		}
+		s.events = append(s.events, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.New("indexer: read events file: " + err.Error())
+	}
+
+	if dirty {
+		return s.rewrite()
+	}
+	return nil
+}
+
+func (s *FileStore) SaveBlock(ctx context.Context, block Block, events []Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(events) > 0 {
+		var sb strings.Builder
+		for i := range events {
+			bt, err := json.Marshal(newEventRecord(&events[i]))
+			if err != nil {
+				return err
+			}
+			sb.Write(bt)
+			sb.WriteByte('\n')
+		}
+		if _, err := s.file.WriteString(sb.String()); err != nil {
+			return errors.New("indexer: write events: " + err.Error())
+		}
+	}
+
+	s.events = append(s.events, events...)
+	s.checkpoint = block
+	s.hasCP = true
+
+	s.unsynced++
+	if s.unsynced < max(s.SyncEvery, 1) {
+		return nil
+	}
+	return s.sync()
+}
+
+// 同步事件文件后写入检查点，检查点之前的事件都已落盘
+// Sync events file and then write checkpoint, so events before the checkpoint are all on disk
+func (s *FileStore) sync() error {
+	if err := s.file.Sync(); err != nil {
+		return errors.New("indexer: sync events: " + err.Error())
+	}
+	if err := s.writeCheckpoint(s.checkpoint); err != nil {
+		return err
+	}
+	s.unsynced = 0
+	return nil
+}
+
+func (s *FileStore) Rollback(ctx context.Context, block Block) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.truncate(block.Number)
+	if err := s.writeCheckpoint(block); err != nil {
+		return err
+	}
+	s.checkpoint = block
+	s.hasCP = true
+	s.unsynced = 0
+
+	return s.rewrite()
+}
+
+// 关闭存储，写入未同步的检查点
+// Close store, the checkpoint not synced yet is written
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+	if s.unsynced > 0 {
+		err = s.sync()
+	}
+	if cerr := s.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// 检查点先写入临时文件再重命名，保证原子性
+// Checkpoint is written to a temporary file and renamed, so that it is replaced atomically
+func (s *FileStore) writeCheckpoint(block Block) error {
+	bt, err := json.Marshal(blockRecord{Number: block.Number, Hash: block.Hash.Hex()})
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(s.dir, checkpointFile), bt)
+}
+
+// 按内存中的事件重写事件文件
+// Rewrite events file with events in memory
+func (s *FileStore) rewrite() error {
+	var sb strings.Builder
+	for i := range s.events {
+		bt, err := json.Marshal(newEventRecord(&s.events[i]))
+		if err != nil {
+			return err
+		}
+		sb.Write(bt)
+		sb.WriteByte('\n')
+	}
+
+	path := filepath.Join(s.dir, eventsFile)
+	if err := writeFileAtomic(path, []byte(sb.String())); err != nil {
+		return err
+	}
+
+	// reopen the appending file after it is replaced
+	if s.file != nil {
+		s.file.Close()
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return errors.New("indexer: open events file: " + err.Error())
+		}
+		s.file = file
+	}
+	return nil
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return errors.New("indexer: write " + path + ": " + err.Error())
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return errors.New("indexer: write " + path + ": " + err.Error())
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return errors.New("indexer: sync " + path + ": " + err.Error())
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.New("indexer: rename " + path + ": " + err.Error())
+	}
+	return nil
+}
+
+type blockRecord struct {
+	Number uint64 `json:"number"`
+	Hash   string `json:"hash"`
+}
+
+func (r blockRecord) block() (Block, error) {
+	hash, err := types.NewHashFromHexString(r.Hash)
+	if err != nil {
+		return Block{}, errors.New("indexer: decode block hash: " + err.Error())
+	}
+	return Block{Number: r.Number, Hash: hash}, nil
+}
+
+// JSON 格式的事件，字节数组以 hex 存储
+// Event in JSON, byte arrays are stored as hex
+type eventRecord struct {
+	BlockNumber    uint64          `json:"block_number"`
+	BlockHash      string          `json:"block_hash"`
+	EventIndex     uint32          `json:"event_index"`
+	ExtrinsicIndex int64           `json:"extrinsic_index"`
+	Contract       string          `json:"contract"`
+	Topics         []string        `json:"topics"`
+	Data           string          `json:"data"`
+	Name           string          `json:"name,omitempty"`
+	Decoded        json.RawMessage `json:"decoded,omitempty"`
+}
+
+func newEventRecord(e *Event) eventRecord {
+	topics := make([]string, 0, len(e.Topics))
+	for _, t := range e.Topics {
+		topics = append(topics, t.Hex())
+	}
+	return eventRecord{
+		BlockNumber:    e.BlockNumber,
+		BlockHash:      e.BlockHash.Hex(),
+		EventIndex:     e.EventIndex,
+		ExtrinsicIndex: e.ExtrinsicIndex,
+		Contract:       e.Contract.Hex(),
+		Topics:         topics,
+		Data:           "0x" + hex.EncodeToString(e.Data),
+		Name:           e.Name,
+		Decoded:        e.Decoded,
+	}
+}
+
+func (r *eventRecord) event() (Event, error) {
+	blockHash, err := types.NewHashFromHexString(r.BlockHash)
+	if err != nil {
+		return Event{}, errors.New("indexer: decode block hash: " + err.Error())
+	}
+	contract, err := hex.DecodeString(strings.TrimPrefix(r.Contract, "0x"))
+	if err != nil || len(contract) != 20 {
+		return Event{}, errors.New("indexer: decode contract address " + r.Contract)
+	}
+	topics := make([]types.Hash, 0, len(r.Topics))
+	for _, t := range r.Topics {
+		topic, err := types.NewHashFromHexString(t)
+		if err != nil {
+			return Event{}, errors.New("indexer: decode topic: " + err.Error())
+		}
+		topics = append(topics, topic)
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(r.Data, "0x"))
+	if err != nil {
+		return Event{}, errors.New("indexer: decode data: " + err.Error())
+	}
+
+	return Event{
+		BlockNumber:    r.BlockNumber,
+		BlockHash:      blockHash,
+		EventIndex:     r.EventIndex,
+		ExtrinsicIndex: r.ExtrinsicIndex,
+		Contract:       types.NewH160(contract),
+		Topics:         topics,
+		Data:           data,
+		Name:           r.Name,
+		Decoded:        r.Decoded,
+	}, nil
+}
@@ -0,0 +1,330 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	chain "github.com/wetee-dao/ink.go"
+	"github.com/wetee-dao/ink.go/util"
+)
+
+// 检查点与链不一致时默认回退的区块数
+// Default number of blocks rolled back when the checkpoint does not match the chain
+var DefaultReorgDepth uint64 = 256
+
+// 出错后重新开始的间隔
+// Interval of restarting after errors
+var RetryInterval = 3 * time.Second
+
+var errReorg = errors.New("indexer: parent hash does not match checkpoint")
+
+// 需要索引的合约
+// Contract to index
+type Contract struct {
+	Address types.H160
+	// 用于解码事件，为 nil 时不解码
+	// ABI to decode events, events are not decoded when it is nil
+	Abi *util.InkAbi
+	// 事件签名 topic，为空时索引所有事件
+	// signature topics of events, all events are indexed when it is empty
+	Topics []types.Hash
+}
+
+// 索引参数
+// Options of indexer
+type Options struct {
+	Contracts []Contract
+	// 没有检查点时开始的区块
+	// block to start from when there is no checkpoint
+	StartBlock uint64
+	// 检查点与链不一致时回退的区块数，为 0 时使用 DefaultReorgDepth
+	// number of blocks rolled back when the checkpoint does not match the chain, DefaultReorgDepth is used when it is 0
+	ReorgDepth uint64
+	// 区块保存后的回调
+	// hook called after a block is saved
+	OnBlock func(block Block, events []Event)
+}
+
+// 区块数据来源，由 ChainClient 实现
+// Source of blocks, implemented by ChainClient
+type source interface {
+	GetBlockHashContext(ctx context.Context, number uint64) (types.Hash, error)
+	GetBlockEventsContext(ctx context.Context, number uint64) (*chain.BlockEvents, error)
+	GetFinalizedHeaderContext(ctx context.Context) (*types.Header, error)
+	SubscribeFinalizedEventsContext(ctx context.Context) (*chain.EventSubscription[chain.BlockEvents], error)
+}
+
+// 合约事件索引器，先补齐历史区块，再跟随最终确认区块
+// Indexer of contract events, it backfills history blocks and then follows finalized blocks
+type Indexer struct {
+	src       source
+	store     Store
+	opts      Options
+	contracts map[types.H160]*Contract
+	debug     bool
+}
+
+func New(client *chain.ChainClient, store Store, opts Options) *Indexer {
+	ix := newIndexer(client, store, opts)
+	ix.debug = client.Debug
+	return ix
+}
+
+func newIndexer(src source, store Store, opts Options) *Indexer {
+	if opts.ReorgDepth == 0 {
+		opts.ReorgDepth = DefaultReorgDepth
+	}
+
+	contracts := make(map[types.H160]*Contract, len(opts.Contracts))
+	for i := range opts.Contracts {
+		contracts[opts.Contracts[i].Address] = &opts.Contracts[i]
+	}
+
+	return &Indexer{
+		src:       src,
+		store:     store,
+		opts:      opts,
+		contracts: contracts,
+	}
+}
+
+// 运行索引直到 ctx 取消，出错后自动重新开始
+// Run indexer until ctx is done, it restarts from the checkpoint after errors
+func (ix *Indexer) Run(ctx context.Context) error {
+	for {
+		err := ix.run(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		util.LogWithRed("[ Indexer ]", err.Error(), "restart in", RetryInterval.String())
+
+		select {
+		case <-time.After(RetryInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (ix *Indexer) run(ctx context.Context) error {
+	if err := ix.verify(ctx); err != nil {
+		return err
+	}
+
+	header, err := ix.src.GetFinalizedHeaderContext(ctx)
+	if err != nil {
+		return err
+	}
+	if err := ix.Backfill(ctx, uint64(header.Number)); err != nil {
+		return err
+	}
+
+	sub, err := ix.src.SubscribeFinalizedEventsContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case block, ok := <-sub.Chan():
+			if !ok {
+				select {
+				case err := <-sub.Err():
+					return err
+				default:
+					return errors.New("indexer: finalized subscription closed")
+				}
+			}
+
+			cp, hasCP, err := ix.store.Checkpoint(ctx)
+			if err != nil {
+				return err
+			}
+			if hasCP && block.Number <= cp.Number {
+				continue
+			}
+			if next := ix.next(cp, hasCP); block.Number > next {
+				if err := ix.Backfill(ctx, block.Number-1); err != nil {
+					return err
+				}
+			}
+
+			if err := ix.process(ctx, &block); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// 处理检查点之后到 to 的区块
+// Process blocks after the checkpoint up to to
+func (ix *Indexer) Backfill(ctx context.Context, to uint64) error {
+	cp, hasCP, err := ix.store.Checkpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	for number := ix.next(cp, hasCP); number <= to; number++ {
+		block, err := ix.src.GetBlockEventsContext(ctx, number)
+		if err != nil {
+			return err
+		}
+		if err := ix.process(ctx, block); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ix *Indexer) next(cp Block, hasCP bool) uint64 {
+	if !hasCP {
+		return ix.opts.StartBlock
+	}
+	return cp.Number + 1
+}
+
+// 保存区块中的合约事件，父区块与检查点不一致时回退
+// Save contract events of block, the store is rolled back when its parent does not match the checkpoint
+func (ix *Indexer) process(ctx context.Context, block *chain.BlockEvents) error {
+	cp, hasCP, err := ix.store.Checkpoint(ctx)
+	if err != nil {
+		return err
+	}
+	if hasCP && block.Number == cp.Number+1 && block.Header.ParentHash != cp.Hash {
+		if err := ix.rollback(ctx, cp); err != nil {
+			return err
+		}
+		return errReorg
+	}
+
+	events := ix.extract(block)
+	b := Block{Number: block.Number, Hash: block.Hash}
+	if err := ix.store.SaveBlock(ctx, b, events); err != nil {
+		return err
+	}
+
+	if ix.debug && len(events) > 0 {
+		util.LogWithCyan("[ Indexer ]", "block", block.Number, "events", len(events))
+	}
+	if ix.opts.OnBlock != nil {
+		ix.opts.OnBlock(b, events)
+	}
+
+	return nil
+}
+
+// 检查点不在链上时回退
+// Roll back when the checkpoint is not on chain
+func (ix *Indexer) verify(ctx context.Context) error {
+	cp, hasCP, err := ix.store.Checkpoint(ctx)
+	if err != nil || !hasCP {
+		return err
+	}
+
+	hash, err := ix.src.GetBlockHashContext(ctx, cp.Number)
+	if err != nil {
+		return err
+	}
+	if hash == cp.Hash {
+		return nil
+	}
+
+	return ix.rollback(ctx, cp)
+}
+
+func (ix *Indexer) rollback(ctx context.Context, cp Block) error {
+	number := cp.Number - min(ix.opts.ReorgDepth, cp.Number)
+	hash, err := ix.src.GetBlockHashContext(ctx, number)
+	if err != nil {
+		return err
+	}
+
+	util.LogWithRed("[ Indexer ]", fmt.Sprintf("checkpoint %d %s is not on chain, roll back to %d", cp.Number, cp.Hash.Hex(), number))
+	return ix.store.Rollback(ctx, Block{Number: number, Hash: hash})
+}
+
+// 过滤并解码区块中的合约事件
+// Filter and decode contract events of block
+func (ix *Indexer) extract(block *chain.BlockEvents) []Event {
+	events := make([]Event, 0)
+	for i, r := range block.Events {
+		if !r.Event.IsRevive || r.Event.AsReviveField0 == nil || !r.Event.AsReviveField0.IsContractEmitted {
+			continue
+		}
+
+		emitted := r.Event.AsReviveField0
+		contract, ok := ix.contracts[emitted.AsContractEmittedContract0]
+		if !ok {
+			continue
+		}
+
+		topics := make([]types.Hash, 0, len(emitted.AsContractEmittedTopics2))
+		for _, t := range emitted.AsContractEmittedTopics2 {
+			topics = append(topics, types.Hash(t))
+		}
+		if !matchTopics(contract.Topics, topics) {
+			continue
+		}
+
+		e := Event{
+			BlockNumber:    block.Number,
+			BlockHash:      block.Hash,
+			EventIndex:     uint32(i),
+			ExtrinsicIndex: -1,
+			Contract:       contract.Address,
+			Topics:         topics,
+			Data:           emitted.AsContractEmittedData1,
+		}
+		if r.Phase.IsApplyExtrinsic {
+			e.ExtrinsicIndex = int64(r.Phase.AsApplyExtrinsicField0)
+		}
+		ix.decode(contract, &e)
+
+		events = append(events, e)
+	}
+
+	return events
+}
+
+// 通过 ABI 解码事件，解码失败时保留原始数据
+// Decode event with ABI, the raw data is kept when decoding fails
+func (ix *Indexer) decode(contract *Contract, e *Event) {
+	if contract.Abi == nil || len(e.Topics) == 0 {
+		return
+	}
+
+	spec, err := contract.Abi.Event(e.Topics[0].Hex())
+	if err != nil {
+		return
+	}
+	e.Name = spec.Label
+
+	decoded, err := contract.Abi.DecodeEventJSON(spec, e.Data)
+	if err != nil {
+		util.LogWithRed("[ Indexer ]", "decode event", spec.Label, err.Error())
+		return
+	}
+	e.Decoded = decoded
+}
+
+func matchTopics(filter []types.Hash, topics []types.Hash) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	if len(topics) == 0 {
+		return false
+	}
+	for _, t := range filter {
+		if t == topics[0] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,255 @@
+package indexer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	chain "github.com/wetee-dao/ink.go"
+	gtypes "github.com/wetee-dao/ink.go/pallet/types"
+	"github.com/wetee-dao/ink.go/util"
+)
+
+var (
+	testContract = types.H160{1}
+	otherAddress = types.H160{2}
+	paidTopic    = types.Hash{0x11}
+)
+
+type fakeSource struct {
+	blocks []*chain.BlockEvents
+}
+
+// build chain of n blocks, fork changes the hashes of blocks from fork
+func newFakeSource(n int, fork uint64, events map[uint64][]gtypes.EventRecord) *fakeSource {
+	src := &fakeSource{}
+	var parent types.Hash
+	for i := range n {
+		number := uint64(i)
+		hash := types.Hash{byte(i), 0xbb}
+		if fork != 0 && number >= fork {
+			hash[1] = 0xcc
+		}
+		src.blocks = append(src.blocks, &chain.BlockEvents{
+			Number: number,
+			Hash:   hash,
+			Header: types.Header{ParentHash: parent, Number: types.BlockNumber(number)},
+			Events: events[number],
+		})
+		parent = hash
+	}
+	return src
+}
+
+func (s *fakeSource) GetBlockHashContext(_ context.Context, number uint64) (types.Hash, error) {
+	return s.blocks[number].Hash, nil
+}
+
+func (s *fakeSource) GetBlockEventsContext(_ context.Context, number uint64) (*chain.BlockEvents, error) {
+	return s.blocks[number], nil
+}
+
+func (s *fakeSource) GetFinalizedHeaderContext(context.Context) (*types.Header, error) {
+	return &s.blocks[len(s.blocks)-1].Header, nil
+}
+
+func (s *fakeSource) SubscribeFinalizedEventsContext(context.Context) (*chain.EventSubscription[chain.BlockEvents], error) {
+	return nil, nil
+}
+
+func emitted(extrinsic uint32, address types.H160, topic types.Hash, data []byte) gtypes.EventRecord {
+	return gtypes.EventRecord{
+		Phase: gtypes.Phase{IsApplyExtrinsic: true, AsApplyExtrinsicField0: extrinsic},
+		Event: gtypes.RuntimeEvent{
+			IsRevive: true,
+			AsReviveField0: &gtypes.PalletRevivePalletEvent{
+				IsContractEmitted:          true,
+				AsContractEmittedContract0: address,
+				AsContractEmittedData1:     data,
+				AsContractEmittedTopics2:   [][32]byte{topic},
+			},
+		},
+	}
+}
+
+func testAbi(t *testing.T) *util.InkAbi {
+	raw, err := os.ReadFile("../example/contracts/pod.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	abi, err := util.InitAbi(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	abi.Spec.Events = append(abi.Spec.Events, util.SpecEvent{
+		Label:          "Paid",
+		SignatureTopic: paidTopic.Hex(),
+		Args:           []util.EventArg{{Label: "amount", Type: util.TypeWithDisplayName{Type: 3}}},
+	})
+	return abi
+}
+
+func testEvents() map[uint64][]gtypes.EventRecord {
+	return map[uint64][]gtypes.EventRecord{
+		3: {
+			emitted(0, testContract, paidTopic, []byte{5, 0, 0, 0, 0, 0, 0, 0}),
+			emitted(1, otherAddress, paidTopic, []byte{6, 0, 0, 0, 0, 0, 0, 0}),
+		},
+		7: {emitted(2, testContract, types.Hash{0x22}, []byte{1})},
+		8: {emitted(0, testContract, paidTopic, []byte{9, 0, 0, 0, 0, 0, 0, 0})},
+	}
+}
+
+func TestBackfill(t *testing.T) {
+	ctx := context.Background()
+	src := newFakeSource(10, 0, testEvents())
+	store, err := OpenFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	ix := newIndexer(src, store, Options{Contracts: []Contract{{Address: testContract, Abi: testAbi(t)}}})
+	if err := ix.Backfill(ctx, 9); err != nil {
+		t.Fatal(err)
+	}
+
+	events, _ := store.Events(ctx, Query{})
+	if len(events) != 3 {
+		t.Fatalf("expect 3 events, got %d", len(events))
+	}
+	if events[0].Name != "Paid" || string(events[0].Decoded) != `{"amount":5}` || events[0].EventIndex != 0 {
+		t.Errorf("event 0: %+v", events[0])
+	}
+	if events[1].Name != "" || events[1].ExtrinsicIndex != 2 {
+		t.Errorf("event 1: %+v", events[1])
+	}
+
+	paid, _ := store.Events(ctx, Query{Name: "Paid", FromBlock: 4})
+	if len(paid) != 1 || paid[0].BlockNumber != 8 {
+		t.Errorf("query Paid from 4: %+v", paid)
+	}
+
+	// reopen from files
+	store.Close()
+	reopened, err := OpenFileStore(store.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	cp, ok, _ := reopened.Checkpoint(ctx)
+	if !ok || cp.Number != 9 || cp.Hash != src.blocks[9].Hash {
+		t.Errorf("checkpoint %+v", cp)
+	}
+	events, _ = reopened.Events(ctx, Query{})
+	if len(events) != 3 || string(events[2].Decoded) != `{"amount":9}` || events[2].Contract != testContract {
+		t.Errorf("reopened events: %+v", events)
+	}
+}
+
+func TestTopicFilter(t *testing.T) {
+	ctx := context.Background()
+	src := newFakeSource(10, 0, testEvents())
+	store := NewMemoryStore()
+
+	ix := newIndexer(src, store, Options{Contracts: []Contract{{Address: testContract, Topics: []types.Hash{paidTopic}}}})
+	if err := ix.Backfill(ctx, 9); err != nil {
+		t.Fatal(err)
+	}
+
+	events, _ := store.Events(ctx, Query{})
+	if len(events) != 2 || events[0].Decoded != nil {
+		t.Errorf("events: %+v", events)
+	}
+}
+
+func TestReorg(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	ix := newIndexer(newFakeSource(10, 0, testEvents()), store, Options{
+		Contracts:  []Contract{{Address: testContract}},
+		ReorgDepth: 4,
+	})
+	if err := ix.Backfill(ctx, 9); err != nil {
+		t.Fatal(err)
+	}
+
+	// blocks from 7 are replaced
+	fork := newFakeSource(12, 7, testEvents())
+	ix.src = fork
+	if err := ix.verify(ctx); err != nil {
+		t.Fatal(err)
+	}
+	cp, _, _ := store.Checkpoint(ctx)
+	if cp.Number != 5 {
+		t.Fatalf("expect rollback to 5, got %d", cp.Number)
+	}
+
+	if err := ix.Backfill(ctx, 11); err != nil {
+		t.Fatal(err)
+	}
+	events, _ := store.Events(ctx, Query{})
+	if len(events) != 3 || events[2].BlockHash != fork.blocks[8].Hash {
+		t.Errorf("events after reorg: %+v", events)
+	}
+}
+
+func TestFileStoreSyncEvery(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := OpenFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.SyncEvery = 4
+
+	for number := uint64(1); number <= 6; number++ {
+		block := Block{Number: number, Hash: types.Hash{byte(number)}}
+		event := Event{BlockNumber: number, BlockHash: block.Hash, Contract: testContract}
+		if err := store.SaveBlock(ctx, block, []Event{event}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 未关闭时只有前 4 个区块写入了检查点，之后的事件在打开时被丢弃
+	// before closing only the first 4 blocks are checkpointed, later events are dropped when opening
+	crashDir := t.TempDir()
+	for _, name := range []string{eventsFile, checkpointFile} {
+		bt, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(crashDir, name), bt, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	crashed, err := OpenFileStore(crashDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp, ok, _ := crashed.Checkpoint(ctx)
+	events, _ := crashed.Events(ctx, Query{})
+	if !ok || cp.Number != 4 || len(events) != 4 {
+		t.Errorf("checkpoint %+v, %d events", cp, len(events))
+	}
+	crashed.Close()
+
+	// 关闭时写入最新的检查点
+	// the latest checkpoint is written on close
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := OpenFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	cp, ok, _ = reopened.Checkpoint(ctx)
+	events, _ = reopened.Events(ctx, Query{})
+	if !ok || cp.Number != 6 || len(events) != 6 {
+		t.Errorf("checkpoint %+v, %d events", cp, len(events))
+	}
+}
@@ -0,0 +1,157 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// 已索引的合约事件
+// Indexed contract event
+type Event struct {
+	BlockNumber uint64
+	BlockHash   types.Hash
+	// 事件在区块中的序号
+	// index of event in the block
+	EventIndex uint32
+	// 交易在区块中的序号，非交易产生的事件为 -1
+	// index of extrinsic in the block, -1 for events not emitted by extrinsics
+	ExtrinsicIndex int64
+	Contract       types.H160
+	Topics         []types.Hash
+	Data           []byte
+	// ABI 中的事件名和解码后的参数，未知事件为空
+	// label of event and decoded args by ABI, empty for unknown events
+	Name    string
+	Decoded json.RawMessage
+}
+
+// 已处理的区块
+// Processed block
+type Block struct {
+	Number uint64
+	Hash   types.Hash
+}
+
+// 事件查询条件，零值字段不参与过滤
+// Query of events, zero fields are not filtered
+type Query struct {
+	Contract  *types.H160
+	Name      string
+	FromBlock uint64
+	// 包含 ToBlock，为 0 时不限制
+	// ToBlock is inclusive, 0 means no limit
+	ToBlock uint64
+	Limit   int
+}
+
+func (q Query) match(e *Event) bool {
+	if q.Contract != nil && e.Contract != *q.Contract {
+		return false
+	}
+	if q.Name != "" && e.Name != q.Name {
+		return false
+	}
+	if e.BlockNumber < q.FromBlock {
+		return false
+	}
+	if q.ToBlock != 0 && e.BlockNumber > q.ToBlock {
+		return false
+	}
+	return true
+}
+
+// 索引数据存储
+// Storage of indexer
+type Store interface {
+	// 保存区块的事件并将检查点更新为该区块
+	// Save events of block and move the checkpoint to it
+	SaveBlock(ctx context.Context, block Block, events []Event) error
+	// 最后处理的区块，未处理任何区块时返回 false
+	// Last processed block, false is returned when no block is processed
+	Checkpoint(ctx context.Context) (Block, bool, error)
+	// 删除 block 之后的事件并将检查点回退到 block
+	// Delete events after block and move the checkpoint back to it
+	Rollback(ctx context.Context, block Block) error
+	// 按区块顺序查询事件
+	// Query events in order of block
+	Events(ctx context.Context, q Query) ([]Event, error)
+}
+
+// 内存存储
+// Store in memory
+type MemoryStore struct {
+	mu         sync.RWMutex
+	events     []Event
+	checkpoint Block
+	hasCP      bool
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (s *MemoryStore) SaveBlock(_ context.Context, block Block, events []Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, events...)
+	s.checkpoint = block
+	s.hasCP = true
+	return nil
+}
+
+func (s *MemoryStore) Checkpoint(context.Context) (Block, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.checkpoint, s.hasCP, nil
+}
+
+func (s *MemoryStore) Rollback(_ context.Context, block Block) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.truncate(block.Number)
+	s.checkpoint = block
+	s.hasCP = true
+	return nil
+}
+
+func (s *MemoryStore) Events(_ context.Context, q Query) ([]Event, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// events are appended in order of block, find the first block by binary search
+	start := sort.Search(len(s.events), func(i int) bool {
+		return s.events[i].BlockNumber >= q.FromBlock
+	})
+
+	events := make([]Event, 0)
+	for i := start; i < len(s.events); i++ {
+		e := &s.events[i]
+		if q.ToBlock != 0 && e.BlockNumber > q.ToBlock {
+			break
+		}
+		if !q.match(e) {
+			continue
+		}
+		events = append(events, *e)
+		if q.Limit > 0 && len(events) >= q.Limit {
+			break
+		}
+	}
+
+	return events, nil
+}
+
+// delete events after block number
+func (s *MemoryStore) truncate(number uint64) {
+	end := sort.Search(len(s.events), func(i int) bool {
+		return s.events[i].BlockNumber > number
+	})
+	s.events = s.events[:end]
+}
@@ -0,0 +1,637 @@
+// 进程内的测试链，通过 websocket JSON-RPC 提供 ChainClient 所需的接口，
+// 使 InitClient、生成的合约代码和 SignAndSubmit 可以不依赖节点进行测试
+//
+// In-process chain for tests, it serves the JSON-RPC methods used by ChainClient over websocket,
+// so that InitClient, generated contract wrappers and SignAndSubmit can be tested without a node
+package inktest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/wetee-dao/ink.go/pallet/system"
+	gtypes "github.com/wetee-dao/ink.go/pallet/types"
+	"golang.org/x/crypto/blake2b"
+)
+
+// 运行时 API 处理函数，参数和返回值为 SCALE 编码
+// Handler of runtime api called by state_call, args and result are SCALE encoded
+type CallHandler func(args []byte) ([]byte, error)
+
+// 交易处理函数，返回交易所在区块的事件，返回错误时交易被拒绝
+// Handler of submitted extrinsic, it returns the events of the block including the extrinsic,
+// the extrinsic is rejected when an error is returned
+type SubmitHandler func(xt Extrinsic) ([]gtypes.EventRecord, error)
+
+// 提交的交易
+// Submitted extrinsic
+type Extrinsic struct {
+	Bytes []byte
+	Hash  types.Hash
+}
+
+// 交易签名账户，未签名或地址不是 AccountId 时返回 false
+// Signer of extrinsic, false is returned when it is unsigned or the address is not an AccountId
+func (xt Extrinsic) Signer() (types.AccountID, bool) {
+	var length types.UCompact
+	if err := codec.Decode(xt.Bytes, &length); err != nil {
+		return types.AccountID{}, false
+	}
+	prefix, _ := codec.Encode(length)
+
+	// version, MultiAddress::Id, AccountId
+	body := xt.Bytes[len(prefix):]
+	if len(body) < 34 || body[0]&0x80 == 0 || body[1] != 0 {
+		return types.AccountID{}, false
+	}
+	return types.AccountID(body[2:34]), true
+}
+
+// 区块
+// Block of chain
+type Block struct {
+	Hash       types.Hash
+	Header     types.Header
+	Extrinsics [][]byte
+	Events     []gtypes.EventRecord
+}
+
+// 测试链，每个区块产生后立即最终确认
+// Chain for tests, every block is finalized once it is produced
+type Chain struct {
+	// websocket 地址，用于 InitClient
+	// websocket url for InitClient
+	URL string
+
+	server   *httptest.Server
+	mu       sync.Mutex
+	conns    map[*conn]bool
+	nextSub  uint64
+	metadata string
+	runtime  types.RuntimeVersion
+	blocks   []*Block
+	hashes   map[types.Hash]*Block
+	storage  map[string][]byte
+	nonces   map[types.AccountID]uint64
+	calls    map[string]CallHandler
+	handlers map[string]Handler
+	submit   SubmitHandler
+	requests []Request
+}
+
+// 启动测试链，默认使用 pallet/types 生成时的 metadata
+// Start chain, the metadata which pallet/types is generated from is used by default
+func NewChain() (*Chain, error) {
+	metadata, err := codec.EncodeToHex(gtypes.Meta)
+	if err != nil {
+		return nil, errors.New("inktest: encode metadata: " + err.Error())
+	}
+
+	c := &Chain{
+		conns:    map[*conn]bool{},
+		metadata: metadata,
+		runtime: types.RuntimeVersion{
+			SpecName:           "inktest",
+			ImplName:           "inktest",
+			SpecVersion:        1,
+			TransactionVersion: 1,
+		},
+		hashes:   map[types.Hash]*Block{},
+		storage:  map[string][]byte{},
+		nonces:   map[types.AccountID]uint64{},
+		calls:    map[string]CallHandler{},
+		handlers: map[string]Handler{},
+		submit: func(Extrinsic) ([]gtypes.EventRecord, error) {
+			return []gtypes.EventRecord{ExtrinsicSuccess(0)}, nil
+		},
+	}
+	if _, err := c.NewBlock(nil, nil); err != nil {
+		return nil, err
+	}
+	c.SetFee(DefaultFee)
+
+	c.server = httptest.NewServer(c)
+	c.URL = "ws" + strings.TrimPrefix(c.server.URL, "http")
+	return c, nil
+}
+
+// 关闭测试链
+// Close chain
+func (c *Chain) Close() {
+	c.mu.Lock()
+	for cn := range c.conns {
+		cn.ws.Close()
+	}
+	c.mu.Unlock()
+	c.server.Close()
+}
+
+// 停止测试链，断开所有连接并停止监听，状态保留到 Restart
+// Stop chain, all connections are dropped and the url stops listening, the state is kept until Restart
+func (c *Chain) Stop() {
+	c.Close()
+}
+
+// 在原地址上重新启动已停止的测试链
+// Restart the stopped chain on the same url
+func (c *Chain) Restart() error {
+	l, err := net.Listen("tcp", c.server.Listener.Addr().String())
+	if err != nil {
+		return errors.New("inktest: restart: " + err.Error())
+	}
+	server := httptest.NewUnstartedServer(c)
+	server.Listener.Close()
+	server.Listener = l
+	server.Start()
+
+	c.mu.Lock()
+	c.server = server
+	c.mu.Unlock()
+	return nil
+}
+
+// 断开所有 websocket 连接，用于测试重连和重新订阅
+// Drop all websocket connections, it is used to test reconnecting and resubscribing
+func (c *Chain) DropConnections() {
+	c.mu.Lock()
+	conns := make([]*conn, 0, len(c.conns))
+	for cn := range c.conns {
+		conns = append(conns, cn)
+	}
+	c.mu.Unlock()
+
+	for _, cn := range conns {
+		c.dropConn(cn)
+	}
+}
+
+// 设置 metadata，meta 为 state_getMetadata 返回的 hex
+// Set metadata, meta is the hex returned by state_getMetadata
+func (c *Chain) SetMetadata(meta string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.metadata = meta
+}
+
+// 设置运行时版本
+// Set runtime version
+func (c *Chain) SetRuntimeVersion(runtime types.RuntimeVersion) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.runtime = runtime
+}
+
+// 设置存储，value 为 nil 时删除
+// Set storage of key, the key is deleted when value is nil
+func (c *Chain) SetStorage(key types.StorageKey, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if value == nil {
+		delete(c.storage, key.Hex())
+		return
+	}
+	c.storage[key.Hex()] = value
+}
+
+// 设置存储为 value 的 SCALE 编码
+// Set storage of key to SCALE encoded value
+func (c *Chain) SetStorageValue(key types.StorageKey, value any) error {
+	bt, err := codec.Encode(value)
+	if err != nil {
+		return errors.New("inktest: encode storage: " + err.Error())
+	}
+	c.SetStorage(key, bt)
+	return nil
+}
+
+// 设置账户的下一个 nonce，签名交易提交后自动加一
+// Set next nonce of account, it increases after signed extrinsics are submitted
+func (c *Chain) SetNonce(account types.AccountID, nonce uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nonces[account] = nonce
+}
+
+// 设置运行时 API 的处理函数，method 如 ReviveApi_call
+// Set handler of runtime api called by state_call, such as ReviveApi_call
+func (c *Chain) HandleCall(method string, fn CallHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls[method] = fn
+}
+
+// 设置交易处理函数
+// Set handler of submitted extrinsics
+func (c *Chain) HandleSubmit(fn SubmitHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.submit = fn
+}
+
+// 设置 rpc 方法的处理函数，覆盖内置实现
+// Set handler of rpc method, it overrides the builtin one
+func (c *Chain) Handle(method string, fn Handler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handlers[method] = fn
+}
+
+// 已收到的 rpc 请求
+// Received rpc requests
+func (c *Chain) Requests() []Request {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Request{}, c.requests...)
+}
+
+// 最新区块
+// Latest block
+func (c *Chain) Head() *Block {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.blocks[len(c.blocks)-1]
+}
+
+// 区块号对应的区块，不存在时返回 nil
+// Block of number, nil is returned when it does not exist
+func (c *Chain) Block(number uint64) *Block {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if number >= uint64(len(c.blocks)) {
+		return nil
+	}
+	return c.blocks[number]
+}
+
+// 产生新区块并通知区块头订阅
+// Produce a new block and notify subscriptions of heads
+func (c *Chain) NewBlock(extrinsics [][]byte, events []gtypes.EventRecord) (*Block, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.newBlock(extrinsics, events)
+}
+
+func (c *Chain) newBlock(extrinsics [][]byte, events []gtypes.EventRecord) (*Block, error) {
+	header := types.Header{Number: types.BlockNumber(len(c.blocks))}
+	if len(c.blocks) > 0 {
+		header.ParentHash = c.blocks[len(c.blocks)-1].Hash
+	}
+	bt, err := codec.Encode(header)
+	if err != nil {
+		return nil, errors.New("inktest: encode header: " + err.Error())
+	}
+
+	block := &Block{
+		Hash:       blake2b.Sum256(bt),
+		Header:     header,
+		Extrinsics: extrinsics,
+		Events:     events,
+	}
+	c.blocks = append(c.blocks, block)
+	c.hashes[block.Hash] = block
+
+	for cn := range c.conns {
+		for _, sub := range cn.subs {
+			if sub.method == "chain_newHead" || sub.method == "chain_finalizedHead" {
+				sub.notify(header)
+			}
+		}
+	}
+	return block, nil
+}
+
+// 处理 rpc 请求，after 在返回结果后执行
+// Handle rpc request, after is run once the result is returned
+func (c *Chain) handle(cn *conn, method string, params []json.RawMessage) (result any, after func(), err error) {
+	c.mu.Lock()
+	c.conns[cn] = true
+	c.requests = append(c.requests, Request{Method: method, Params: params})
+	handler, ok := c.handlers[method]
+	c.mu.Unlock()
+	if ok {
+		result, err = handler(params)
+		return
+	}
+
+	switch method {
+	case "chain_getBlockHash":
+		result, err = c.getBlockHash(params)
+	case "chain_getHeader":
+		result, err = c.getHeader(params)
+	case "chain_getBlock":
+		result, err = c.getBlock(params)
+	case "chain_getFinalizedHead":
+		result = c.Head().Hash
+	case "state_getMetadata":
+		c.mu.Lock()
+		result = c.metadata
+		c.mu.Unlock()
+	case "state_getRuntimeVersion":
+		c.mu.Lock()
+		result = c.runtime
+		c.mu.Unlock()
+	case "state_call":
+		result, err = c.stateCall(params)
+	case "state_getStorage":
+		result, err = c.getStorage(params)
+	case "state_getKeys":
+		result, err = c.getKeys(params)
+	case "state_queryStorageAt":
+		result, err = c.queryStorageAt(params)
+	case "system_accountNextIndex":
+		result, err = c.accountNextIndex(params)
+	case "author_submitAndWatchExtrinsic":
+		result, after, err = c.submitAndWatch(cn, params)
+	case "chain_subscribeNewHeads":
+		result = c.subscribe(cn, "chain_newHead").id
+	case "chain_subscribeFinalizedHeads":
+		result = c.subscribe(cn, "chain_finalizedHead").id
+	case "author_unwatchExtrinsic", "chain_unsubscribeNewHeads", "chain_unsubscribeFinalizedHeads":
+		result, err = c.unsubscribe(cn, params)
+	default:
+		err = &Error{Code: ErrCodeMethodNotFound, Message: "method not found: " + method}
+	}
+	return
+}
+
+func (c *Chain) getBlockHash(params []json.RawMessage) (any, error) {
+	number := uint64(c.Head().Header.Number)
+	if err := param(params, 0, &number); err != nil {
+		return nil, err
+	}
+	block := c.Block(number)
+	if block == nil {
+		return nil, nil
+	}
+	return block.Hash, nil
+}
+
+// 区块哈希参数对应的区块，参数缺失时为最新区块
+// Block of hash param, the latest block when the param is missing
+func (c *Chain) blockAt(params []json.RawMessage, i int) (*Block, error) {
+	var at *types.Hash
+	if err := param(params, i, &at); err != nil {
+		return nil, err
+	}
+	if at == nil {
+		return c.Head(), nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	block, ok := c.hashes[*at]
+	if !ok {
+		return nil, &Error{Code: ErrCodeServer, Message: "unknown block " + at.Hex()}
+	}
+	return block, nil
+}
+
+func (c *Chain) getHeader(params []json.RawMessage) (any, error) {
+	block, err := c.blockAt(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	return block.Header, nil
+}
+
+func (c *Chain) getBlock(params []json.RawMessage) (any, error) {
+	block, err := c.blockAt(params, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	extrinsics := make([]string, 0, len(block.Extrinsics))
+	for _, xt := range block.Extrinsics {
+		extrinsics = append(extrinsics, "0x"+hex.EncodeToString(xt))
+	}
+	return map[string]any{
+		"block": map[string]any{
+			"header":     block.Header,
+			"extrinsics": extrinsics,
+		},
+		"justifications": nil,
+	}, nil
+}
+
+func (c *Chain) stateCall(params []json.RawMessage) (any, error) {
+	var method, data string
+	if err := param(params, 0, &method); err != nil {
+		return nil, err
+	}
+	if err := param(params, 1, &data); err != nil {
+		return nil, err
+	}
+	args, err := codec.HexDecodeString(data)
+	if err != nil {
+		return nil, &Error{Code: ErrCodeInvalidParams, Message: "decode args: " + err.Error()}
+	}
+
+	c.mu.Lock()
+	fn, ok := c.calls[method]
+	c.mu.Unlock()
+	if !ok {
+		return nil, &Error{Code: ErrCodeServer, Message: "no handler of runtime api " + method}
+	}
+
+	result, err := fn(args)
+	if err != nil {
+		return nil, err
+	}
+	return "0x" + hex.EncodeToString(result), nil
+}
+
+// 存储值，System.Events 为区块的事件
+// Storage value of key at block, System.Events is the events of the block
+func (c *Chain) storageAt(key string, block *Block) (*string, error) {
+	eventsKey, err := system.MakeEventsStorageKey()
+	if err != nil {
+		return nil, err
+	}
+	if key == eventsKey.Hex() {
+		bt, err := codec.EncodeToHex(block.Events)
+		if err != nil {
+			return nil, err
+		}
+		return &bt, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.storage[key]
+	if !ok {
+		return nil, nil
+	}
+	hexValue := "0x" + hex.EncodeToString(value)
+	return &hexValue, nil
+}
+
+func (c *Chain) getStorage(params []json.RawMessage) (any, error) {
+	var key string
+	if err := param(params, 0, &key); err != nil {
+		return nil, err
+	}
+	block, err := c.blockAt(params, 1)
+	if err != nil {
+		return nil, err
+	}
+	return c.storageAt(strings.ToLower(key), block)
+}
+
+func (c *Chain) getKeys(params []json.RawMessage) (any, error) {
+	var prefix string
+	if err := param(params, 0, &prefix); err != nil {
+		return nil, err
+	}
+	prefix = strings.ToLower(prefix)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]string, 0)
+	for key := range c.storage {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (c *Chain) queryStorageAt(params []json.RawMessage) (any, error) {
+	var keys []string
+	if err := param(params, 0, &keys); err != nil {
+		return nil, err
+	}
+	block, err := c.blockAt(params, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([][2]*string, 0, len(keys))
+	for _, key := range keys {
+		value, err := c.storageAt(strings.ToLower(key), block)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, [2]*string{&key, value})
+	}
+	return []map[string]any{{"block": block.Hash, "changes": changes}}, nil
+}
+
+func (c *Chain) accountNextIndex(params []json.RawMessage) (any, error) {
+	var address string
+	if err := param(params, 0, &address); err != nil {
+		return nil, err
+	}
+	_, pub, err := subkey.SS58Decode(address)
+	if err != nil || len(pub) != 32 {
+		return nil, &Error{Code: ErrCodeInvalidParams, Message: "invalid address " + address}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.nonces[types.AccountID(pub)], nil
+}
+
+// 交易进入新区块，依次通知 ready、inBlock 和 finalized
+// The extrinsic is included in a new block, ready, inBlock and finalized are notified in order
+func (c *Chain) submitAndWatch(cn *conn, params []json.RawMessage) (any, func(), error) {
+	var data string
+	if err := param(params, 0, &data); err != nil {
+		return nil, nil, err
+	}
+	bt, err := codec.HexDecodeString(data)
+	if err != nil {
+		return nil, nil, &Error{Code: ErrCodeInvalidParams, Message: "decode extrinsic: " + err.Error()}
+	}
+	xt := Extrinsic{Bytes: bt, Hash: blake2b.Sum256(bt)}
+
+	c.mu.Lock()
+	submit := c.submit
+	c.mu.Unlock()
+	events, err := submit(xt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.mu.Lock()
+	if signer, ok := xt.Signer(); ok {
+		c.nonces[signer]++
+	}
+	block, err := c.newBlock([][]byte{bt}, events)
+	c.mu.Unlock()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sub := c.subscribe(cn, "author_extrinsicUpdate")
+	return sub.id, func() {
+		sub.notify("ready")
+		sub.notify(map[string]types.Hash{"inBlock": block.Hash})
+		sub.notify(map[string]types.Hash{"finalized": block.Hash})
+	}, nil
+}
+
+// 交易成功事件
+// ExtrinsicSuccess event of the extrinsic at index
+func ExtrinsicSuccess(index uint32) gtypes.EventRecord {
+	return systemEvent(index, &gtypes.FrameSystemPalletEvent{
+		IsExtrinsicSuccess:              true,
+		AsExtrinsicSuccessDispatchInfo0: dispatchInfo(),
+	})
+}
+
+// 交易失败事件
+// ExtrinsicFailed event of the extrinsic at index
+func ExtrinsicFailed(index uint32, err gtypes.DispatchError) gtypes.EventRecord {
+	return systemEvent(index, &gtypes.FrameSystemPalletEvent{
+		IsExtrinsicFailed:               true,
+		AsExtrinsicFailedDispatchError0: err,
+		AsExtrinsicFailedDispatchInfo1:  dispatchInfo(),
+	})
+}
+
+// 合约事件
+// ContractEmitted event of the extrinsic at index
+func ContractEmitted(index uint32, contract types.H160, topics []types.Hash, data []byte) gtypes.EventRecord {
+	topicBytes := make([][32]byte, 0, len(topics))
+	for _, t := range topics {
+		topicBytes = append(topicBytes, t)
+	}
+	return gtypes.EventRecord{
+		Phase: gtypes.Phase{IsApplyExtrinsic: true, AsApplyExtrinsicField0: index},
+		Event: gtypes.RuntimeEvent{
+			IsRevive: true,
+			AsReviveField0: &gtypes.PalletRevivePalletEvent{
+				IsContractEmitted:          true,
+				AsContractEmittedContract0: contract,
+				AsContractEmittedData1:     data,
+				AsContractEmittedTopics2:   topicBytes,
+			},
+		},
+		Topics: [][32]byte{},
+	}
+}
+
+func systemEvent(index uint32, event *gtypes.FrameSystemPalletEvent) gtypes.EventRecord {
+	return gtypes.EventRecord{
+		Phase:  gtypes.Phase{IsApplyExtrinsic: true, AsApplyExtrinsicField0: index},
+		Event:  gtypes.RuntimeEvent{IsSystem: true, AsSystemField0: event},
+		Topics: [][32]byte{},
+	}
+}
+
+func dispatchInfo() gtypes.DispatchEventInfo {
+	return gtypes.DispatchEventInfo{
+		Class:   gtypes.DispatchClass{IsNormal: true},
+		PaysFee: gtypes.Pays{IsYes: true},
+	}
+}
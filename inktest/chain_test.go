@@ -0,0 +1,1063 @@
+package inktest
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	chain "github.com/wetee-dao/ink.go"
+	"github.com/wetee-dao/ink.go/example/contracts/pod"
+	"github.com/wetee-dao/ink.go/pallet/multisig"
+	"github.com/wetee-dao/ink.go/pallet/proxy"
+	"github.com/wetee-dao/ink.go/pallet/revive"
+	"github.com/wetee-dao/ink.go/pallet/transactionpayment"
+	gtypes "github.com/wetee-dao/ink.go/pallet/types"
+	"github.com/wetee-dao/ink.go/util"
+)
+
+func TestChain(t *testing.T) {
+	c, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	client, err := chain.InitClient([]string{c.URL}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.Runtime.SpecVersion != 1 || client.Hash != c.Block(0).Hash {
+		t.Fatalf("runtime %v genesis %s", client.Runtime.SpecVersion, client.Hash.Hex())
+	}
+
+	signer, err := chain.Sr25519PairFromSecret("//Alice", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := types.CreateStorageKey(client.Meta, "System", "Account", signer.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetStorageValue(key, types.AccountInfo{Nonce: 3})
+	account, err := client.GetAccount(&signer)
+	if err != nil || account.Nonce != 3 {
+		t.Fatalf("GetAccount: %v %v", account, err)
+	}
+
+	cloud := types.H160{0xc1}
+	c.HandleContract(func(call *ContractCall) ContractReturn {
+		if call.Selector() != util.FuncToSelector("0xb24fd0f6") {
+			return ContractReturn{Data: []byte{1, 1}, Reverted: true}
+		}
+		data, _ := codec.Encode(cloud)
+		return ContractReturn{Data: data}
+	})
+
+	contract, err := pod.InitPodContract(client, "0x0000000000000000000000000000000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, _, err := contract.DryRunCloud(chain.DefaultParamWithOrigin(signer.AccountID()))
+	if err != nil || *v != cloud {
+		t.Fatalf("DryRunCloud: %v %v", v, err)
+	}
+
+	_, _, err = contract.DryRunApprove(util.NewNone[types.U256](), chain.DefaultParamWithOrigin(signer.AccountID()))
+	var lerr util.LangError
+	if !errors.As(err, &lerr) {
+		t.Errorf("DryRunApprove: %v", err)
+	}
+
+	// 合约返回 Ok(Err(NotOwner)) 而未回滚
+	// contract returns Ok(Err(NotOwner)) without reverting
+	c.HandleContract(func(call *ContractCall) ContractReturn {
+		return ContractReturn{Data: []byte{1, 4}}
+	})
+	_, _, err = contract.DryRunApprove(util.NewNone[types.U256](), chain.DefaultParamWithOrigin(signer.AccountID()))
+	var resErr *chain.ContractResultError
+	var perr *pod.Error
+	if !errors.As(err, &resErr) || errors.Is(err, chain.ErrContractReverted) || !errors.As(err, &perr) || perr.NotOwner == nil {
+		t.Errorf("DryRunApprove result error: %v", err)
+	}
+	c.HandleContract(func(call *ContractCall) ContractReturn {
+		data, _ := codec.Encode(cloud)
+		return ContractReturn{Data: data}
+	})
+
+	receipt, err := contract.ExecCloud(chain.ExecParams{Signer: &signer, PayAmount: types.NewU128(*big.NewInt(0))})
+	if err != nil || !receipt.Success {
+		t.Fatalf("ExecCloud: %v %v", receipt, err)
+	}
+	head := c.Head()
+	if receipt.BlockHash != head.Hash || len(head.Extrinsics) != 1 {
+		t.Errorf("receipt block %s, head %s", receipt.BlockHash.Hex(), head.Hash.Hex())
+	}
+	if xtSigner, ok := (Extrinsic{Bytes: head.Extrinsics[0]}).Signer(); !ok || xtSigner != signer.AccountID() {
+		t.Errorf("extrinsic signer %v", xtSigner)
+	}
+
+	ecdsaSigner, err := chain.EcdsaPairFromSecret("//Alice", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipt, err = contract.ExecCloud(chain.ExecParams{Signer: &ecdsaSigner, PayAmount: types.NewU128(*big.NewInt(0))})
+	if err != nil || !receipt.Success {
+		t.Fatalf("ExecCloud with ecdsa: %v %v", receipt, err)
+	}
+	if xtSigner, ok := (Extrinsic{Bytes: c.Head().Extrinsics[0]}).Signer(); !ok || xtSigner != ecdsaSigner.AccountID() {
+		t.Errorf("ecdsa extrinsic signer %v", xtSigner)
+	}
+
+	c.HandleSubmit(func(Extrinsic) ([]gtypes.EventRecord, error) {
+		return []gtypes.EventRecord{ExtrinsicFailed(0, gtypes.DispatchError{IsBadOrigin: true})}, nil
+	})
+	_, err = contract.ExecCloud(chain.ExecParams{Signer: &signer, PayAmount: types.NewU128(*big.NewInt(0))})
+	var derr *chain.DispatchError
+	if !errors.As(err, &derr) || derr.Kind() != "BadOrigin" {
+		t.Errorf("failed extrinsic: %v", err)
+	}
+}
+
+func TestEthTransact(t *testing.T) {
+	c, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	c.HandleContract(func(call *ContractCall) ContractReturn {
+		data, _ := codec.Encode(types.H160{0xc1})
+		return ContractReturn{Data: data}
+	})
+	gasPrice, _ := codec.Encode(types.NewU256(*big.NewInt(1000)))
+	c.HandleCall("ReviveApi_gas_price", func([]byte) ([]byte, error) { return gasPrice, nil })
+	gasLimit, _ := codec.Encode(types.NewU256(*big.NewInt(30_000_000)))
+	c.HandleCall("ReviveApi_block_gas_limit", func([]byte) ([]byte, error) { return gasLimit, nil })
+
+	var payload []byte
+	c.HandleSubmit(func(xt Extrinsic) ([]gtypes.EventRecord, error) {
+		// compact length, version, call index and payload
+		var ext struct {
+			Length    types.UCompact
+			Version   byte
+			CallIndex types.CallIndex
+			Payload   []byte
+		}
+		if err := codec.Decode(xt.Bytes, &ext); err != nil {
+			return nil, err
+		}
+		if ext.Version != 4 {
+			return nil, errors.New("eth_transact extrinsic is signed")
+		}
+		payload = ext.Payload
+		return []gtypes.EventRecord{ExtrinsicSuccess(0)}, nil
+	})
+
+	client, err := chain.InitClient([]string{c.URL}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contract, err := pod.InitPodContract(client, "0x0000000000000000000000000000000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := chain.EcdsaPairFromSecret("0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	receipt, err := contract.ExecCloud(chain.ExecParams{
+		Signer:    &signer,
+		PayAmount: types.NewU128(*big.NewInt(2)),
+		Eth:       &chain.EthTxOptions{},
+	})
+	if err != nil || !receipt.Success {
+		t.Fatalf("ExecCloud: %v %v", receipt, err)
+	}
+
+	tx := new(ethtypes.Transaction)
+	if err := tx.UnmarshalBinary(payload); err != nil {
+		t.Fatal(err)
+	}
+	chainID, _ := client.EthChainID()
+	from, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(new(big.Int).SetUint64(chainID)), tx)
+	address, _ := signer.EthAddress()
+	if err != nil || types.H160(from) != address {
+		t.Errorf("sender %s %v", from.Hex(), err)
+	}
+	if tx.Type() != ethtypes.DynamicFeeTxType || tx.To() == nil || types.H160(*tx.To()) != contract.Address {
+		t.Errorf("tx type %d to %v", tx.Type(), tx.To())
+	}
+	if tx.Gas() != 30_000_000 || tx.GasFeeCap().Int64() != 1000 || tx.Value().Int64() != 200_000_000 {
+		t.Errorf("gas %d fee cap %s value %s", tx.Gas(), tx.GasFeeCap(), tx.Value())
+	}
+	if hex.EncodeToString(tx.Data()[:4]) != "b24fd0f6" {
+		t.Errorf("data %x", tx.Data())
+	}
+}
+
+func TestEthTransactMappedAccount(t *testing.T) {
+	c, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	var payload []byte
+	c.HandleSubmit(func(xt Extrinsic) ([]gtypes.EventRecord, error) {
+		var ext struct {
+			Length    types.UCompact
+			Version   byte
+			CallIndex types.CallIndex
+			Payload   []byte
+		}
+		if err := codec.Decode(xt.Bytes, &ext); err != nil {
+			return nil, err
+		}
+		payload = ext.Payload
+		return []gtypes.EventRecord{ExtrinsicSuccess(0)}, nil
+	})
+
+	signer, err := chain.EcdsaPairFromSecret("0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	address, _ := signer.EthAddress()
+	mapped := types.AccountID{0x0a}
+	key, _ := revive.MakeOriginalAccountStorageKey(address)
+	if err := c.SetStorageValue(key, [32]byte(mapped)); err != nil {
+		t.Fatal(err)
+	}
+	c.SetNonce(mapped, 7)
+	c.SetNonce(util.AccountIDFromH160(address), 2)
+
+	client, err := chain.InitClient([]string{c.URL}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := client.EthAccountID(address)
+	if err != nil || account != mapped {
+		t.Fatalf("EthAccountID %x %v", account, err)
+	}
+
+	receipt, err := client.SubmitEthTransaction(&signer, chain.EthTransaction{
+		To:           &types.H160{0xc1},
+		EthTxOptions: chain.EthTxOptions{GasLimit: 21_000, GasPrice: big.NewInt(1000)},
+	}, chain.SubmitOptions{})
+	if err != nil || !receipt.Success {
+		t.Fatalf("SubmitEthTransaction: %v %v", receipt, err)
+	}
+
+	tx := new(ethtypes.Transaction)
+	if err := tx.UnmarshalBinary(payload); err != nil {
+		t.Fatal(err)
+	}
+	if tx.Nonce() != 7 {
+		t.Errorf("nonce %d, want nonce of mapped account", tx.Nonce())
+	}
+
+	// 生成代码的 Exec* 以已映射的原账户预执行
+	// generated Exec* dry runs as the original account of mapped address
+	var origins []types.AccountID
+	c.HandleContract(func(call *ContractCall) ContractReturn {
+		origins = append(origins, call.Origin)
+		data, _ := codec.Encode(types.H160{0xc1})
+		return ContractReturn{Data: data}
+	})
+	gasPrice, _ := codec.Encode(types.NewU256(*big.NewInt(1000)))
+	c.HandleCall("ReviveApi_gas_price", func([]byte) ([]byte, error) { return gasPrice, nil })
+	gasLimit, _ := codec.Encode(types.NewU256(*big.NewInt(30_000_000)))
+	c.HandleCall("ReviveApi_block_gas_limit", func([]byte) ([]byte, error) { return gasLimit, nil })
+	contract, err := pod.InitPodContract(client, "0x0000000000000000000000000000000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipt, err = contract.ExecCloud(chain.ExecParams{Signer: &signer, Eth: &chain.EthTxOptions{}})
+	if err != nil || !receipt.Success {
+		t.Fatalf("ExecCloud: %v %v", receipt, err)
+	}
+	if len(origins) == 0 || origins[0] != mapped {
+		t.Errorf("dry run origins %x, want mapped account", origins)
+	}
+}
+
+// 测试用的部分签名者，部分签名即完整签名
+// Partial signer of tests, the partial signature is a full signature
+type partialSigner struct {
+	chain.Signer
+}
+
+func (p *partialSigner) PartialSign(msg []byte) ([]byte, error) {
+	return p.Sign(msg)
+}
+
+func TestPartialSignTx(t *testing.T) {
+	c, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	client, err := chain.InitClient([]string{c.URL}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alice, err := chain.Ed25519PairFromSecret("//Alice", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetNonce(alice.AccountID(), 5)
+
+	call, err := types.NewCall(client.Meta, "System.remark", []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := client.NewPartialSignTx(context.Background(), alice.PublicKey, alice.KeyType, call, chain.SubmitOptions{Era: chain.MortalEra(64)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Nonce != 5 || tx.AccountID() != alice.AccountID() {
+		t.Fatalf("nonce %d account %x", tx.Nonce, tx.AccountID())
+	}
+
+	bob, _ := chain.Ed25519PairFromSecret("//Bob", 42)
+	var partials [][]byte
+	for _, party := range []chain.Signer{bob, alice} {
+		sig, err := tx.PartialSign(&partialSigner{party})
+		if err != nil {
+			t.Fatal(err)
+		}
+		partials = append(partials, sig)
+	}
+
+	// 1-of-n 聚合，选择可以验证的签名
+	// 1-of-n combiner, the signature which can be verified is selected
+	combiner := chain.SignatureCombinerFunc(func(msg []byte, partials [][]byte) ([]byte, error) {
+		for _, sig := range partials {
+			if alice.Verify(msg, sig) {
+				return sig, nil
+			}
+		}
+		return nil, errors.New("no valid signature")
+	})
+	if err := tx.Combine(combiner, partials[:1]); err == nil {
+		t.Error("signature of bob is combined")
+	}
+
+	// 聚合失败的交易被取消，nonce 被归还
+	// extrinsic is cancelled when combination fails, and its nonce is released
+	if err := tx.Combine(combiner, partials); err == nil {
+		t.Error("cancelled extrinsic is combined")
+	}
+	tx, err = client.NewPartialSignTx(context.Background(), alice.PublicKey, alice.KeyType, call, chain.SubmitOptions{Era: chain.MortalEra(64)})
+	if err != nil || tx.Nonce != 5 {
+		t.Fatalf("nonce after failed combination %v %v", tx, err)
+	}
+	partials = nil
+	for _, party := range []chain.Signer{bob, alice} {
+		sig, err := tx.PartialSign(&partialSigner{party})
+		if err != nil {
+			t.Fatal(err)
+		}
+		partials = append(partials, sig)
+	}
+	if err := tx.Combine(combiner, partials); err != nil {
+		t.Fatal(err)
+	}
+
+	receipt, err := client.SubmitPartialSignTx(context.Background(), tx)
+	if err != nil || !receipt.Success {
+		t.Fatalf("SubmitPartialSignTx: %v %v", receipt, err)
+	}
+	if xtSigner, ok := (Extrinsic{Bytes: c.Head().Extrinsics[0]}).Signer(); !ok || xtSigner != alice.AccountID() {
+		t.Errorf("extrinsic signer %v", xtSigner)
+	}
+
+	// 未指定 era 时交易永久有效
+	// extrinsic is immortal when era is not set
+	tx, err = client.NewPartialSignTx(context.Background(), alice.PublicKey, alice.KeyType, call, chain.SubmitOptions{})
+	if err != nil || tx.Nonce != 6 {
+		t.Fatalf("partial sign tx %v %v", tx, err)
+	}
+	if summary, err := chain.NewPayloadSummary(nil, tx.Payload); err != nil || !summary.Immortal {
+		t.Errorf("summary %+v %v", summary, err)
+	}
+	client.CancelPartialSignTx(tx)
+}
+
+func TestOfflineSign(t *testing.T) {
+	c, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	// 运行时版本与离线签名使用的元数据一致
+	// runtime version is the same as the one of metadata used offline
+	version, err := chain.RuntimeVersionOf(&gtypes.Meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetRuntimeVersion(*version)
+
+	client, err := chain.InitClient([]string{c.URL}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alice, err := chain.Sr25519PairFromSecret("//Alice", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetNonce(alice.AccountID(), 3)
+
+	// 在线机器构建交易
+	// build the extrinsic on the online machine
+	call, err := types.NewCall(client.Meta, "System.remark", []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsigned, err := client.NewUnsignedTx(alice.AccountID(), call, chain.SubmitOptions{Era: chain.MortalEra(64)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(unsigned)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 离线机器使用缓存的元数据签名
+	// sign on the offline machine with cached metadata
+	var offline chain.UnsignedTx
+	if err := json.Unmarshal(data, &offline); err != nil {
+		t.Fatal(err)
+	}
+	summary, err := offline.Summary(&gtypes.Meta)
+	if err != nil || summary.Call != "System.remark" || summary.Nonce != 3 || summary.Immortal {
+		t.Fatalf("summary %+v %v", summary, err)
+	}
+	if summary.Period != 64 || summary.ExpiresAt != offline.BlockNumber+64 {
+		t.Errorf("period %d expires at %d", summary.Period, summary.ExpiresAt)
+	}
+	stale := offline
+	stale.SpecVersion--
+	if _, err := stale.Sign(&alice, &gtypes.Meta); err == nil {
+		t.Error("extrinsic of other runtime version is signed")
+	}
+	bob, _ := chain.Sr25519PairFromSecret("//Bob", 42)
+	if _, err := offline.Sign(&bob, &gtypes.Meta); err == nil {
+		t.Error("extrinsic of alice is signed by bob")
+	}
+	raw, err := offline.Sign(&alice, &gtypes.Meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	receipt, err := client.SubmitRaw(raw, false)
+	if err != nil || !receipt.Success {
+		t.Fatalf("SubmitRaw: %v %v", receipt, err)
+	}
+	if xtSigner, ok := (Extrinsic{Bytes: c.Head().Extrinsics[0]}).Signer(); !ok || xtSigner != alice.AccountID() {
+		t.Errorf("extrinsic signer %v", xtSigner)
+	}
+	if nonce, err := client.Nonces.Next(context.Background(), alice.AccountID()); err != nil || nonce != 4 {
+		t.Errorf("next nonce %d %v", nonce, err)
+	}
+
+	// 未指定 era 时离线交易永久有效
+	// offline extrinsic is immortal when era is not set
+	unsigned, err = client.NewUnsignedTx(alice.AccountID(), call, chain.SubmitOptions{})
+	if err != nil || !unsigned.Era.IsImmortalEra {
+		t.Errorf("default era %+v %v", unsigned, err)
+	}
+}
+
+func TestMultisig(t *testing.T) {
+	c, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	client, err := chain.InitClient([]string{c.URL}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alice, _ := chain.Sr25519PairFromSecret("//Alice", 42)
+	bob, _ := chain.Sr25519PairFromSecret("//Bob", 42)
+	charlie, _ := chain.Sr25519PairFromSecret("//Charlie", 42)
+	m, err := chain.NewMultisig(2, charlie.AccountID(), alice.AccountID(), bob.AccountID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	reordered, _ := chain.NewMultisig(2, alice.AccountID(), bob.AccountID(), charlie.AccountID())
+	if m.AccountID() != reordered.AccountID() {
+		t.Error("multisig account depends on order of signatories")
+	}
+
+	call, err := types.NewCall(client.Meta, "System.remark", []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	innerCall, err := codec.Encode(call)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var remark gtypes.RuntimeCall
+	if err := codec.Decode(innerCall, &remark); err != nil {
+		t.Fatal(err)
+	}
+	others := func(signer types.AccountID) [][32]byte {
+		others := [][32]byte{}
+		for _, s := range m.Signatories {
+			if s != signer {
+				others = append(others, s)
+			}
+		}
+		return others
+	}
+	// 提交的交易以期望的调用结尾
+	// submitted extrinsic ends with the expected call
+	expectCall := func(runtimeCall gtypes.RuntimeCall) {
+		t.Helper()
+		want, err := runtimeCall.AsCall()
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantBytes, _ := codec.Encode(want)
+		if xt := c.Head().Extrinsics[0]; !bytes.HasSuffix(xt, wantBytes) {
+			t.Errorf("extrinsic %x does not end with call %x", xt, wantBytes)
+		}
+	}
+
+	// 第一次批准创建多签操作
+	// the first approval opens the operation
+	receipt, err := client.AsMulti(&alice, m, call, types.Weight{}, chain.SubmitOptions{})
+	if err != nil || !receipt.Success {
+		t.Fatalf("AsMulti: %v %v", receipt, err)
+	}
+	expectCall(multisig.MakeAsMultiCall(2, others(alice.AccountID()), gtypes.OptionTTimepoint{IsNone: true}, remark, ContractWeight))
+
+	callHash, _ := chain.CallHash(call)
+	key, err := multisig.MakeMultisigsStorageKey(m.AccountID(), callHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	timepoint := gtypes.Timepoint{Height: 1, Index: 0}
+	if err := c.SetStorageValue(key, gtypes.Multisig{
+		When:      timepoint,
+		Deposit:   types.NewU128(*big.NewInt(1)),
+		Depositor: alice.AccountID(),
+		Approvals: [][32]byte{alice.AccountID()},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ApproveAsMulti(&alice, m, call, chain.SubmitOptions{}); err == nil {
+		t.Error("alice approved twice")
+	}
+
+	// 之后的批准使用链上的时间点
+	// later approvals use the timepoint on chain
+	receipt, err = client.AsMulti(&bob, m, call, types.Weight{}, chain.SubmitOptions{})
+	if err != nil || !receipt.Success {
+		t.Fatalf("AsMulti: %v %v", receipt, err)
+	}
+	expectCall(multisig.MakeAsMultiCall(2, others(bob.AccountID()), gtypes.OptionTTimepoint{IsSome: true, AsSomeField0: timepoint}, remark, ContractWeight))
+
+	dave, _ := chain.Sr25519PairFromSecret("//Dave", 42)
+	if _, err := client.AsMulti(&dave, m, call, types.Weight{}, chain.SubmitOptions{}); err == nil {
+		t.Error("call is approved by dave who is not a signatory")
+	}
+}
+
+func TestProxy(t *testing.T) {
+	c, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	client, err := chain.InitClient([]string{c.URL}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alice, _ := chain.Sr25519PairFromSecret("//Alice", 42)
+	bob, _ := chain.Sr25519PairFromSecret("//Bob", 42)
+
+	proxies, deposit, err := client.Proxies(alice.AccountID())
+	if err != nil || len(proxies) != 0 || deposit.Int.Sign() != 0 {
+		t.Fatalf("Proxies: %v %v %v", proxies, deposit, err)
+	}
+
+	receipt, err := client.AddProxy(&alice, bob.AccountID(), gtypes.ProxyType{IsNonTransfer: true}, 0, chain.SubmitOptions{})
+	if err != nil || !receipt.Success {
+		t.Fatalf("AddProxy: %v %v", receipt, err)
+	}
+	runtimeCall := proxy.MakeAddProxyCall(gtypes.MultiAddress{IsId: true, AsIdField0: bob.AccountID()}, gtypes.ProxyType{IsNonTransfer: true}, 0)
+	addProxy, _ := runtimeCall.AsCall()
+	want, _ := codec.Encode(addProxy)
+	if xt := c.Head().Extrinsics[0]; !bytes.HasSuffix(xt, want) {
+		t.Errorf("extrinsic %x does not end with call %x", xt, want)
+	}
+
+	key, err := proxy.MakeProxiesStorageKey(alice.AccountID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetStorageValue(key, gtypes.TupleOfProxyDefinitionSliceU128{
+		Elem0: []gtypes.ProxyDefinition{{Delegate: bob.AccountID(), ProxyType: gtypes.ProxyType{IsNonTransfer: true}}},
+		Elem1: types.NewU128(*big.NewInt(100)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	proxies, deposit, err = client.Proxies(alice.AccountID())
+	if err != nil || len(proxies) != 1 || proxies[0].Delegate != bob.AccountID() || !proxies[0].ProxyType.IsNonTransfer || deposit.Int.Int64() != 100 {
+		t.Fatalf("Proxies: %v %v %v", proxies, deposit, err)
+	}
+}
+
+func TestUploadCodeError(t *testing.T) {
+	c, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	client, err := chain.InitClient([]string{c.URL}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice, _ := chain.Sr25519PairFromSecret("//Alice", 42)
+
+	c.HandleCall("ReviveApi_upload_code", func([]byte) ([]byte, error) {
+		return codec.Encode(util.Result[util.UploadResult, gtypes.DispatchError]{
+			V: util.UploadResult{CodeHash: types.H256{1}, Deposit: types.NewU128(*big.NewInt(10))},
+		})
+	})
+	c.HandleSubmit(func(Extrinsic) ([]gtypes.EventRecord, error) {
+		return []gtypes.EventRecord{ExtrinsicFailed(0, gtypes.DispatchError{
+			IsModule:       true,
+			AsModuleField0: gtypes.ModuleError{Index: 60, Error: [4]byte{3}},
+		})}, nil
+	})
+
+	// 提交失败的错误可以用 errors.As 取出
+	// error of failed submission can be unwrapped by errors.As
+	_, _, err = client.UploadInkCode([]byte{0x50, 0x56, 0x4d}, &alice, chain.SubmitOptions{})
+	var merr *chain.ModuleError
+	if !errors.As(err, &merr) || merr.Index != 60 {
+		t.Fatalf("UploadInkCode error %v", err)
+	}
+	var derr *chain.DispatchError
+	if !errors.As(err, &derr) || derr.Kind() != "Module" {
+		t.Errorf("UploadInkCode dispatch error %v", err)
+	}
+}
+
+func TestEstimateFeeKeyType(t *testing.T) {
+	c, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	client, err := chain.InitClient([]string{c.URL}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	call, err := types.NewCall(client.Meta, "System.remark", []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sr, _ := chain.Sr25519PairFromSecret("//Alice", 42)
+	ecdsa, _ := chain.EcdsaPairFromSecret("//Alice", 42)
+
+	srFee, err := client.EstimateFee(call, &sr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaFee, err := client.EstimateFee(call, &ecdsa)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ecdsa 签名比 sr25519 签名长一个字节
+	// ecdsa signature is one byte longer than sr25519 signature
+	if ecdsaFee.Length != srFee.Length+1 {
+		t.Errorf("length of ecdsa extrinsic %d, sr25519 %d", ecdsaFee.Length, srFee.Length)
+	}
+
+	// 费用乘数只在调用方请求时读取
+	// fee multiplier is read only when the caller asks for it
+	for _, req := range c.Requests() {
+		if req.Method == "state_getStorage" {
+			t.Errorf("storage is read by EstimateFee: %s", req.Params)
+		}
+	}
+	multiplier, err := client.NextFeeMultiplier()
+	if err != nil || multiplier.String() != "1000000000000000000" {
+		t.Errorf("default multiplier %v %v", multiplier, err)
+	}
+	key, _ := transactionpayment.MakeNextFeeMultiplierStorageKey()
+	if err := c.SetStorageValue(key, types.NewU128(*big.NewInt(2))); err != nil {
+		t.Fatal(err)
+	}
+	multiplier, err = client.NextFeeMultiplier()
+	if err != nil || multiplier.Int64() != 2 {
+		t.Errorf("multiplier %v %v", multiplier, err)
+	}
+}
+
+func TestWatchContractEvents(t *testing.T) {
+	c, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	client, err := chain.InitClient([]string{c.URL}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contract := types.H160{1}
+	topic := types.Hash{2}
+	sub, err := client.WatchContractEvents(contract, &topic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	block, err := c.NewBlock(nil, []gtypes.EventRecord{
+		ContractEmitted(0, types.H160{3}, []types.Hash{topic}, []byte{1}),
+		ContractEmitted(0, contract, []types.Hash{{4}}, []byte{2}),
+		ContractEmitted(0, contract, []types.Hash{topic}, []byte{3}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 只返回最终确认区块中匹配合约和主题的事件
+	// only events of contract and topic in finalized blocks are returned
+	select {
+	case e := <-sub.Chan():
+		if e.BlockNumber != uint64(block.Header.Number) || e.BlockHash != block.Hash || !bytes.Equal(e.Data, []byte{3}) {
+			t.Errorf("event %+v", e)
+		}
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no contract event")
+	}
+}
+
+func TestFinalizedEventsResubscribe(t *testing.T) {
+	c, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	opts := chain.DefaultPoolOptions()
+	opts.HealthCheckInterval = 50 * time.Millisecond
+	opts.MinBackoff = 200 * time.Millisecond
+	opts.MaxBackoff = 400 * time.Millisecond
+	client, err := chain.InitClientWithPool([]string{c.URL}, false, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub, err := client.SubscribeFinalizedEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	// System.Events 的第一次查询失败，重试后继续订阅
+	// the first query of System.Events fails, the subscription goes on after retrying
+	failed := false
+	c.Handle("state_getStorage", func(params []json.RawMessage) (any, error) {
+		c.mu.Lock()
+		fail := !failed
+		failed = true
+		c.mu.Unlock()
+		if fail {
+			return nil, &Error{Code: -32000, Message: "temporary failure"}
+		}
+		return c.getStorage(params)
+	})
+
+	next := func() chain.BlockEvents {
+		t.Helper()
+		select {
+		case block, ok := <-sub.Chan():
+			if !ok {
+				t.Fatalf("subscription ended: %v", <-sub.Err())
+			}
+			return block
+		case <-time.After(10 * time.Second):
+			t.Fatal("no finalized block")
+		}
+		return chain.BlockEvents{}
+	}
+
+	if _, err := c.NewBlock(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if block := next(); block.Number != 1 {
+		t.Fatalf("block %d", block.Number)
+	}
+
+	// 连接断开期间产生的区块在重新订阅后补齐
+	// blocks produced while the connection is lost are filled in after resubscribing
+	c.DropConnections()
+	for range 3 {
+		if _, err := c.NewBlock(nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-time.After(50 * time.Millisecond):
+				c.NewBlock(nil, nil)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for want := uint64(2); want <= 6; want++ {
+		if block := next(); block.Number != want {
+			t.Fatalf("block %d, want %d", block.Number, want)
+		}
+	}
+}
+
+// 等待连接池事件，忽略其他类型的事件
+// Wait for pool event of type, events of other types are skipped
+func waitPoolEvent(t *testing.T, events <-chan chain.PoolEvent, typ chain.PoolEventType) chain.PoolEvent {
+	t.Helper()
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case e := <-events:
+			if e.Type == typ {
+				return e
+			}
+		case <-timeout:
+			t.Fatalf("no %s event", typ)
+		}
+	}
+}
+
+func poolOptions(events chan<- chain.PoolEvent) chain.PoolOptions {
+	opts := chain.DefaultPoolOptions()
+	opts.HealthCheckInterval = 50 * time.Millisecond
+	opts.HealthCheckTimeout = time.Second
+	opts.MinBackoff = 100 * time.Millisecond
+	opts.MaxBackoff = 200 * time.Millisecond
+	opts.OnEvent = func(e chain.PoolEvent) {
+		events <- e
+	}
+	return opts
+}
+
+func TestPoolReconnect(t *testing.T) {
+	c, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	events := make(chan chain.PoolEvent, 64)
+	client, err := chain.InitClientWithPool([]string{c.URL}, false, poolOptions(events))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// 链停止后健康检查失败，连接池不可用
+	// health check fails after the chain stops, the pool is down
+	c.Stop()
+	if e := waitPoolEvent(t, events, chain.PoolConnLost); e.URL != c.URL || e.Err == nil {
+		t.Fatalf("lost event %+v", e)
+	}
+	waitPoolEvent(t, events, chain.PoolDown)
+	if _, err := client.Api(); !errors.Is(err, chain.ErrNoConnection) {
+		t.Fatalf("api of down pool: %v", err)
+	}
+
+	// 链重启后按退避重连，连接池恢复
+	// the pool is reconnected with backoff and recovers after the chain restarts
+	if err := c.Restart(); err != nil {
+		t.Fatal(err)
+	}
+	if e := waitPoolEvent(t, events, chain.PoolConnRestored); e.URL != c.URL || e.Healthy != 1 {
+		t.Fatalf("restored event %+v", e)
+	}
+	if e := waitPoolEvent(t, events, chain.PoolRecovered); e.Healthy != 1 || e.Total != 1 {
+		t.Fatalf("recovered event %+v", e)
+	}
+	if number, err := client.GetBlockNumber(); err != nil || number != 0 {
+		t.Fatalf("block number %d, %v", number, err)
+	}
+}
+
+func TestPoolGenesisMismatch(t *testing.T) {
+	a, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close()
+	b, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	// b 的创世区块与 a 不同时拒绝连接
+	// b is rejected while its genesis hash differs from a
+	var forked atomic.Bool
+	forked.Store(true)
+	b.Handle("chain_getBlockHash", func(params []json.RawMessage) (any, error) {
+		if forked.Load() {
+			return types.Hash{1}, nil
+		}
+		return b.getBlockHash(params)
+	})
+
+	events := make(chan chain.PoolEvent, 64)
+	if _, err := chain.InitClientWithPool([]string{a.URL, b.URL}, false, poolOptions(events)); err == nil {
+		t.Fatal("client with mismatched genesis is inited")
+	}
+
+	// 初始化时 b 不可用，后台重连时同样拒绝创世区块不同的 b
+	// b is down while initing, and is rejected as well by background reconnecting until its genesis matches
+	b.Stop()
+	client, err := chain.InitClientWithPool([]string{a.URL, b.URL}, false, poolOptions(events))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if err := b.Restart(); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(500 * time.Millisecond)
+	if healthy, total := client.PoolStatus(); healthy != 1 || total != 2 {
+		t.Fatalf("pool status %d/%d", healthy, total)
+	}
+
+	forked.Store(false)
+	if e := waitPoolEvent(t, events, chain.PoolConnRestored); e.URL != b.URL || e.Healthy != 2 {
+		t.Fatalf("restored event %+v", e)
+	}
+}
+
+func TestPoolInitSkipsFailedURL(t *testing.T) {
+	a, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close()
+	b, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	// a 的运行时版本查询失败时使用 b 初始化，a 在后台重连
+	// b inits the client when querying runtime version of a fails, a is reconnected in background
+	a.Handle("state_getRuntimeVersion", func([]json.RawMessage) (any, error) {
+		return nil, &Error{Code: ErrCodeServer, Message: "runtime version unavailable"}
+	})
+
+	events := make(chan chain.PoolEvent, 64)
+	client, err := chain.InitClientWithPool([]string{a.URL, b.URL}, false, poolOptions(events))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if client.Meta == nil || client.Hash != b.Block(0).Hash {
+		t.Fatalf("client of b %x", client.Hash)
+	}
+	if e := waitPoolEvent(t, events, chain.PoolConnRestored); e.URL != a.URL || e.Healthy != 2 {
+		t.Fatalf("restored event %+v", e)
+	}
+}
+
+func TestSubmitResyncNonce(t *testing.T) {
+	c, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	// 交易被接受但一直没有状态通知
+	// extrinsics are accepted but their status is never notified
+	var submitted atomic.Int32
+	c.Handle("author_submitAndWatchExtrinsic", func([]json.RawMessage) (any, error) {
+		submitted.Add(1)
+		return "stalled", nil
+	})
+
+	client, err := chain.InitClient([]string{c.URL}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	signer, err := chain.Sr25519PairFromSecret("//Alice", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	call, err := types.NewCall(client.Meta, "System.remark", []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 等待超时后 nonce 从链上重新同步
+	// nonce is synced from the chain again after waiting times out
+	c.SetNonce(signer.AccountID(), 3)
+	_, err = client.SignAndSubmitWithOptions(context.Background(), &signer, call, chain.SubmitOptions{Timeout: 200 * time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("stalled extrinsic: %v", err)
+	}
+	c.SetNonce(signer.AccountID(), 9)
+	if n, err := client.Nonces.Next(context.Background(), signer.AccountID()); err != nil || n != 9 {
+		t.Fatalf("nonce after timeout %d %v", n, err)
+	}
+	client.Nonces.Release(signer.AccountID(), 9)
+
+	// 订阅出错后 nonce 从链上重新同步
+	// nonce is synced from the chain again after the subscription fails
+	go func() {
+		for submitted.Load() < 2 {
+			time.Sleep(10 * time.Millisecond)
+		}
+		c.DropConnections()
+	}()
+	_, err = client.SignAndSubmitWithOptions(context.Background(), &signer, call, chain.SubmitOptions{Timeout: 5 * time.Second})
+	if err == nil || errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("extrinsic of dropped connection: %v", err)
+	}
+	c.SetNonce(signer.AccountID(), 12)
+	if n, err := client.Nonces.Next(context.Background(), signer.AccountID()); err != nil || n != 12 {
+		t.Fatalf("nonce after subscription error %d %v", n, err)
+	}
+}
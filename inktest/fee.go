@@ -0,0 +1,38 @@
+package inktest
+
+import (
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	chain "github.com/wetee-dao/ink.go"
+	gtypes "github.com/wetee-dao/ink.go/pallet/types"
+)
+
+// 默认交易费用
+// Default fee of every transaction
+var DefaultFee uint64 = 1_000_000
+
+// 设置每笔交易的费用，用于 EstimateFee 和 DryRunParams.EstimateFee
+// Set fee of every transaction, which is returned to EstimateFee and DryRunParams.EstimateFee
+func (c *Chain) SetFee(fee uint64) {
+	partialFee := types.NewU128(*new(big.Int).SetUint64(fee))
+	zero := types.NewU128(*big.NewInt(0))
+
+	c.HandleCall("TransactionPaymentApi_query_info", func([]byte) ([]byte, error) {
+		return codec.Encode(chain.RuntimeDispatchInfo{
+			Weight:     ContractWeight,
+			Class:      gtypes.DispatchClass{IsNormal: true},
+			PartialFee: partialFee,
+		})
+	})
+	c.HandleCall("TransactionPaymentApi_query_fee_details", func([]byte) ([]byte, error) {
+		details := chain.FeeDetails{Tip: zero}
+		details.InclusionFee.Set(chain.InclusionFee{
+			BaseFee:           partialFee,
+			LenFee:            zero,
+			AdjustedWeightFee: zero,
+		})
+		return codec.Encode(details)
+	})
+}
@@ -0,0 +1,184 @@
+package inktest
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/gorilla/websocket"
+)
+
+// 录制的 rpc 交互
+// Recorded rpc interaction
+type Interaction struct {
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+	Result json.RawMessage   `json:"result,omitempty"`
+	Error  *Error            `json:"error,omitempty"`
+	// 返回值为订阅 id 时收到的通知
+	// notifications received when the result is a subscription id
+	Notifications []Notification `json:"notifications,omitempty"`
+}
+
+// 录制的订阅通知
+// Recorded notification of subscription
+type Notification struct {
+	Method string          `json:"method"`
+	Result json.RawMessage `json:"result"`
+}
+
+// 录制文件，交互按请求顺序保存
+// Fixture of recorded interactions in order of requests
+type Fixture struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// 读取录制文件
+// Load fixture from file
+func LoadFixture(path string) (*Fixture, error) {
+	bt, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.New("inktest: read fixture: " + err.Error())
+	}
+	f := &Fixture{}
+	if err := json.Unmarshal(bt, f); err != nil {
+		return nil, errors.New("inktest: decode fixture: " + err.Error())
+	}
+	return f, nil
+}
+
+// 保存录制文件
+// Save fixture to file
+func (f *Fixture) Save(path string) error {
+	bt, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return errors.New("inktest: encode fixture: " + err.Error())
+	}
+	if err := os.WriteFile(path, bt, 0o644); err != nil {
+		return errors.New("inktest: write fixture: " + err.Error())
+	}
+	return nil
+}
+
+// 录制传输层，通过 websocket 连接节点并记录所有请求、返回和订阅通知，
+// 在 PoolOptions.Transport 中使用
+// Recording transport, it connects nodes by websocket and records all requests, results and notifications,
+// it is used as PoolOptions.Transport
+type Recorder struct {
+	mu      sync.Mutex
+	fixture Fixture
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+func (r *Recorder) Dial(url string) (client.Client, error) {
+	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cl, err := dialPipe(url, func(requests *json.Decoder, responses *messageWriter) {
+		defer ws.Close()
+		c := &recordingConn{r: r, pending: map[string]*Interaction{}, subs: map[string]*Interaction{}}
+		go c.forwardResponses(ws, responses)
+		c.forwardRequests(ws, requests)
+	})
+	if err != nil {
+		ws.Close()
+		return nil, err
+	}
+	return cl, nil
+}
+
+// 已录制的交互
+// Recorded interactions
+func (r *Recorder) Fixture() *Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f := &Fixture{Interactions: make([]*Interaction, 0, len(r.fixture.Interactions))}
+	for _, i := range r.fixture.Interactions {
+		copied := *i
+		copied.Notifications = append([]Notification{}, i.Notifications...)
+		f.Interactions = append(f.Interactions, &copied)
+	}
+	return f
+}
+
+// 保存录制文件
+// Save recorded interactions to file
+func (r *Recorder) Save(path string) error {
+	return r.Fixture().Save(path)
+}
+
+// 一个连接上等待返回的请求和订阅
+// Pending requests and subscriptions of a connection
+type recordingConn struct {
+	r       *Recorder
+	pending map[string]*Interaction
+	subs    map[string]*Interaction
+}
+
+// 记录请求并发送给节点
+// Record requests and send them to node
+func (c *recordingConn) forwardRequests(ws *websocket.Conn, requests *json.Decoder) {
+	for {
+		var msg message
+		if err := requests.Decode(&msg); err != nil {
+			return
+		}
+		params, err := splitParams(msg.Params)
+		if err != nil {
+			return
+		}
+
+		c.r.mu.Lock()
+		i := &Interaction{Method: msg.Method, Params: params}
+		c.r.fixture.Interactions = append(c.r.fixture.Interactions, i)
+		c.pending[string(msg.ID)] = i
+		c.r.mu.Unlock()
+
+		if err := ws.WriteJSON(msg); err != nil {
+			return
+		}
+	}
+}
+
+// 记录节点的返回和通知并转发给客户端
+// Record responses and notifications of node and forward them to client
+func (c *recordingConn) forwardResponses(ws *websocket.Conn, responses *messageWriter) {
+	for {
+		var msg message
+		if err := ws.ReadJSON(&msg); err != nil {
+			return
+		}
+
+		c.r.mu.Lock()
+		if msg.Method != "" {
+			var params notificationParams
+			if json.Unmarshal(msg.Params, &params) == nil {
+				if i, ok := c.subs[string(params.Subscription)]; ok {
+					i.Notifications = append(i.Notifications, Notification{Method: msg.Method, Result: params.Result})
+				}
+			}
+		} else if i, ok := c.pending[string(msg.ID)]; ok {
+			delete(c.pending, string(msg.ID))
+			i.Result = msg.Result
+			i.Error = msg.Error
+			// 返回值可能是订阅 id
+			// the result may be a subscription id
+			if len(msg.Result) > 0 && msg.Result[0] == '"' {
+				c.subs[string(msg.Result)] = i
+			}
+		}
+		c.r.mu.Unlock()
+
+		if err := responses.write(msg); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package inktest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+)
+
+// 回放传输层，按方法和参数匹配录制的交互并返回录制的结果，不需要节点，
+// 在 PoolOptions.Transport 中使用
+//
+// 相同的请求按录制顺序各返回一次，每个录制只能使用一次；
+// 未录制或超过录制次数的请求返回错误并记录在 Unexpected 中，未使用的录制由 Unconsumed 返回，Verify 检查两者。
+// sr25519 签名是随机的，录制需要回放的交易时应使用 ed25519 签名
+//
+// Replaying transport, it matches requests with recorded interactions by method and params and returns
+// the recorded results without node, it is used as PoolOptions.Transport.
+//
+// Same requests are answered in recorded order and every recording is used once;
+// requests which are not recorded or are made more times than recorded fail and are kept in Unexpected,
+// recordings which are never used are returned by Unconsumed, and Verify checks both.
+// sr25519 signatures are randomized, so replayed extrinsics should be signed by ed25519
+type Replayer struct {
+	mu         sync.Mutex
+	fixture    *Fixture
+	used       []bool
+	unexpected []Request
+}
+
+func NewReplayer(f *Fixture) *Replayer {
+	return &Replayer{fixture: f, used: make([]bool, len(f.Interactions))}
+}
+
+// 读取录制文件并新建回放传输层
+// Load fixture from file and create replaying transport
+func LoadReplayer(path string) (*Replayer, error) {
+	f, err := LoadFixture(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplayer(f), nil
+}
+
+func (r *Replayer) Dial(url string) (client.Client, error) {
+	return dialPipe(url, r.serve)
+}
+
+// 未匹配到录制的请求
+// Requests which do not match any recorded interaction
+func (r *Replayer) Unexpected() []Request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Request{}, r.unexpected...)
+}
+
+// 未被使用的录制
+// Recorded interactions which are not replayed
+func (r *Replayer) Unconsumed() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unconsumed []*Interaction
+	for index, i := range r.fixture.Interactions {
+		if !r.used[index] {
+			unconsumed = append(unconsumed, i)
+		}
+	}
+	return unconsumed
+}
+
+// 检查所有请求都匹配到录制且所有录制都被使用，测试结束时调用
+// Check that every request matches a recording and every recording is replayed, it is called at the end of test
+func (r *Replayer) Verify() error {
+	var msgs []string
+	for _, req := range r.Unexpected() {
+		msgs = append(msgs, "unexpected call "+req.Method+" "+joinParams(req.Params))
+	}
+	for _, i := range r.Unconsumed() {
+		msgs = append(msgs, "unconsumed recording "+i.Method+" "+joinParams(i.Params))
+	}
+	if len(msgs) > 0 {
+		return errors.New("replay: " + strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+func (r *Replayer) serve(requests *json.Decoder, responses *messageWriter) {
+	for {
+		var msg message
+		if err := requests.Decode(&msg); err != nil {
+			return
+		}
+		if len(msg.ID) == 0 {
+			continue
+		}
+
+		resp := message{Version: "2.0", ID: msg.ID}
+		params, err := splitParams(msg.Params)
+		if err != nil {
+			resp.Error = &Error{Code: ErrCodeInvalidParams, Message: err.Error()}
+			responses.write(resp)
+			continue
+		}
+
+		i := r.match(msg.Method, params)
+		if i == nil {
+			resp.Error = &Error{
+				Code:    ErrCodeMethodNotFound,
+				Message: fmt.Sprintf("replay: unexpected call %s %s", msg.Method, string(msg.Params)),
+			}
+			responses.write(resp)
+			continue
+		}
+
+		resp.Result, resp.Error = i.Result, i.Error
+		if resp.Result == nil && resp.Error == nil {
+			resp.Result = json.RawMessage("null")
+		}
+		if err := responses.write(resp); err != nil {
+			return
+		}
+
+		// 订阅 id 返回后发送录制的通知
+		// recorded notifications are sent after the subscription id
+		for _, n := range i.Notifications {
+			note := message{Version: "2.0", Method: n.Method}
+			note.Params, _ = json.Marshal(notificationParams{Subscription: i.Result, Result: n.Result})
+			if err := responses.write(note); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// 查找第一个未使用的匹配交互，都已使用或没有录制时返回 nil
+// Find the first unused matched interaction, nil is returned when all of them are used or none is recorded
+func (r *Replayer) match(method string, params []json.RawMessage) *Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for index, i := range r.fixture.Interactions {
+		if i.Method != method || !equalParams(i.Params, params) || r.used[index] {
+			continue
+		}
+		r.used[index] = true
+		return i
+	}
+
+	r.unexpected = append(r.unexpected, Request{Method: method, Params: params})
+	return nil
+}
+
+func joinParams(params []json.RawMessage) string {
+	bt, _ := json.Marshal(params)
+	return string(bt)
+}
+
+func equalParams(a, b []json.RawMessage) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		var ca, cb bytes.Buffer
+		if json.Compact(&ca, a[i]) != nil || json.Compact(&cb, b[i]) != nil {
+			return false
+		}
+		if !bytes.Equal(ca.Bytes(), cb.Bytes()) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+package inktest
+
+import (
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	chain "github.com/wetee-dao/ink.go"
+	"github.com/wetee-dao/ink.go/example/contracts/pod"
+)
+
+type scenarioResult struct {
+	cloud     types.H160
+	blockHash types.Hash
+	number    types.BlockNumber
+}
+
+func runScenario(t *testing.T, url string, transport chain.Transport) (*chain.ChainClient, scenarioResult) {
+	opts := chain.DefaultPoolOptions()
+	opts.Transport = transport
+	client, err := chain.InitClientWithPool([]string{url}, false, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ed25519 signatures are deterministic, so the extrinsic can be replayed
+	signer, err := chain.Ed25519PairFromSecret("//Alice", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contract, err := pod.InitPodContract(client, "0x0000000000000000000000000000000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cloud, _, err := contract.DryRunCloud(chain.DefaultParamWithOrigin(signer.AccountID()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipt, err := contract.ExecCloud(chain.ExecParams{Signer: &signer, PayAmount: types.NewU128(*big.NewInt(0))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	number, err := client.GetBlockNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, scenarioResult{cloud: *cloud, blockHash: receipt.BlockHash, number: number}
+}
+
+func TestRecordReplay(t *testing.T) {
+	c, err := NewChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.HandleContract(func(call *ContractCall) ContractReturn {
+		data, _ := codec.Encode(types.H160{0xc1})
+		return ContractReturn{Data: data}
+	})
+
+	recorder := NewRecorder()
+	client, recorded := runScenario(t, c.URL, recorder)
+	client.Close()
+	c.Close()
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	if err := recorder.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	replayer, err := LoadReplayer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, replayed := runScenario(t, "ws://replay", replayer)
+	defer client.Close()
+
+	if replayed != recorded {
+		t.Errorf("replayed %+v, recorded %+v", replayed, recorded)
+	}
+	if err := replayer.Verify(); err != nil {
+		t.Error(err)
+	}
+
+	// 录制只回放一次，重复的请求与未录制的请求一样失败
+	// recordings are replayed once, repeated requests fail like requests which are not recorded
+	_, err = client.GetBlockNumber()
+	if err == nil || !strings.Contains(err.Error(), "unexpected call chain_getHeader") {
+		t.Errorf("repeated call error: %v", err)
+	}
+	_, err = client.GetBlockHash(99)
+	if err == nil || !strings.Contains(err.Error(), "unexpected call chain_getBlockHash") {
+		t.Errorf("unexpected call error: %v", err)
+	}
+	if u := replayer.Unexpected(); len(u) != 2 || u[0].Method != "chain_getHeader" || u[1].Method != "chain_getBlockHash" {
+		t.Errorf("unexpected requests: %+v", u)
+	}
+	if err := replayer.Verify(); err == nil || !strings.Contains(err.Error(), "unexpected call chain_getBlockHash") {
+		t.Errorf("verify extra requests: %v", err)
+	}
+
+	// 未回放的录制被报告
+	// recordings which are not replayed are reported
+	partial, err := LoadReplayer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := chain.DefaultPoolOptions()
+	opts.Transport = partial
+	client, err = chain.InitClientWithPool([]string{"ws://replay"}, false, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if len(partial.Unconsumed()) == 0 || len(partial.Unexpected()) != 0 {
+		t.Errorf("unconsumed %d, unexpected %+v", len(partial.Unconsumed()), partial.Unexpected())
+	}
+	if err := partial.Verify(); err == nil || !strings.Contains(err.Error(), "unconsumed recording") {
+		t.Errorf("verify unconsumed recordings: %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package inktest
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	gtypes "github.com/wetee-dao/ink.go/pallet/types"
+	"github.com/wetee-dao/ink.go/util"
+)
+
+// ReviveApi_call 的参数
+// Args of ReviveApi_call
+type ContractCall struct {
+	Origin              types.AccountID
+	Dest                types.H160
+	Value               types.U128
+	GasLimit            util.Option[types.Weight]
+	StorageDepositLimit util.Option[types.U128]
+	Input               []byte
+}
+
+// 合约消息的 selector
+// Selector of the called message
+func (call *ContractCall) Selector() [4]byte {
+	var selector [4]byte
+	copy(selector[:], call.Input)
+	return selector
+}
+
+// 选择器之后的 SCALE 编码参数
+// SCALE encoded args after the selector
+func (call *ContractCall) Args() []byte {
+	if len(call.Input) < 4 {
+		return nil
+	}
+	return call.Input[4:]
+}
+
+// 合约调用结果，Err 不为 nil 时为 DispatchError
+// Result of contract call, the call fails with DispatchError when Err is not nil
+type ContractReturn struct {
+	Data     []byte
+	Reverted bool
+	Err      *gtypes.DispatchError
+}
+
+// 预执行消耗的资源
+// Weight consumed by every dry run
+var ContractWeight = gtypes.Weight{
+	RefTime:   types.NewUCompactFromUInt(1_000_000_000),
+	ProofSize: types.NewUCompactFromUInt(100_000),
+}
+
+// 设置 ReviveApi_call 的处理函数，用于 DryRunInk 和生成的 Query/DryRun 方法
+// Set handler of ReviveApi_call, which is called by DryRunInk and generated Query/DryRun methods
+func (c *Chain) HandleContract(fn func(call *ContractCall) ContractReturn) {
+	c.HandleCall("ReviveApi_call", func(args []byte) ([]byte, error) {
+		call := &ContractCall{}
+		decoder := scale.NewDecoder(bytes.NewReader(args))
+		for _, v := range []any{&call.Origin, &call.Dest, &call.Value, &call.GasLimit, &call.StorageDepositLimit, &call.Input} {
+			if err := decoder.Decode(v); err != nil {
+				return nil, &Error{Code: ErrCodeInvalidParams, Message: "decode ReviveApi_call args: " + err.Error()}
+			}
+		}
+
+		ret := fn(call)
+		zero := types.NewU128(*big.NewInt(0))
+		result := util.ContractResult{
+			WeightConsumed:    ContractWeight,
+			WeightRequired:    ContractWeight,
+			StorageDeposit:    util.StorageDeposit{IsCharge: true, AsChargeField0: zero},
+			MaxStorageDeposit: util.StorageDeposit{IsCharge: true, AsChargeField0: zero},
+			GasConsumed:       zero,
+		}
+		if ret.Err != nil {
+			result.Result = util.Result[util.ExecReturnValue, gtypes.DispatchError]{IsErr: true, E: *ret.Err}
+		} else {
+			value := util.ExecReturnValue{Data: ret.Data}
+			if ret.Reverted {
+				value.Flags = 1
+			}
+			result.Result = util.Result[util.ExecReturnValue, gtypes.DispatchError]{V: value}
+		}
+
+		bt, err := codec.Encode(result)
+		if err != nil {
+			return nil, errors.New("inktest: encode ContractResult: " + err.Error())
+		}
+		return bt, nil
+	})
+}
+
+// 设置合约存储的读取函数，用于 GetContractStorage 和 QueryInkStorage，
+// 返回 nil 表示存储不存在
+// Set reader of contract storage, which is used by GetContractStorage and QueryInkStorage,
+// nil means the storage does not exist
+func (c *Chain) HandleContractStorage(fn func(address types.H160, key []byte) []byte) {
+	handler := func(varKey bool) CallHandler {
+		return func(args []byte) ([]byte, error) {
+			var address types.H160
+			var key []byte
+
+			decoder := scale.NewDecoder(bytes.NewReader(args))
+			err := decoder.Decode(&address)
+			if err == nil && varKey {
+				err = decoder.Decode(&key)
+			} else if err == nil {
+				var fixed [32]byte
+				err = decoder.Decode(&fixed)
+				key = fixed[:]
+			}
+			if err != nil {
+				return nil, &Error{Code: ErrCodeInvalidParams, Message: "decode ReviveApi_get_storage args: " + err.Error()}
+			}
+
+			var value util.Option[[]byte]
+			if v := fn(address, key); v != nil {
+				value.Set(v)
+			}
+			return codec.Encode(util.GetStorageResult{V: value})
+		}
+	}
+
+	c.HandleCall("ReviveApi_get_storage", handler(false))
+	c.HandleCall("ReviveApi_get_storage_var_key", handler(true))
+}
@@ -0,0 +1,181 @@
+package inktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// JSON-RPC 错误码
+// Codes of JSON-RPC errors
+const (
+	ErrCodeMethodNotFound = -32601
+	ErrCodeInvalidParams  = -32602
+	ErrCodeServer         = -32000
+)
+
+// JSON-RPC 错误，处理函数返回其他错误时使用 ErrCodeServer
+// JSON-RPC error, ErrCodeServer is used when handlers return other errors
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("inktest: %s (code %d)", e.Message, e.Code)
+}
+
+// rpc 方法处理函数，返回值被编码为 JSON
+// Handler of rpc method, the result is encoded to JSON
+type Handler func(params []json.RawMessage) (any, error)
+
+// 收到的 rpc 请求
+// Received rpc request
+type Request struct {
+	Method string
+	Params []json.RawMessage
+}
+
+type request struct {
+	Version string            `json:"jsonrpc"`
+	ID      json.RawMessage   `json:"id"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+type response struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+type notification struct {
+	Version string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  struct {
+		Subscription string `json:"subscription"`
+		Result       any    `json:"result"`
+	} `json:"params"`
+}
+
+// websocket 连接，写入需要加锁
+// Websocket connection, writes are serialized
+type conn struct {
+	ws   *websocket.Conn
+	mu   sync.Mutex
+	subs map[string]*subscription
+}
+
+func (c *conn) write(v any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ws.WriteJSON(v)
+}
+
+// 订阅，通知方法为 namespace_notification
+// Subscription, notifications are sent with method namespace_notification
+type subscription struct {
+	id     string
+	method string
+	conn   *conn
+}
+
+func (s *subscription) notify(result any) {
+	msg := notification{Version: "2.0", Method: s.method}
+	msg.Params.Subscription = s.id
+	msg.Params.Result = result
+	s.conn.write(msg)
+}
+
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+func (c *Chain) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ws, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	cn := &conn{ws: ws, subs: map[string]*subscription{}}
+	defer c.dropConn(cn)
+
+	for {
+		var req request
+		if err := ws.ReadJSON(&req); err != nil {
+			return
+		}
+
+		resp := response{Version: "2.0", ID: req.ID}
+		result, after, err := c.handle(cn, req.Method, req.Params)
+		if err == nil {
+			resp.Result, err = json.Marshal(result)
+		}
+		if err != nil {
+			rpcErr, ok := err.(*Error)
+			if !ok {
+				rpcErr = &Error{Code: ErrCodeServer, Message: err.Error()}
+			}
+			resp.Result = nil
+			resp.Error = rpcErr
+		}
+
+		if err := cn.write(resp); err != nil {
+			return
+		}
+		// 订阅的通知在订阅 id 返回后发送
+		// notifications of subscription are sent after its id is returned
+		if after != nil && resp.Error == nil {
+			go after()
+		}
+	}
+}
+
+// 新建订阅，id 在 Chain 内唯一
+// Create subscription, ids are unique in Chain
+func (c *Chain) subscribe(cn *conn, method string) *subscription {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nextSub++
+	sub := &subscription{id: strconv.FormatUint(c.nextSub, 10), method: method, conn: cn}
+	cn.subs[sub.id] = sub
+	return sub
+}
+
+func (c *Chain) unsubscribe(cn *conn, params []json.RawMessage) (any, error) {
+	var id string
+	if err := param(params, 0, &id); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := cn.subs[id]
+	delete(cn.subs, id)
+	return ok, nil
+}
+
+func (c *Chain) dropConn(cn *conn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cn.subs = map[string]*subscription{}
+	delete(c.conns, cn)
+	cn.ws.Close()
+}
+
+// 解码第 i 个参数，参数缺失或为 null 时保持 v 不变
+// Decode the ith param into v, v is unchanged when the param is missing or null
+func param(params []json.RawMessage, i int, v any) error {
+	if i >= len(params) || string(params[i]) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(params[i], v); err != nil {
+		return &Error{Code: ErrCodeInvalidParams, Message: fmt.Sprintf("param %d: %s", i, err.Error())}
+	}
+	return nil
+}
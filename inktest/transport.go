@@ -0,0 +1,93 @@
+package inktest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+)
+
+// JSON-RPC 消息，请求、返回和通知共用
+// JSON-RPC message, shared by requests, responses and notifications
+type message struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// 订阅通知的参数
+// Params of subscription notification
+type notificationParams struct {
+	Subscription json.RawMessage `json:"subscription"`
+	Result       json.RawMessage `json:"result"`
+}
+
+// 写入客户端的消息流
+// Stream of messages written to client
+type messageWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func (w *messageWriter) write(msg any) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.enc.Encode(msg)
+}
+
+// 通过管道连接的 rpc 客户端，serve 从 requests 读取客户端的请求并通过 responses 返回
+// Rpc client connected by pipes, serve reads requests of client from requests and replies to responses
+type pipeClient struct {
+	*gethrpc.Client
+	url     string
+	closers []io.Closer
+}
+
+func dialPipe(url string, serve func(requests *json.Decoder, responses *messageWriter)) (client.Client, error) {
+	reqReader, reqWriter := io.Pipe()
+	respReader, respWriter := io.Pipe()
+
+	cl, err := gethrpc.DialIO(context.Background(), respReader, reqWriter)
+	if err != nil {
+		return nil, err
+	}
+	go serve(json.NewDecoder(reqReader), &messageWriter{enc: json.NewEncoder(respWriter)})
+
+	return &pipeClient{
+		Client:  cl,
+		url:     url,
+		closers: []io.Closer{reqReader, reqWriter, respReader, respWriter},
+	}, nil
+}
+
+func (c *pipeClient) URL() string {
+	return c.url
+}
+
+// 先关闭管道，gethrpc 客户端在读取结束后才能关闭
+// Pipes are closed first, gethrpc client is closed after its reading ends
+func (c *pipeClient) Close() {
+	for _, closer := range c.closers {
+		closer.Close()
+	}
+	c.Client.Close()
+}
+
+// 解析请求参数，无参数时为空
+// Split params of request, it is empty when there are no params
+func splitParams(raw json.RawMessage) ([]json.RawMessage, error) {
+	params := make([]json.RawMessage, 0)
+	if len(raw) == 0 || string(raw) == "null" {
+		return params, nil
+	}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
@@ -0,0 +1,193 @@
+package multisig
+
+import types "github.com/wetee-dao/ink.go/pallet/types"
+
+// Immediately dispatch a multi-signature call using a single approval from the caller.
+//
+// The dispatch origin for this call must be _Signed_.
+//
+// - `other_signatories`: The accounts (other than the sender) who are part of the
+// multi-signature, but do not participate in the approval process.
+// - `call`: The call to be executed.
+//
+// Result is equivalent to the dispatched result.
+//
+// ## Complexity
+// O(Z + C) where Z is the length of the call and C its execution weight.
+func MakeAsMultiThreshold1Call(otherSignatories0 [][32]byte, call1 types.RuntimeCall) types.RuntimeCall {
+	return types.RuntimeCall{
+		IsMultisig: true,
+		AsMultisigField0: &types.PalletMultisigPalletCall{
+			IsAsMultiThreshold1:                  true,
+			AsAsMultiThreshold1OtherSignatories0: otherSignatories0,
+			AsAsMultiThreshold1Call1:             &call1,
+		},
+	}
+}
+
+// Register approval for a dispatch to be made from a deterministic composite account if
+// approved by a total of `threshold - 1` of `other_signatories`.
+//
+// **If the approval threshold is met (including the sender's approval), this will
+// immediately execute the call.** This is the only way to execute a multisig call -
+// `approve_as_multi` will never trigger execution.
+//
+// Payment: `DepositBase` will be reserved if this is the first approval, plus
+// `threshold` times `DepositFactor`. It is returned once this dispatch happens or
+// is cancelled.
+//
+// The dispatch origin for this call must be _Signed_.
+//
+// - `threshold`: The total number of approvals for this dispatch before it is executed.
+// - `other_signatories`: The accounts (other than the sender) who can approve this
+// dispatch. May not be empty.
+// - `maybe_timepoint`: If this is the first approval, then this must be `None`. If it is
+// not the first approval, then it must be `Some`, with the timepoint (block number and
+// transaction index) of the first approval transaction.
+// - `call`: The call to be executed.
+//
+// NOTE: For intermediate approvals (not the final approval), you should generally use
+// `approve_as_multi` instead, since it only requires a hash of the call and is more
+// efficient.
+//
+// Result is equivalent to the dispatched result if `threshold` is exactly `1`. Otherwise
+// on success, result is `Ok` and the result from the interior call, if it was executed,
+// may be found in the deposited `MultisigExecuted` event.
+//
+// ## Complexity
+//   - `O(S + Z + Call)`.
+//   - Up to one balance-reserve or unreserve operation.
+//   - One passthrough operation, one insert, both `O(S)` where `S` is the number of
+//     signatories. `S` is capped by `MaxSignatories`, with weight being proportional.
+//   - One call encode & hash, both of complexity `O(Z)` where `Z` is tx-len.
+//   - One encode & hash, both of complexity `O(S)`.
+//   - Up to one binary search and insert (`O(logS + S)`).
+//   - I/O: 1 read `O(S)`, up to 1 mutate `O(S)`. Up to one remove.
+//   - One event.
+//   - The weight of the `call`.
+//   - Storage: inserts one item, value size bounded by `MaxSignatories`, with a deposit
+//     taken for its lifetime of `DepositBase + threshold * DepositFactor`.
+func MakeAsMultiCall(threshold0 uint16, otherSignatories1 [][32]byte, maybeTimepoint2 types.OptionTTimepoint, call3 types.RuntimeCall, maxWeight4 types.Weight) types.RuntimeCall {
+	return types.RuntimeCall{
+		IsMultisig: true,
+		AsMultisigField0: &types.PalletMultisigPalletCall{
+			IsAsMulti:                  true,
+			AsAsMultiThreshold0:        threshold0,
+			AsAsMultiOtherSignatories1: otherSignatories1,
+			AsAsMultiMaybeTimepoint2:   maybeTimepoint2,
+			AsAsMultiCall3:             &call3,
+			AsAsMultiMaxWeight4:        maxWeight4,
+		},
+	}
+}
+
+// Register approval for a dispatch to be made from a deterministic composite account if
+// approved by a total of `threshold - 1` of `other_signatories`.
+//
+// **This function will NEVER execute the call, even if the approval threshold is
+// reached.** It only registers approval. To actually execute the call, `as_multi` must
+// be called with the full call data by any of the signatories.
+//
+// This function is more efficient than `as_multi` for intermediate approvals since it
+// only requires the call hash, not the full call data.
+//
+// Payment: `DepositBase` will be reserved if this is the first approval, plus
+// `threshold` times `DepositFactor`. It is returned once this dispatch happens or
+// is cancelled.
+//
+// The dispatch origin for this call must be _Signed_.
+//
+// - `threshold`: The total number of approvals for this dispatch before it is executed.
+// - `other_signatories`: The accounts (other than the sender) who can approve this
+// dispatch. May not be empty.
+// - `maybe_timepoint`: If this is the first approval, then this must be `None`. If it is
+// not the first approval, then it must be `Some`, with the timepoint (block number and
+// transaction index) of the first approval transaction.
+// - `call_hash`: The hash of the call to be executed.
+//
+// NOTE: To execute the call after approvals are gathered, any signatory must call
+// `as_multi` with the full call data. This function cannot execute the call.
+//
+// ## Complexity
+//   - `O(S)`.
+//   - Up to one balance-reserve or unreserve operation.
+//   - One passthrough operation, one insert, both `O(S)` where `S` is the number of
+//     signatories. `S` is capped by `MaxSignatories`, with weight being proportional.
+//   - One encode & hash, both of complexity `O(S)`.
+//   - Up to one binary search and insert (`O(logS + S)`).
+//   - I/O: 1 read `O(S)`, up to 1 mutate `O(S)`. Up to one remove.
+//   - One event.
+//   - Storage: inserts one item, value size bounded by `MaxSignatories`, with a deposit
+//     taken for its lifetime of `DepositBase + threshold * DepositFactor`.
+func MakeApproveAsMultiCall(threshold0 uint16, otherSignatories1 [][32]byte, maybeTimepoint2 types.OptionTTimepoint, callHash3 [32]byte, maxWeight4 types.Weight) types.RuntimeCall {
+	return types.RuntimeCall{
+		IsMultisig: true,
+		AsMultisigField0: &types.PalletMultisigPalletCall{
+			IsApproveAsMulti:                  true,
+			AsApproveAsMultiThreshold0:        threshold0,
+			AsApproveAsMultiOtherSignatories1: otherSignatories1,
+			AsApproveAsMultiMaybeTimepoint2:   maybeTimepoint2,
+			AsApproveAsMultiCallHash3:         callHash3,
+			AsApproveAsMultiMaxWeight4:        maxWeight4,
+		},
+	}
+}
+
+// Cancel a pre-existing, on-going multisig transaction. Any deposit reserved previously
+// for this operation will be unreserved on success.
+//
+// The dispatch origin for this call must be _Signed_.
+//
+// - `threshold`: The total number of approvals for this dispatch before it is executed.
+// - `other_signatories`: The accounts (other than the sender) who can approve this
+// dispatch. May not be empty.
+// - `timepoint`: The timepoint (block number and transaction index) of the first approval
+// transaction for this dispatch.
+// - `call_hash`: The hash of the call to be executed.
+//
+// ## Complexity
+//   - `O(S)`.
+//   - Up to one balance-reserve or unreserve operation.
+//   - One passthrough operation, one insert, both `O(S)` where `S` is the number of
+//     signatories. `S` is capped by `MaxSignatories`, with weight being proportional.
+//   - One encode & hash, both of complexity `O(S)`.
+//   - One event.
+//   - I/O: 1 read `O(S)`, one remove.
+//   - Storage: removes one item.
+func MakeCancelAsMultiCall(threshold0 uint16, otherSignatories1 [][32]byte, timepoint2 types.Timepoint, callHash3 [32]byte) types.RuntimeCall {
+	return types.RuntimeCall{
+		IsMultisig: true,
+		AsMultisigField0: &types.PalletMultisigPalletCall{
+			IsCancelAsMulti:                  true,
+			AsCancelAsMultiThreshold0:        threshold0,
+			AsCancelAsMultiOtherSignatories1: otherSignatories1,
+			AsCancelAsMultiTimepoint2:        timepoint2,
+			AsCancelAsMultiCallHash3:         callHash3,
+		},
+	}
+}
+
+// Poke the deposit reserved for an existing multisig operation.
+//
+// The dispatch origin for this call must be _Signed_ and must be the original depositor of
+// the multisig operation.
+//
+// The transaction fee is waived if the deposit amount has changed.
+//
+//   - `threshold`: The total number of approvals needed for this multisig.
+//   - `other_signatories`: The accounts (other than the sender) who are part of the
+//     multisig.
+//   - `call_hash`: The hash of the call this deposit is reserved for.
+//
+// Emits `DepositPoked` if successful.
+func MakePokeDepositCall(threshold0 uint16, otherSignatories1 [][32]byte, callHash2 [32]byte) types.RuntimeCall {
+	return types.RuntimeCall{
+		IsMultisig: true,
+		AsMultisigField0: &types.PalletMultisigPalletCall{
+			IsPokeDeposit:                  true,
+			AsPokeDepositThreshold0:        threshold0,
+			AsPokeDepositOtherSignatories1: otherSignatories1,
+			AsPokeDepositCallHash2:         callHash2,
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package multisig
+
+import (
+	state "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	codec "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	types1 "github.com/wetee-dao/ink.go/pallet/types"
+)
+
+// Make a storage key for Multisigs
+//
+//	The set of open multisig operations.
+func MakeMultisigsStorageKey(byteArray320 [32]byte, byteArray321 [32]byte) (types.StorageKey, error) {
+	byteArgs := [][]byte{}
+	encBytes := []byte{}
+	var err error
+	encBytes, err = codec.Encode(byteArray320)
+	if err != nil {
+		return nil, err
+	}
+	byteArgs = append(byteArgs, encBytes)
+	encBytes, err = codec.Encode(byteArray321)
+	if err != nil {
+		return nil, err
+	}
+	byteArgs = append(byteArgs, encBytes)
+	return types.CreateStorageKey(&types1.Meta, "Multisig", "Multisigs", byteArgs...)
+}
+func GetMultisigs(state state.State, bhash types.Hash, byteArray320 [32]byte, byteArray321 [32]byte) (ret types1.Multisig, isSome bool, err error) {
+	key, err := MakeMultisigsStorageKey(byteArray320, byteArray321)
+	if err != nil {
+		return
+	}
+	isSome, err = state.GetStorage(key, &ret, bhash)
+	if err != nil {
+		return
+	}
+	return
+}
+func GetMultisigsLatest(state state.State, byteArray320 [32]byte, byteArray321 [32]byte) (ret types1.Multisig, isSome bool, err error) {
+	key, err := MakeMultisigsStorageKey(byteArray320, byteArray321)
+	if err != nil {
+		return
+	}
+	isSome, err = state.GetStorageLatest(key, &ret)
+	if err != nil {
+		return
+	}
+	return
+}
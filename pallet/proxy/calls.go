@@ -0,0 +1,251 @@
+package proxy
+
+import (
+	types1 "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	types "github.com/wetee-dao/ink.go/pallet/types"
+)
+
+// Dispatch the given `call` from an account that the sender is authorised for through
+// `add_proxy`.
+//
+// The dispatch origin for this call must be _Signed_.
+//
+// Parameters:
+// - `real`: The account that the proxy will make a call on behalf of.
+// - `force_proxy_type`: Specify the exact proxy type to be used and checked for this call.
+// - `call`: The call to be made by the `real` account.
+func MakeProxyCall(real0 types.MultiAddress, forceProxyType1 types.OptionTProxyType, call2 types.RuntimeCall) types.RuntimeCall {
+	return types.RuntimeCall{
+		IsProxy: true,
+		AsProxyField0: &types.PalletProxyPalletCall{
+			IsProxy:                true,
+			AsProxyReal0:           real0,
+			AsProxyForceProxyType1: forceProxyType1,
+			AsProxyCall2:           &call2,
+		},
+	}
+}
+
+// Register a proxy account for the sender that is able to make calls on its behalf.
+//
+// The dispatch origin for this call must be _Signed_.
+//
+// Parameters:
+// - `proxy`: The account that the `caller` would like to make a proxy.
+// - `proxy_type`: The permissions allowed for this proxy account.
+// - `delay`: The announcement period required of the initial proxy. Will generally be
+// zero.
+func MakeAddProxyCall(delegate0 types.MultiAddress, proxyType1 types.ProxyType, delay2 uint32) types.RuntimeCall {
+	return types.RuntimeCall{
+		IsProxy: true,
+		AsProxyField0: &types.PalletProxyPalletCall{
+			IsAddProxy:           true,
+			AsAddProxyDelegate0:  delegate0,
+			AsAddProxyProxyType1: proxyType1,
+			AsAddProxyDelay2:     delay2,
+		},
+	}
+}
+
+// Unregister a proxy account for the sender.
+//
+// The dispatch origin for this call must be _Signed_.
+//
+// Parameters:
+// - `proxy`: The account that the `caller` would like to remove as a proxy.
+// - `proxy_type`: The permissions currently enabled for the removed proxy account.
+func MakeRemoveProxyCall(delegate0 types.MultiAddress, proxyType1 types.ProxyType, delay2 uint32) types.RuntimeCall {
+	return types.RuntimeCall{
+		IsProxy: true,
+		AsProxyField0: &types.PalletProxyPalletCall{
+			IsRemoveProxy:           true,
+			AsRemoveProxyDelegate0:  delegate0,
+			AsRemoveProxyProxyType1: proxyType1,
+			AsRemoveProxyDelay2:     delay2,
+		},
+	}
+}
+
+// Unregister all proxy accounts for the sender.
+//
+// The dispatch origin for this call must be _Signed_.
+//
+// WARNING: This may be called on accounts created by `create_pure`, however if done, then
+// the unreserved fees will be inaccessible. **All access to this account will be lost.**
+func MakeRemoveProxiesCall() types.RuntimeCall {
+	return types.RuntimeCall{
+		IsProxy: true,
+		AsProxyField0: &types.PalletProxyPalletCall{
+			IsRemoveProxies: true,
+		},
+	}
+}
+
+// Spawn a fresh new account that is guaranteed to be otherwise inaccessible, and
+// initialize it with a proxy of `proxy_type` for `origin` sender.
+//
+// Requires a `Signed` origin.
+//
+// - `proxy_type`: The type of the proxy that the sender will be registered as over the
+// new account. This will almost always be the most permissive `ProxyType` possible to
+// allow for maximum flexibility.
+// - `index`: A disambiguation index, in case this is called multiple times in the same
+// transaction (e.g. with `utility::batch`). Unless you're using `batch` you probably just
+// want to use `0`.
+// - `delay`: The announcement period required of the initial proxy. Will generally be
+// zero.
+//
+// Fails with `Duplicate` if this has already been called in this transaction, from the
+// same sender, with the same parameters.
+//
+// Fails if there are insufficient funds to pay for deposit.
+func MakeCreatePureCall(proxyType0 types.ProxyType, delay1 uint32, index2 uint16) types.RuntimeCall {
+	return types.RuntimeCall{
+		IsProxy: true,
+		AsProxyField0: &types.PalletProxyPalletCall{
+			IsCreatePure:           true,
+			AsCreatePureProxyType0: proxyType0,
+			AsCreatePureDelay1:     delay1,
+			AsCreatePureIndex2:     index2,
+		},
+	}
+}
+
+// Removes a previously spawned pure proxy.
+//
+// WARNING: **All access to this account will be lost.** Any funds held in it will be
+// inaccessible.
+//
+// Requires a `Signed` origin, and the sender account must have been created by a call to
+// `create_pure` with corresponding parameters.
+//
+// - `spawner`: The account that originally called `create_pure` to create this account.
+// - `index`: The disambiguation index originally passed to `create_pure`. Probably `0`.
+// - `proxy_type`: The proxy type originally passed to `create_pure`.
+// - `height`: The height of the chain when the call to `create_pure` was processed.
+// - `ext_index`: The extrinsic index in which the call to `create_pure` was processed.
+//
+// Fails with `NoPermission` in case the caller is not a previously created pure
+// account whose `create_pure` call has corresponding parameters.
+func MakeKillPureCall(spawner0 types.MultiAddress, proxyType1 types.ProxyType, index2 uint16, height3 types1.UCompact, extIndex4 types1.UCompact) types.RuntimeCall {
+	return types.RuntimeCall{
+		IsProxy: true,
+		AsProxyField0: &types.PalletProxyPalletCall{
+			IsKillPure:           true,
+			AsKillPureSpawner0:   spawner0,
+			AsKillPureProxyType1: proxyType1,
+			AsKillPureIndex2:     index2,
+			AsKillPureHeight3:    height3,
+			AsKillPureExtIndex4:  extIndex4,
+		},
+	}
+}
+
+// Publish the hash of a proxy-call that will be made in the future.
+//
+// This must be called some number of blocks before the corresponding `proxy` is attempted
+// if the delay associated with the proxy relationship is greater than zero.
+//
+// No more than `MaxPending` announcements may be made at any one time.
+//
+// This will take a deposit of `AnnouncementDepositFactor` as well as
+// `AnnouncementDepositBase` if there are no other pending announcements.
+//
+// The dispatch origin for this call must be _Signed_ and a proxy of `real`.
+//
+// Parameters:
+// - `real`: The account that the proxy will make a call on behalf of.
+// - `call_hash`: The hash of the call to be made by the `real` account.
+func MakeAnnounceCall(real0 types.MultiAddress, callHash1 [32]byte) types.RuntimeCall {
+	return types.RuntimeCall{
+		IsProxy: true,
+		AsProxyField0: &types.PalletProxyPalletCall{
+			IsAnnounce:          true,
+			AsAnnounceReal0:     real0,
+			AsAnnounceCallHash1: callHash1,
+		},
+	}
+}
+
+// Remove a given announcement.
+//
+// May be called by a proxy account to remove a call they previously announced and return
+// the deposit.
+//
+// The dispatch origin for this call must be _Signed_.
+//
+// Parameters:
+// - `real`: The account that the proxy will make a call on behalf of.
+// - `call_hash`: The hash of the call to be made by the `real` account.
+func MakeRemoveAnnouncementCall(real0 types.MultiAddress, callHash1 [32]byte) types.RuntimeCall {
+	return types.RuntimeCall{
+		IsProxy: true,
+		AsProxyField0: &types.PalletProxyPalletCall{
+			IsRemoveAnnouncement:          true,
+			AsRemoveAnnouncementReal0:     real0,
+			AsRemoveAnnouncementCallHash1: callHash1,
+		},
+	}
+}
+
+// Remove the given announcement of a delegate.
+//
+// May be called by a target (proxied) account to remove a call that one of their delegates
+// (`delegate`) has announced they want to execute. The deposit is returned.
+//
+// The dispatch origin for this call must be _Signed_.
+//
+// Parameters:
+// - `delegate`: The account that previously announced the call.
+// - `call_hash`: The hash of the call to be made.
+func MakeRejectAnnouncementCall(delegate0 types.MultiAddress, callHash1 [32]byte) types.RuntimeCall {
+	return types.RuntimeCall{
+		IsProxy: true,
+		AsProxyField0: &types.PalletProxyPalletCall{
+			IsRejectAnnouncement:          true,
+			AsRejectAnnouncementDelegate0: delegate0,
+			AsRejectAnnouncementCallHash1: callHash1,
+		},
+	}
+}
+
+// Dispatch the given `call` from an account that the sender is authorized for through
+// `add_proxy`.
+//
+// Removes any corresponding announcement(s).
+//
+// The dispatch origin for this call must be _Signed_.
+//
+// Parameters:
+// - `real`: The account that the proxy will make a call on behalf of.
+// - `force_proxy_type`: Specify the exact proxy type to be used and checked for this call.
+// - `call`: The call to be made by the `real` account.
+func MakeProxyAnnouncedCall(delegate0 types.MultiAddress, real1 types.MultiAddress, forceProxyType2 types.OptionTProxyType, call3 types.RuntimeCall) types.RuntimeCall {
+	return types.RuntimeCall{
+		IsProxy: true,
+		AsProxyField0: &types.PalletProxyPalletCall{
+			IsProxyAnnounced:                true,
+			AsProxyAnnouncedDelegate0:       delegate0,
+			AsProxyAnnouncedReal1:           real1,
+			AsProxyAnnouncedForceProxyType2: forceProxyType2,
+			AsProxyAnnouncedCall3:           &call3,
+		},
+	}
+}
+
+// Poke / Adjust deposits made for proxies and announcements based on current values.
+// This can be used by accounts to possibly lower their locked amount.
+//
+// The dispatch origin for this call must be _Signed_.
+//
+// The transaction fee is waived if the deposit amount has changed.
+//
+// Emits `DepositPoked` if successful.
+func MakePokeDepositCall() types.RuntimeCall {
+	return types.RuntimeCall{
+		IsProxy: true,
+		AsProxyField0: &types.PalletProxyPalletCall{
+			IsPokeDeposit: true,
+		},
+	}
+}
@@ -0,0 +1,118 @@
+package proxy
+
+import (
+	"encoding/hex"
+	state "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	codec "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	types1 "github.com/wetee-dao/ink.go/pallet/types"
+)
+
+// Make a storage key for Proxies
+//
+//	The set of account proxies. Maps the account which has delegated to the accounts
+//	which are being delegated to, together with the amount held on deposit.
+func MakeProxiesStorageKey(byteArray320 [32]byte) (types.StorageKey, error) {
+	byteArgs := [][]byte{}
+	encBytes := []byte{}
+	var err error
+	encBytes, err = codec.Encode(byteArray320)
+	if err != nil {
+		return nil, err
+	}
+	byteArgs = append(byteArgs, encBytes)
+	return types.CreateStorageKey(&types1.Meta, "Proxy", "Proxies", byteArgs...)
+}
+
+var ProxiesResultDefaultBytes, _ = hex.DecodeString("0000000000000000000000000000000000")
+
+func GetProxies(state state.State, bhash types.Hash, byteArray320 [32]byte) (ret types1.TupleOfProxyDefinitionSliceU128, err error) {
+	key, err := MakeProxiesStorageKey(byteArray320)
+	if err != nil {
+		return
+	}
+	var isSome bool
+	isSome, err = state.GetStorage(key, &ret, bhash)
+	if err != nil {
+		return
+	}
+	if !isSome {
+		err = codec.Decode(ProxiesResultDefaultBytes, &ret)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+func GetProxiesLatest(state state.State, byteArray320 [32]byte) (ret types1.TupleOfProxyDefinitionSliceU128, err error) {
+	key, err := MakeProxiesStorageKey(byteArray320)
+	if err != nil {
+		return
+	}
+	var isSome bool
+	isSome, err = state.GetStorageLatest(key, &ret)
+	if err != nil {
+		return
+	}
+	if !isSome {
+		err = codec.Decode(ProxiesResultDefaultBytes, &ret)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Make a storage key for Announcements
+//
+//	The announcements made by the proxy (key).
+func MakeAnnouncementsStorageKey(byteArray320 [32]byte) (types.StorageKey, error) {
+	byteArgs := [][]byte{}
+	encBytes := []byte{}
+	var err error
+	encBytes, err = codec.Encode(byteArray320)
+	if err != nil {
+		return nil, err
+	}
+	byteArgs = append(byteArgs, encBytes)
+	return types.CreateStorageKey(&types1.Meta, "Proxy", "Announcements", byteArgs...)
+}
+
+var AnnouncementsResultDefaultBytes, _ = hex.DecodeString("0000000000000000000000000000000000")
+
+func GetAnnouncements(state state.State, bhash types.Hash, byteArray320 [32]byte) (ret types1.TupleOfAnnouncementSliceU128, err error) {
+	key, err := MakeAnnouncementsStorageKey(byteArray320)
+	if err != nil {
+		return
+	}
+	var isSome bool
+	isSome, err = state.GetStorage(key, &ret, bhash)
+	if err != nil {
+		return
+	}
+	if !isSome {
+		err = codec.Decode(AnnouncementsResultDefaultBytes, &ret)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+func GetAnnouncementsLatest(state state.State, byteArray320 [32]byte) (ret types1.TupleOfAnnouncementSliceU128, err error) {
+	key, err := MakeAnnouncementsStorageKey(byteArray320)
+	if err != nil {
+		return
+	}
+	var isSome bool
+	isSome, err = state.GetStorageLatest(key, &ret)
+	if err != nil {
+		return
+	}
+	if !isSome {
+		err = codec.Decode(AnnouncementsResultDefaultBytes, &ret)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
@@ -12,6 +12,7 @@ type ContractCallBox struct {
 	Name         string
 	Funcs        []Func
 	Constructors []Constructor
+	Events       []Event
 }
 
 type Func struct {
@@ -54,25 +55,53 @@ func callGen(callData ContractCallBox) []byte {
 
 var callTemp = `package {{.PackageName}}
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	chain "github.com/wetee-dao/ink.go"
 	"github.com/wetee-dao/ink.go/util"
 )
 
 {{ range .Constructors }}
-func Deploy{{$.Name}}With{{CamelCase .FuncName}}({{.ArgTypeStr}} __ink_params chain.DeployParams) (*types.H160, error) {
-	return __ink_params.Client.DeployContract(
-		__ink_params.Code, __ink_params.Signer, types.NewU128(*big.NewInt(0)),
+func Deploy{{$.Name}}With{{CamelCase .FuncName}}({{.ArgTypeStr}} __ink_params chain.DeployParams) (*types.H160, *chain.TxReceipt, error) {
+	return Deploy{{$.Name}}With{{CamelCase .FuncName}}Context(context.Background(), {{.ArgStr}}__ink_params)
+}
+
+func Deploy{{$.Name}}With{{CamelCase .FuncName}}Context(ctx context.Context, {{.ArgTypeStr}} __ink_params chain.DeployParams) (*types.H160, *chain.TxReceipt, error) {
+	return __ink_params.Client.DeployContractContext(
+		ctx, __ink_params.Code, __ink_params.Signer, types.NewU128(*big.NewInt(0)),
 		util.InkContractInput{
 			Selector: "{{.Selector}}",
 			Args: []any{ {{.ArgStr}} },
 		},
 		__ink_params.Salt,
+		__ink_params.SubmitOptions,
 	)
 }
 {{ end }}
 
-func Init{{.Name}}Contract(client *chain.ChainClient, address string) (*{{.Name}}, error) {
+// {{.Name}}API is the interface of {{.Name}} contract, it can be mocked in tests
+type {{.Name}}API interface {
+	chain.Ink
+{{- range .Funcs }}
+	{{if .IsMut}}DryRun{{else}}Query{{end}}{{CamelCase .FuncName}}({{.ArgTypeStr}} __ink_params chain.DryRunParams) (*{{.Return}}, *chain.DryRunReturnGas, error)
+	{{if .IsMut}}DryRun{{else}}Query{{end}}{{CamelCase .FuncName}}Context(ctx context.Context, {{.ArgTypeStr}} __ink_params chain.DryRunParams) (*{{.Return}}, *chain.DryRunReturnGas, error)
+{{- if .IsMut}}
+	Exec{{CamelCase .FuncName}}({{.ArgTypeStr}} __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	Exec{{CamelCase .FuncName}}Context(ctx context.Context, {{.ArgTypeStr}} __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOf{{CamelCase .FuncName}}({{.ArgTypeStr}} __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOf{{CamelCase .FuncName}}Context(ctx context.Context, {{.ArgTypeStr}} __ink_params chain.DryRunParams) (*types.Call, error)
+{{- end }}
+{{- end }}
+{{- range .Events }}
+	Watch{{.StructName}}() (*chain.EventSubscription[{{.StructName}}], error)
+	Watch{{.StructName}}Context(ctx context.Context) (*chain.EventSubscription[{{.StructName}}], error)
+{{- end }}
+}
+
+var _ {{.Name}}API = (*{{.Name}})(nil)
+
+func Init{{.Name}}Contract(client chain.InkClient, address string) (*{{.Name}}, error) {
 	contractAddress, err := util.HexToH160(address)
 	if err != nil {
 		return nil, err
@@ -84,11 +113,11 @@ func Init{{.Name}}Contract(client *chain.ChainClient, address string) (*{{.Name}
 }
 
 type {{.Name}} struct {
-	ChainClient *chain.ChainClient
+	ChainClient chain.InkClient
 	Address     types.H160
 }
 
-func (c *{{.Name}}) Client() *chain.ChainClient {
+func (c *{{.Name}}) Client() chain.InkClient {
 	return c.ChainClient
 }
 
@@ -100,11 +129,18 @@ func (c *{{.Name}}) ContractAddress() types.H160 {
 func (c *{{$.Name}}) {{if .IsMut}}DryRun{{else}}Query{{end}}{{CamelCase .FuncName}}(
 	{{.ArgTypeStr}} __ink_params chain.DryRunParams,
 ) (*{{.Return}}, *chain.DryRunReturnGas, error) {
- 	if c.ChainClient.Debug {
+	return c.{{if .IsMut}}DryRun{{else}}Query{{end}}{{CamelCase .FuncName}}Context(context.Background(), {{.ArgStr}}__ink_params)
+}
+
+func (c *{{$.Name}}) {{if .IsMut}}DryRun{{else}}Query{{end}}{{CamelCase .FuncName}}Context(
+	ctx context.Context, {{.ArgTypeStr}} __ink_params chain.DryRunParams,
+) (*{{.Return}}, *chain.DryRunReturnGas, error) {
+ 	if c.ChainClient.IsDebug() {
 		fmt.Println()
 		util.LogWithPurple("[ DryRun   method ]", "{{.FuncName}}")
 	}
-	v, gas, err := chain.DryRunInk[{{.Return}}](
+	v, gas, err := chain.DryRunInkContext[{{.Return}}](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
@@ -115,12 +151,31 @@ func (c *{{$.Name}}) {{if .IsMut}}DryRun{{else}}Query{{end}}{{CamelCase .FuncNam
 			Args:     []any{ {{.ArgStr}} },
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	{{- if IsResult .Return}}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+	{{end}}
+	{{- if .IsMut}}
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "{{.Selector}}",
+				Args:     []any{ {{.ArgStr}} },
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 	{{end}}
 	return v, gas, nil
@@ -128,14 +183,26 @@ func (c *{{$.Name}}) {{if .IsMut}}DryRun{{else}}Query{{end}}{{CamelCase .FuncNam
 {{if .IsMut}}
 func (c *{{$.Name}}) Exec{{CamelCase .FuncName}}(
 	{{.ArgTypeStr}} __ink_params chain.ExecParams,
-) error {
- 	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+) (*chain.TxReceipt, error) {
+	return c.Exec{{CamelCase .FuncName}}Context(context.Background(), {{.ArgStr}}__ink_params)
+}
+
+func (c *{{$.Name}}) Exec{{CamelCase .FuncName}}Context(
+	ctx context.Context, {{.ArgTypeStr}} __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRun{{CamelCase .FuncName}}({{.ArgStr}}_param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRun{{CamelCase .FuncName}}Context(ctx, {{.ArgStr}}_param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
@@ -150,7 +217,14 @@ func (c *{{$.Name}}) Exec{{CamelCase .FuncName}}(
 func (c *{{$.Name}}) CallOf{{CamelCase .FuncName}}(
 	{{.ArgTypeStr}} __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRun{{CamelCase .FuncName}}({{.ArgStr}}__ink_params)
+	return c.CallOf{{CamelCase .FuncName}}Context(context.Background(), {{.ArgStr}}__ink_params)
+}
+
+func (c *{{$.Name}}) CallOf{{CamelCase .FuncName}}Context(
+	ctx context.Context, {{.ArgTypeStr}} __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRun{{CamelCase .FuncName}}Context(ctx, {{.ArgStr}}__ink_params)
 	if err != nil {
 		return nil,err
 	}
@@ -1,7 +1,7 @@
 package cloud
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"math/big"
 
@@ -10,18 +10,128 @@ import (
 	"github.com/wetee-dao/ink.go/util"
 )
 
-func DeployCloudWithNew(__ink_params chain.DeployParams) (*types.H160, error) {
-	return __ink_params.Client.DeployContract(
-		__ink_params.Code, __ink_params.Signer, types.NewU128(*big.NewInt(0)),
+func DeployCloudWithNew(subnet_addr types.H160, pod_contract_code_hash types.H256, __ink_params chain.DeployParams) (*types.H160, *chain.TxReceipt, error) {
+	return DeployCloudWithNewContext(context.Background(), subnet_addr, pod_contract_code_hash, __ink_params)
+}
+
+func DeployCloudWithNewContext(ctx context.Context, subnet_addr types.H160, pod_contract_code_hash types.H256, __ink_params chain.DeployParams) (*types.H160, *chain.TxReceipt, error) {
+	return __ink_params.Client.DeployContractContext(
+		ctx, __ink_params.Code, __ink_params.Signer, types.NewU128(*big.NewInt(0)),
 		util.InkContractInput{
 			Selector: "0x9bae9d5e",
-			Args:     []any{},
+			Args:     []any{subnet_addr, pod_contract_code_hash},
 		},
 		__ink_params.Salt,
+		__ink_params.SubmitOptions,
 	)
 }
 
-func InitCloudContract(client *chain.ChainClient, address string) (*Cloud, error) {
+// CloudAPI is the interface of Cloud contract, it can be mocked in tests
+type CloudAPI interface {
+	chain.Ink
+	DryRunSetPodContract(pod_contract types.H256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunSetPodContractContext(ctx context.Context, pod_contract types.H256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecSetPodContract(pod_contract types.H256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecSetPodContractContext(ctx context.Context, pod_contract types.H256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfSetPodContract(pod_contract types.H256, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfSetPodContractContext(ctx context.Context, pod_contract types.H256, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunSetMintInterval(t uint32, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunSetMintIntervalContext(ctx context.Context, t uint32, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecSetMintInterval(t uint32, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecSetMintIntervalContext(ctx context.Context, t uint32, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfSetMintInterval(t uint32, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfSetMintIntervalContext(ctx context.Context, t uint32, __ink_params chain.DryRunParams) (*types.Call, error)
+	QueryMintInterval(__ink_params chain.DryRunParams) (*uint32, *chain.DryRunReturnGas, error)
+	QueryMintIntervalContext(ctx context.Context, __ink_params chain.DryRunParams) (*uint32, *chain.DryRunReturnGas, error)
+	QuerySubnetAddress(__ink_params chain.DryRunParams) (*types.H160, *chain.DryRunReturnGas, error)
+	QuerySubnetAddressContext(ctx context.Context, __ink_params chain.DryRunParams) (*types.H160, *chain.DryRunReturnGas, error)
+	DryRunCreatePod(name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunCreatePodContext(ctx context.Context, name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecCreatePod(name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecCreatePodContext(ctx context.Context, name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfCreatePod(name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfCreatePodContext(ctx context.Context, name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunStartPod(pod_id uint64, pod_key util.AccountId, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunStartPodContext(ctx context.Context, pod_id uint64, pod_key util.AccountId, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecStartPod(pod_id uint64, pod_key util.AccountId, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecStartPodContext(ctx context.Context, pod_id uint64, pod_key util.AccountId, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfStartPod(pod_id uint64, pod_key util.AccountId, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfStartPodContext(ctx context.Context, pod_id uint64, pod_key util.AccountId, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunMintPod(pod_id uint64, report types.H256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunMintPodContext(ctx context.Context, pod_id uint64, report types.H256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecMintPod(pod_id uint64, report types.H256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecMintPodContext(ctx context.Context, pod_id uint64, report types.H256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfMintPod(pod_id uint64, report types.H256, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfMintPodContext(ctx context.Context, pod_id uint64, report types.H256, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunStopPod(pod_id uint64, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunStopPodContext(ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecStopPod(pod_id uint64, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecStopPodContext(ctx context.Context, pod_id uint64, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfStopPod(pod_id uint64, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfStopPodContext(ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunRestartPod(pod_id uint64, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunRestartPodContext(ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecRestartPod(pod_id uint64, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecRestartPodContext(ctx context.Context, pod_id uint64, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfRestartPod(pod_id uint64, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfRestartPodContext(ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunEditContainer(pod_id uint64, containers []ContainerInput, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunEditContainerContext(ctx context.Context, pod_id uint64, containers []ContainerInput, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecEditContainer(pod_id uint64, containers []ContainerInput, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecEditContainerContext(ctx context.Context, pod_id uint64, containers []ContainerInput, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfEditContainer(pod_id uint64, containers []ContainerInput, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfEditContainerContext(ctx context.Context, pod_id uint64, containers []ContainerInput, __ink_params chain.DryRunParams) (*types.Call, error)
+	QueryPodLen(__ink_params chain.DryRunParams) (*uint64, *chain.DryRunReturnGas, error)
+	QueryPodLenContext(ctx context.Context, __ink_params chain.DryRunParams) (*uint64, *chain.DryRunReturnGas, error)
+	QueryPods(start util.Option[uint64], size uint64, __ink_params chain.DryRunParams) (*[]Tuple_106, *chain.DryRunReturnGas, error)
+	QueryPodsContext(ctx context.Context, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams) (*[]Tuple_106, *chain.DryRunReturnGas, error)
+	QueryUserPodLen(__ink_params chain.DryRunParams) (*uint32, *chain.DryRunReturnGas, error)
+	QueryUserPodLenContext(ctx context.Context, __ink_params chain.DryRunParams) (*uint32, *chain.DryRunReturnGas, error)
+	QueryUserPods(start util.Option[uint32], size uint32, __ink_params chain.DryRunParams) (*[]Tuple_106, *chain.DryRunReturnGas, error)
+	QueryUserPodsContext(ctx context.Context, start util.Option[uint32], size uint32, __ink_params chain.DryRunParams) (*[]Tuple_106, *chain.DryRunReturnGas, error)
+	QueryWorkerPodsVersion(worker_id uint64, __ink_params chain.DryRunParams) (*[]Tuple_112, *chain.DryRunReturnGas, error)
+	QueryWorkerPodsVersionContext(ctx context.Context, worker_id uint64, __ink_params chain.DryRunParams) (*[]Tuple_112, *chain.DryRunReturnGas, error)
+	QueryWorkerPods(worker_id uint64, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams) (*[]Tuple_106, *chain.DryRunReturnGas, error)
+	QueryWorkerPodsContext(ctx context.Context, worker_id uint64, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams) (*[]Tuple_106, *chain.DryRunReturnGas, error)
+	QueryPod(pod_id uint64, __ink_params chain.DryRunParams) (*util.Option[Tuple_115], *chain.DryRunReturnGas, error)
+	QueryPodContext(ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams) (*util.Option[Tuple_115], *chain.DryRunReturnGas, error)
+	QueryPodsByIds(pod_ids []uint64, __ink_params chain.DryRunParams) (*[]Tuple_119, *chain.DryRunReturnGas, error)
+	QueryPodsByIdsContext(ctx context.Context, pod_ids []uint64, __ink_params chain.DryRunParams) (*[]Tuple_119, *chain.DryRunReturnGas, error)
+	QueryWorkerPodLen(worker_id uint64, __ink_params chain.DryRunParams) (*uint64, *chain.DryRunReturnGas, error)
+	QueryWorkerPodLenContext(ctx context.Context, worker_id uint64, __ink_params chain.DryRunParams) (*uint64, *chain.DryRunReturnGas, error)
+	QueryUserSecrets(user types.H160, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams) (*[]Tuple_122, *chain.DryRunReturnGas, error)
+	QueryUserSecretsContext(ctx context.Context, user types.H160, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams) (*[]Tuple_122, *chain.DryRunReturnGas, error)
+	QuerySecret(user types.H160, index uint64, __ink_params chain.DryRunParams) (*util.Option[Secret], *chain.DryRunReturnGas, error)
+	QuerySecretContext(ctx context.Context, user types.H160, index uint64, __ink_params chain.DryRunParams) (*util.Option[Secret], *chain.DryRunReturnGas, error)
+	DryRunInitSecret(name []byte, __ink_params chain.DryRunParams) (*util.Result[uint64, Error], *chain.DryRunReturnGas, error)
+	DryRunInitSecretContext(ctx context.Context, name []byte, __ink_params chain.DryRunParams) (*util.Result[uint64, Error], *chain.DryRunReturnGas, error)
+	ExecInitSecret(name []byte, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecInitSecretContext(ctx context.Context, name []byte, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfInitSecret(name []byte, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfInitSecretContext(ctx context.Context, name []byte, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunUpdateSecret(user types.H160, index uint64, hash types.H256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunUpdateSecretContext(ctx context.Context, user types.H160, index uint64, hash types.H256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecUpdateSecret(user types.H160, index uint64, hash types.H256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecUpdateSecretContext(ctx context.Context, user types.H160, index uint64, hash types.H256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfUpdateSecret(user types.H160, index uint64, hash types.H256, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfUpdateSecretContext(ctx context.Context, user types.H160, index uint64, hash types.H256, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunDelSecret(index uint64, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunDelSecretContext(ctx context.Context, index uint64, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecDelSecret(index uint64, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecDelSecretContext(ctx context.Context, index uint64, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfDelSecret(index uint64, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfDelSecretContext(ctx context.Context, index uint64, __ink_params chain.DryRunParams) (*types.Call, error)
+	DryRunSetCode(code_hash types.H256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	DryRunSetCodeContext(ctx context.Context, code_hash types.H256, __ink_params chain.DryRunParams) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error)
+	ExecSetCode(code_hash types.H256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	ExecSetCodeContext(ctx context.Context, code_hash types.H256, __ink_params chain.ExecParams) (*chain.TxReceipt, error)
+	CallOfSetCode(code_hash types.H256, __ink_params chain.DryRunParams) (*types.Call, error)
+	CallOfSetCodeContext(ctx context.Context, code_hash types.H256, __ink_params chain.DryRunParams) (*types.Call, error)
+}
+
+var _ CloudAPI = (*Cloud)(nil)
+
+func InitCloudContract(client chain.InkClient, address string) (*Cloud, error) {
 	contractAddress, err := util.HexToH160(address)
 	if err != nil {
 		return nil, err
@@ -33,11 +143,11 @@ func InitCloudContract(client *chain.ChainClient, address string) (*Cloud, error
 }
 
 type Cloud struct {
-	ChainClient *chain.ChainClient
+	ChainClient chain.InkClient
 	Address     types.H160
 }
 
-func (c *Cloud) Client() *chain.ChainClient {
+func (c *Cloud) Client() chain.InkClient {
 	return c.ChainClient
 }
 
@@ -45,59 +155,103 @@ func (c *Cloud) ContractAddress() types.H160 {
 	return c.Address
 }
 
-func (c *Cloud) DryRunSetCode(
-	code_hash types.H256, __ink_params chain.DryRunParams,
+func (c *Cloud) DryRunSetPodContract(
+	pod_contract types.H256, __ink_params chain.DryRunParams,
 ) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+	return c.DryRunSetPodContractContext(context.Background(), pod_contract, __ink_params)
+}
+
+func (c *Cloud) DryRunSetPodContractContext(
+	ctx context.Context, pod_contract types.H256, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
-		util.LogWithPurple("[ DryRun   method ]", "set_code")
+		util.LogWithPurple("[ DryRun   method ]", "set_pod_contract")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[util.NullTuple, Error]](
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
 		__ink_params.GasLimit,
 		__ink_params.StorageDepositLimit,
 		util.InkContractInput{
-			Selector: "0x694fb50f",
-			Args:     []any{code_hash},
+			Selector: "0xeebfb380",
+			Args:     []any{pod_contract},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0xeebfb380",
+				Args:     []any{pod_contract},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
 }
 
-func (c *Cloud) ExecSetCode(
-	code_hash types.H256, __ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+func (c *Cloud) ExecSetPodContract(
+	pod_contract types.H256, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	return c.ExecSetPodContractContext(context.Background(), pod_contract, __ink_params)
+}
+
+func (c *Cloud) ExecSetPodContractContext(
+	ctx context.Context, pod_contract types.H256, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunSetCode(code_hash, _param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunSetPodContractContext(ctx, pod_contract, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
 		util.InkContractInput{
-			Selector: "0x694fb50f",
-			Args:     []any{code_hash},
+			Selector: "0xeebfb380",
+			Args:     []any{pod_contract},
 		},
 		__ink_params,
 	)
 }
 
-func (c *Cloud) CallOfSetCode(
-	code_hash types.H256, __ink_params chain.DryRunParams,
+func (c *Cloud) CallOfSetPodContract(
+	pod_contract types.H256, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	return c.CallOfSetPodContractContext(context.Background(), pod_contract, __ink_params)
+}
+
+func (c *Cloud) CallOfSetPodContractContext(
+	ctx context.Context, pod_contract types.H256, __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunSetCode(code_hash, __ink_params)
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunSetPodContractContext(ctx, pod_contract, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -107,65 +261,109 @@ func (c *Cloud) CallOfSetCode(
 		gas.GasRequired,
 		gas.StorageDeposit,
 		util.InkContractInput{
-			Selector: "0x694fb50f",
-			Args:     []any{code_hash},
+			Selector: "0xeebfb380",
+			Args:     []any{pod_contract},
 		},
 	)
 }
 
-func (c *Cloud) DryRunPodLen(
-	__ink_params chain.DryRunParams,
-) (*util.Result[uint64, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+func (c *Cloud) DryRunSetMintInterval(
+	t uint32, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	return c.DryRunSetMintIntervalContext(context.Background(), t, __ink_params)
+}
+
+func (c *Cloud) DryRunSetMintIntervalContext(
+	ctx context.Context, t uint32, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
-		util.LogWithPurple("[ DryRun   method ]", "pod_len")
+		util.LogWithPurple("[ DryRun   method ]", "set_mint_interval")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[uint64, Error]](
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
 		__ink_params.GasLimit,
 		__ink_params.StorageDepositLimit,
 		util.InkContractInput{
-			Selector: "0xaf63d0e1",
-			Args:     []any{},
+			Selector: "0x936793ec",
+			Args:     []any{t},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x936793ec",
+				Args:     []any{t},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
 }
 
-func (c *Cloud) ExecPodLen(
-	__ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+func (c *Cloud) ExecSetMintInterval(
+	t uint32, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	return c.ExecSetMintIntervalContext(context.Background(), t, __ink_params)
+}
+
+func (c *Cloud) ExecSetMintIntervalContext(
+	ctx context.Context, t uint32, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunPodLen(_param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunSetMintIntervalContext(ctx, t, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
 		util.InkContractInput{
-			Selector: "0xaf63d0e1",
-			Args:     []any{},
+			Selector: "0x936793ec",
+			Args:     []any{t},
 		},
 		__ink_params,
 	)
 }
 
-func (c *Cloud) CallOfPodLen(
-	__ink_params chain.DryRunParams,
+func (c *Cloud) CallOfSetMintInterval(
+	t uint32, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	return c.CallOfSetMintIntervalContext(context.Background(), t, __ink_params)
+}
+
+func (c *Cloud) CallOfSetMintIntervalContext(
+	ctx context.Context, t uint32, __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunPodLen(__ink_params)
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunSetMintIntervalContext(ctx, t, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -175,65 +373,171 @@ func (c *Cloud) CallOfPodLen(
 		gas.GasRequired,
 		gas.StorageDeposit,
 		util.InkContractInput{
-			Selector: "0xaf63d0e1",
+			Selector: "0x936793ec",
+			Args:     []any{t},
+		},
+	)
+}
+
+func (c *Cloud) QueryMintInterval(
+	__ink_params chain.DryRunParams,
+) (*uint32, *chain.DryRunReturnGas, error) {
+	return c.QueryMintIntervalContext(context.Background(), __ink_params)
+}
+
+func (c *Cloud) QueryMintIntervalContext(
+	ctx context.Context, __ink_params chain.DryRunParams,
+) (*uint32, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "mint_interval")
+	}
+	v, gas, err := chain.DryRunInkContext[uint32](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0x0680bc7a",
 			Args:     []any{},
 		},
 	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, gas, nil
 }
 
-func (c *Cloud) DryRunCreateUserPod(
+func (c *Cloud) QuerySubnetAddress(
 	__ink_params chain.DryRunParams,
-) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+) (*types.H160, *chain.DryRunReturnGas, error) {
+	return c.QuerySubnetAddressContext(context.Background(), __ink_params)
+}
+
+func (c *Cloud) QuerySubnetAddressContext(
+	ctx context.Context, __ink_params chain.DryRunParams,
+) (*types.H160, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
-		util.LogWithPurple("[ DryRun   method ]", "create_user_pod")
+		util.LogWithPurple("[ DryRun   method ]", "subnet_address")
 	}
-	v, gas, err := chain.DryRunInk[util.Result[util.NullTuple, Error]](
+	v, gas, err := chain.DryRunInkContext[types.H160](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
 		__ink_params.GasLimit,
 		__ink_params.StorageDepositLimit,
 		util.InkContractInput{
-			Selector: "0x1035bf95",
+			Selector: "0x241d1854",
 			Args:     []any{},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, gas, nil
+}
+
+func (c *Cloud) DryRunCreatePod(
+	name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	return c.DryRunCreatePodContext(context.Background(), name, pod_type, tee_type, containers, region_id, level, worker_id, __ink_params)
+}
+
+func (c *Cloud) DryRunCreatePodContext(
+	ctx context.Context, name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "create_pod")
+	}
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0x080c3dfd",
+			Args:     []any{name, pod_type, tee_type, containers, region_id, level, worker_id},
+		},
+	)
+	if err != nil {
 		return nil, nil, err
 	}
 	if v != nil && v.IsErr {
-		return nil, nil, errors.New("Contract Reverted: " + v.E.Error())
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x080c3dfd",
+				Args:     []any{name, pod_type, tee_type, containers, region_id, level, worker_id},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return v, gas, nil
 }
 
-func (c *Cloud) ExecCreateUserPod(
-	__ink_params chain.ExecParams,
-) error {
-	_param := chain.DefaultParamWithOrigin(__ink_params.Signer.AccountID())
+func (c *Cloud) ExecCreatePod(
+	name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	return c.ExecCreatePodContext(context.Background(), name, pod_type, tee_type, containers, region_id, level, worker_id, __ink_params)
+}
+
+func (c *Cloud) ExecCreatePodContext(
+	ctx context.Context, name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
 	_param.PayAmount = __ink_params.PayAmount
-	_, gas, err := c.DryRunCreateUserPod(_param)
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunCreatePodContext(ctx, name, pod_type, tee_type, containers, region_id, level, worker_id, _param)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return chain.CallInk(
+	return chain.CallInkContext(
+		ctx,
 		c,
 		gas.GasRequired,
 		gas.StorageDeposit,
 		util.InkContractInput{
-			Selector: "0x1035bf95",
-			Args:     []any{},
+			Selector: "0x080c3dfd",
+			Args:     []any{name, pod_type, tee_type, containers, region_id, level, worker_id},
 		},
 		__ink_params,
 	)
 }
 
-func (c *Cloud) CallOfCreateUserPod(
-	__ink_params chain.DryRunParams,
+func (c *Cloud) CallOfCreatePod(
+	name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.DryRunParams,
 ) (*types.Call, error) {
-	_, gas, err := c.DryRunCreateUserPod(__ink_params)
+	return c.CallOfCreatePodContext(context.Background(), name, pod_type, tee_type, containers, region_id, level, worker_id, __ink_params)
+}
+
+func (c *Cloud) CallOfCreatePodContext(
+	ctx context.Context, name []byte, pod_type PodType, tee_type TEEType, containers []Container, region_id uint32, level byte, worker_id uint64, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunCreatePodContext(ctx, name, pod_type, tee_type, containers, region_id, level, worker_id, __ink_params)
 	if err != nil {
 		return nil, err
 	}
@@ -243,32 +547,1357 @@ func (c *Cloud) CallOfCreateUserPod(
 		gas.GasRequired,
 		gas.StorageDeposit,
 		util.InkContractInput{
-			Selector: "0x1035bf95",
-			Args:     []any{},
+			Selector: "0x080c3dfd",
+			Args:     []any{name, pod_type, tee_type, containers, region_id, level, worker_id},
 		},
 	)
 }
 
-func (c *Cloud) QueryUserPods(
-	__ink_params chain.DryRunParams,
-) (*[]Tuple_48, *chain.DryRunReturnGas, error) {
-	if c.ChainClient.Debug {
+func (c *Cloud) DryRunStartPod(
+	pod_id uint64, pod_key util.AccountId, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	return c.DryRunStartPodContext(context.Background(), pod_id, pod_key, __ink_params)
+}
+
+func (c *Cloud) DryRunStartPodContext(
+	ctx context.Context, pod_id uint64, pod_key util.AccountId, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
 		fmt.Println()
-		util.LogWithPurple("[ DryRun   method ]", "user_pods")
+		util.LogWithPurple("[ DryRun   method ]", "start_pod")
 	}
-	v, gas, err := chain.DryRunInk[[]Tuple_48](
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
 		c,
 		__ink_params.Origin,
 		__ink_params.PayAmount,
 		__ink_params.GasLimit,
 		__ink_params.StorageDepositLimit,
 		util.InkContractInput{
-			Selector: "0x2ba5c5d5",
-			Args:     []any{},
+			Selector: "0xc9f85a2d",
+			Args:     []any{pod_id, pod_key},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if v != nil && v.IsErr {
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0xc9f85a2d",
+				Args:     []any{pod_id, pod_key},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return v, gas, nil
+}
+
+func (c *Cloud) ExecStartPod(
+	pod_id uint64, pod_key util.AccountId, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	return c.ExecStartPodContext(context.Background(), pod_id, pod_key, __ink_params)
+}
+
+func (c *Cloud) ExecStartPodContext(
+	ctx context.Context, pod_id uint64, pod_key util.AccountId, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
+	_param.PayAmount = __ink_params.PayAmount
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunStartPodContext(ctx, pod_id, pod_key, _param)
+	if err != nil {
+		return nil, err
+	}
+	return chain.CallInkContext(
+		ctx,
+		c,
+		gas.GasRequired,
+		gas.StorageDeposit,
+		util.InkContractInput{
+			Selector: "0xc9f85a2d",
+			Args:     []any{pod_id, pod_key},
+		},
+		__ink_params,
+	)
+}
+
+func (c *Cloud) CallOfStartPod(
+	pod_id uint64, pod_key util.AccountId, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	return c.CallOfStartPodContext(context.Background(), pod_id, pod_key, __ink_params)
+}
+
+func (c *Cloud) CallOfStartPodContext(
+	ctx context.Context, pod_id uint64, pod_key util.AccountId, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunStartPodContext(ctx, pod_id, pod_key, __ink_params)
+	if err != nil {
+		return nil, err
+	}
+	return chain.CallOfTransaction(
+		c,
+		__ink_params.PayAmount,
+		gas.GasRequired,
+		gas.StorageDeposit,
+		util.InkContractInput{
+			Selector: "0xc9f85a2d",
+			Args:     []any{pod_id, pod_key},
+		},
+	)
+}
+
+func (c *Cloud) DryRunMintPod(
+	pod_id uint64, report types.H256, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	return c.DryRunMintPodContext(context.Background(), pod_id, report, __ink_params)
+}
+
+func (c *Cloud) DryRunMintPodContext(
+	ctx context.Context, pod_id uint64, report types.H256, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "mint_pod")
+	}
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0x8ca4b83c",
+			Args:     []any{pod_id, report},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if v != nil && v.IsErr {
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x8ca4b83c",
+				Args:     []any{pod_id, report},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return v, gas, nil
+}
+
+func (c *Cloud) ExecMintPod(
+	pod_id uint64, report types.H256, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	return c.ExecMintPodContext(context.Background(), pod_id, report, __ink_params)
+}
+
+func (c *Cloud) ExecMintPodContext(
+	ctx context.Context, pod_id uint64, report types.H256, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
+	_param.PayAmount = __ink_params.PayAmount
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunMintPodContext(ctx, pod_id, report, _param)
+	if err != nil {
+		return nil, err
+	}
+	return chain.CallInkContext(
+		ctx,
+		c,
+		gas.GasRequired,
+		gas.StorageDeposit,
+		util.InkContractInput{
+			Selector: "0x8ca4b83c",
+			Args:     []any{pod_id, report},
+		},
+		__ink_params,
+	)
+}
+
+func (c *Cloud) CallOfMintPod(
+	pod_id uint64, report types.H256, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	return c.CallOfMintPodContext(context.Background(), pod_id, report, __ink_params)
+}
+
+func (c *Cloud) CallOfMintPodContext(
+	ctx context.Context, pod_id uint64, report types.H256, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunMintPodContext(ctx, pod_id, report, __ink_params)
+	if err != nil {
+		return nil, err
+	}
+	return chain.CallOfTransaction(
+		c,
+		__ink_params.PayAmount,
+		gas.GasRequired,
+		gas.StorageDeposit,
+		util.InkContractInput{
+			Selector: "0x8ca4b83c",
+			Args:     []any{pod_id, report},
+		},
+	)
+}
+
+func (c *Cloud) DryRunStopPod(
+	pod_id uint64, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	return c.DryRunStopPodContext(context.Background(), pod_id, __ink_params)
+}
+
+func (c *Cloud) DryRunStopPodContext(
+	ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "stop_pod")
+	}
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0x29879008",
+			Args:     []any{pod_id},
 		},
 	)
-	if err != nil && !errors.Is(err, chain.ErrContractReverted) {
+	if err != nil {
 		return nil, nil, err
 	}
+	if v != nil && v.IsErr {
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x29879008",
+				Args:     []any{pod_id},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	return v, gas, nil
 }
+
+func (c *Cloud) ExecStopPod(
+	pod_id uint64, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	return c.ExecStopPodContext(context.Background(), pod_id, __ink_params)
+}
+
+func (c *Cloud) ExecStopPodContext(
+	ctx context.Context, pod_id uint64, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
+	_param.PayAmount = __ink_params.PayAmount
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunStopPodContext(ctx, pod_id, _param)
+	if err != nil {
+		return nil, err
+	}
+	return chain.CallInkContext(
+		ctx,
+		c,
+		gas.GasRequired,
+		gas.StorageDeposit,
+		util.InkContractInput{
+			Selector: "0x29879008",
+			Args:     []any{pod_id},
+		},
+		__ink_params,
+	)
+}
+
+func (c *Cloud) CallOfStopPod(
+	pod_id uint64, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	return c.CallOfStopPodContext(context.Background(), pod_id, __ink_params)
+}
+
+func (c *Cloud) CallOfStopPodContext(
+	ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunStopPodContext(ctx, pod_id, __ink_params)
+	if err != nil {
+		return nil, err
+	}
+	return chain.CallOfTransaction(
+		c,
+		__ink_params.PayAmount,
+		gas.GasRequired,
+		gas.StorageDeposit,
+		util.InkContractInput{
+			Selector: "0x29879008",
+			Args:     []any{pod_id},
+		},
+	)
+}
+
+func (c *Cloud) DryRunRestartPod(
+	pod_id uint64, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	return c.DryRunRestartPodContext(context.Background(), pod_id, __ink_params)
+}
+
+func (c *Cloud) DryRunRestartPodContext(
+	ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "restart_pod")
+	}
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0x0b40460c",
+			Args:     []any{pod_id},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if v != nil && v.IsErr {
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x0b40460c",
+				Args:     []any{pod_id},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return v, gas, nil
+}
+
+func (c *Cloud) ExecRestartPod(
+	pod_id uint64, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	return c.ExecRestartPodContext(context.Background(), pod_id, __ink_params)
+}
+
+func (c *Cloud) ExecRestartPodContext(
+	ctx context.Context, pod_id uint64, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
+	_param.PayAmount = __ink_params.PayAmount
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunRestartPodContext(ctx, pod_id, _param)
+	if err != nil {
+		return nil, err
+	}
+	return chain.CallInkContext(
+		ctx,
+		c,
+		gas.GasRequired,
+		gas.StorageDeposit,
+		util.InkContractInput{
+			Selector: "0x0b40460c",
+			Args:     []any{pod_id},
+		},
+		__ink_params,
+	)
+}
+
+func (c *Cloud) CallOfRestartPod(
+	pod_id uint64, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	return c.CallOfRestartPodContext(context.Background(), pod_id, __ink_params)
+}
+
+func (c *Cloud) CallOfRestartPodContext(
+	ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunRestartPodContext(ctx, pod_id, __ink_params)
+	if err != nil {
+		return nil, err
+	}
+	return chain.CallOfTransaction(
+		c,
+		__ink_params.PayAmount,
+		gas.GasRequired,
+		gas.StorageDeposit,
+		util.InkContractInput{
+			Selector: "0x0b40460c",
+			Args:     []any{pod_id},
+		},
+	)
+}
+
+func (c *Cloud) DryRunEditContainer(
+	pod_id uint64, containers []ContainerInput, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	return c.DryRunEditContainerContext(context.Background(), pod_id, containers, __ink_params)
+}
+
+func (c *Cloud) DryRunEditContainerContext(
+	ctx context.Context, pod_id uint64, containers []ContainerInput, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "edit_container")
+	}
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0x50e8c63b",
+			Args:     []any{pod_id, containers},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if v != nil && v.IsErr {
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x50e8c63b",
+				Args:     []any{pod_id, containers},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return v, gas, nil
+}
+
+func (c *Cloud) ExecEditContainer(
+	pod_id uint64, containers []ContainerInput, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	return c.ExecEditContainerContext(context.Background(), pod_id, containers, __ink_params)
+}
+
+func (c *Cloud) ExecEditContainerContext(
+	ctx context.Context, pod_id uint64, containers []ContainerInput, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
+	_param.PayAmount = __ink_params.PayAmount
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunEditContainerContext(ctx, pod_id, containers, _param)
+	if err != nil {
+		return nil, err
+	}
+	return chain.CallInkContext(
+		ctx,
+		c,
+		gas.GasRequired,
+		gas.StorageDeposit,
+		util.InkContractInput{
+			Selector: "0x50e8c63b",
+			Args:     []any{pod_id, containers},
+		},
+		__ink_params,
+	)
+}
+
+func (c *Cloud) CallOfEditContainer(
+	pod_id uint64, containers []ContainerInput, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	return c.CallOfEditContainerContext(context.Background(), pod_id, containers, __ink_params)
+}
+
+func (c *Cloud) CallOfEditContainerContext(
+	ctx context.Context, pod_id uint64, containers []ContainerInput, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunEditContainerContext(ctx, pod_id, containers, __ink_params)
+	if err != nil {
+		return nil, err
+	}
+	return chain.CallOfTransaction(
+		c,
+		__ink_params.PayAmount,
+		gas.GasRequired,
+		gas.StorageDeposit,
+		util.InkContractInput{
+			Selector: "0x50e8c63b",
+			Args:     []any{pod_id, containers},
+		},
+	)
+}
+
+func (c *Cloud) QueryPodLen(
+	__ink_params chain.DryRunParams,
+) (*uint64, *chain.DryRunReturnGas, error) {
+	return c.QueryPodLenContext(context.Background(), __ink_params)
+}
+
+func (c *Cloud) QueryPodLenContext(
+	ctx context.Context, __ink_params chain.DryRunParams,
+) (*uint64, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "pod_len")
+	}
+	v, gas, err := chain.DryRunInkContext[uint64](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0xaf63d0e1",
+			Args:     []any{},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, gas, nil
+}
+
+func (c *Cloud) QueryPods(
+	start util.Option[uint64], size uint64, __ink_params chain.DryRunParams,
+) (*[]Tuple_106, *chain.DryRunReturnGas, error) {
+	return c.QueryPodsContext(context.Background(), start, size, __ink_params)
+}
+
+func (c *Cloud) QueryPodsContext(
+	ctx context.Context, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams,
+) (*[]Tuple_106, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "pods")
+	}
+	v, gas, err := chain.DryRunInkContext[[]Tuple_106](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0xba743fed",
+			Args:     []any{start, size},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, gas, nil
+}
+
+func (c *Cloud) QueryUserPodLen(
+	__ink_params chain.DryRunParams,
+) (*uint32, *chain.DryRunReturnGas, error) {
+	return c.QueryUserPodLenContext(context.Background(), __ink_params)
+}
+
+func (c *Cloud) QueryUserPodLenContext(
+	ctx context.Context, __ink_params chain.DryRunParams,
+) (*uint32, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "user_pod_len")
+	}
+	v, gas, err := chain.DryRunInkContext[uint32](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0x31385138",
+			Args:     []any{},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, gas, nil
+}
+
+func (c *Cloud) QueryUserPods(
+	start util.Option[uint32], size uint32, __ink_params chain.DryRunParams,
+) (*[]Tuple_106, *chain.DryRunReturnGas, error) {
+	return c.QueryUserPodsContext(context.Background(), start, size, __ink_params)
+}
+
+func (c *Cloud) QueryUserPodsContext(
+	ctx context.Context, start util.Option[uint32], size uint32, __ink_params chain.DryRunParams,
+) (*[]Tuple_106, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "user_pods")
+	}
+	v, gas, err := chain.DryRunInkContext[[]Tuple_106](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0x2ba5c5d5",
+			Args:     []any{start, size},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, gas, nil
+}
+
+func (c *Cloud) QueryWorkerPodsVersion(
+	worker_id uint64, __ink_params chain.DryRunParams,
+) (*[]Tuple_112, *chain.DryRunReturnGas, error) {
+	return c.QueryWorkerPodsVersionContext(context.Background(), worker_id, __ink_params)
+}
+
+func (c *Cloud) QueryWorkerPodsVersionContext(
+	ctx context.Context, worker_id uint64, __ink_params chain.DryRunParams,
+) (*[]Tuple_112, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "worker_pods_version")
+	}
+	v, gas, err := chain.DryRunInkContext[[]Tuple_112](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0x56d09cd0",
+			Args:     []any{worker_id},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, gas, nil
+}
+
+func (c *Cloud) QueryWorkerPods(
+	worker_id uint64, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams,
+) (*[]Tuple_106, *chain.DryRunReturnGas, error) {
+	return c.QueryWorkerPodsContext(context.Background(), worker_id, start, size, __ink_params)
+}
+
+func (c *Cloud) QueryWorkerPodsContext(
+	ctx context.Context, worker_id uint64, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams,
+) (*[]Tuple_106, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "worker_pods")
+	}
+	v, gas, err := chain.DryRunInkContext[[]Tuple_106](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0xd2d1cf5e",
+			Args:     []any{worker_id, start, size},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, gas, nil
+}
+
+func (c *Cloud) QueryPod(
+	pod_id uint64, __ink_params chain.DryRunParams,
+) (*util.Option[Tuple_115], *chain.DryRunReturnGas, error) {
+	return c.QueryPodContext(context.Background(), pod_id, __ink_params)
+}
+
+func (c *Cloud) QueryPodContext(
+	ctx context.Context, pod_id uint64, __ink_params chain.DryRunParams,
+) (*util.Option[Tuple_115], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "pod")
+	}
+	v, gas, err := chain.DryRunInkContext[util.Option[Tuple_115]](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0xb431f434",
+			Args:     []any{pod_id},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, gas, nil
+}
+
+func (c *Cloud) QueryPodsByIds(
+	pod_ids []uint64, __ink_params chain.DryRunParams,
+) (*[]Tuple_119, *chain.DryRunReturnGas, error) {
+	return c.QueryPodsByIdsContext(context.Background(), pod_ids, __ink_params)
+}
+
+func (c *Cloud) QueryPodsByIdsContext(
+	ctx context.Context, pod_ids []uint64, __ink_params chain.DryRunParams,
+) (*[]Tuple_119, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "pods_by_ids")
+	}
+	v, gas, err := chain.DryRunInkContext[[]Tuple_119](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0x711ca8a1",
+			Args:     []any{pod_ids},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, gas, nil
+}
+
+func (c *Cloud) QueryWorkerPodLen(
+	worker_id uint64, __ink_params chain.DryRunParams,
+) (*uint64, *chain.DryRunReturnGas, error) {
+	return c.QueryWorkerPodLenContext(context.Background(), worker_id, __ink_params)
+}
+
+func (c *Cloud) QueryWorkerPodLenContext(
+	ctx context.Context, worker_id uint64, __ink_params chain.DryRunParams,
+) (*uint64, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "worker_pod_len")
+	}
+	v, gas, err := chain.DryRunInkContext[uint64](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0x2fced50e",
+			Args:     []any{worker_id},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, gas, nil
+}
+
+func (c *Cloud) QueryUserSecrets(
+	user types.H160, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams,
+) (*[]Tuple_122, *chain.DryRunReturnGas, error) {
+	return c.QueryUserSecretsContext(context.Background(), user, start, size, __ink_params)
+}
+
+func (c *Cloud) QueryUserSecretsContext(
+	ctx context.Context, user types.H160, start util.Option[uint64], size uint64, __ink_params chain.DryRunParams,
+) (*[]Tuple_122, *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "user_secrets")
+	}
+	v, gas, err := chain.DryRunInkContext[[]Tuple_122](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0xf1660056",
+			Args:     []any{user, start, size},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, gas, nil
+}
+
+func (c *Cloud) QuerySecret(
+	user types.H160, index uint64, __ink_params chain.DryRunParams,
+) (*util.Option[Secret], *chain.DryRunReturnGas, error) {
+	return c.QuerySecretContext(context.Background(), user, index, __ink_params)
+}
+
+func (c *Cloud) QuerySecretContext(
+	ctx context.Context, user types.H160, index uint64, __ink_params chain.DryRunParams,
+) (*util.Option[Secret], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "secret")
+	}
+	v, gas, err := chain.DryRunInkContext[util.Option[Secret]](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0xae4aafb3",
+			Args:     []any{user, index},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, gas, nil
+}
+
+func (c *Cloud) DryRunInitSecret(
+	name []byte, __ink_params chain.DryRunParams,
+) (*util.Result[uint64, Error], *chain.DryRunReturnGas, error) {
+	return c.DryRunInitSecretContext(context.Background(), name, __ink_params)
+}
+
+func (c *Cloud) DryRunInitSecretContext(
+	ctx context.Context, name []byte, __ink_params chain.DryRunParams,
+) (*util.Result[uint64, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "init_secret")
+	}
+	v, gas, err := chain.DryRunInkContext[util.Result[uint64, Error]](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0x0b67c2ff",
+			Args:     []any{name},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if v != nil && v.IsErr {
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x0b67c2ff",
+				Args:     []any{name},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return v, gas, nil
+}
+
+func (c *Cloud) ExecInitSecret(
+	name []byte, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	return c.ExecInitSecretContext(context.Background(), name, __ink_params)
+}
+
+func (c *Cloud) ExecInitSecretContext(
+	ctx context.Context, name []byte, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
+	_param.PayAmount = __ink_params.PayAmount
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunInitSecretContext(ctx, name, _param)
+	if err != nil {
+		return nil, err
+	}
+	return chain.CallInkContext(
+		ctx,
+		c,
+		gas.GasRequired,
+		gas.StorageDeposit,
+		util.InkContractInput{
+			Selector: "0x0b67c2ff",
+			Args:     []any{name},
+		},
+		__ink_params,
+	)
+}
+
+func (c *Cloud) CallOfInitSecret(
+	name []byte, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	return c.CallOfInitSecretContext(context.Background(), name, __ink_params)
+}
+
+func (c *Cloud) CallOfInitSecretContext(
+	ctx context.Context, name []byte, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunInitSecretContext(ctx, name, __ink_params)
+	if err != nil {
+		return nil, err
+	}
+	return chain.CallOfTransaction(
+		c,
+		__ink_params.PayAmount,
+		gas.GasRequired,
+		gas.StorageDeposit,
+		util.InkContractInput{
+			Selector: "0x0b67c2ff",
+			Args:     []any{name},
+		},
+	)
+}
+
+func (c *Cloud) DryRunUpdateSecret(
+	user types.H160, index uint64, hash types.H256, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	return c.DryRunUpdateSecretContext(context.Background(), user, index, hash, __ink_params)
+}
+
+func (c *Cloud) DryRunUpdateSecretContext(
+	ctx context.Context, user types.H160, index uint64, hash types.H256, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "update_secret")
+	}
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0x4972e7e8",
+			Args:     []any{user, index, hash},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if v != nil && v.IsErr {
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x4972e7e8",
+				Args:     []any{user, index, hash},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return v, gas, nil
+}
+
+func (c *Cloud) ExecUpdateSecret(
+	user types.H160, index uint64, hash types.H256, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	return c.ExecUpdateSecretContext(context.Background(), user, index, hash, __ink_params)
+}
+
+func (c *Cloud) ExecUpdateSecretContext(
+	ctx context.Context, user types.H160, index uint64, hash types.H256, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
+	_param.PayAmount = __ink_params.PayAmount
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunUpdateSecretContext(ctx, user, index, hash, _param)
+	if err != nil {
+		return nil, err
+	}
+	return chain.CallInkContext(
+		ctx,
+		c,
+		gas.GasRequired,
+		gas.StorageDeposit,
+		util.InkContractInput{
+			Selector: "0x4972e7e8",
+			Args:     []any{user, index, hash},
+		},
+		__ink_params,
+	)
+}
+
+func (c *Cloud) CallOfUpdateSecret(
+	user types.H160, index uint64, hash types.H256, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	return c.CallOfUpdateSecretContext(context.Background(), user, index, hash, __ink_params)
+}
+
+func (c *Cloud) CallOfUpdateSecretContext(
+	ctx context.Context, user types.H160, index uint64, hash types.H256, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunUpdateSecretContext(ctx, user, index, hash, __ink_params)
+	if err != nil {
+		return nil, err
+	}
+	return chain.CallOfTransaction(
+		c,
+		__ink_params.PayAmount,
+		gas.GasRequired,
+		gas.StorageDeposit,
+		util.InkContractInput{
+			Selector: "0x4972e7e8",
+			Args:     []any{user, index, hash},
+		},
+	)
+}
+
+func (c *Cloud) DryRunDelSecret(
+	index uint64, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	return c.DryRunDelSecretContext(context.Background(), index, __ink_params)
+}
+
+func (c *Cloud) DryRunDelSecretContext(
+	ctx context.Context, index uint64, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "del_secret")
+	}
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0x8f1a7248",
+			Args:     []any{index},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if v != nil && v.IsErr {
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x8f1a7248",
+				Args:     []any{index},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return v, gas, nil
+}
+
+func (c *Cloud) ExecDelSecret(
+	index uint64, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	return c.ExecDelSecretContext(context.Background(), index, __ink_params)
+}
+
+func (c *Cloud) ExecDelSecretContext(
+	ctx context.Context, index uint64, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
+	_param.PayAmount = __ink_params.PayAmount
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunDelSecretContext(ctx, index, _param)
+	if err != nil {
+		return nil, err
+	}
+	return chain.CallInkContext(
+		ctx,
+		c,
+		gas.GasRequired,
+		gas.StorageDeposit,
+		util.InkContractInput{
+			Selector: "0x8f1a7248",
+			Args:     []any{index},
+		},
+		__ink_params,
+	)
+}
+
+func (c *Cloud) CallOfDelSecret(
+	index uint64, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	return c.CallOfDelSecretContext(context.Background(), index, __ink_params)
+}
+
+func (c *Cloud) CallOfDelSecretContext(
+	ctx context.Context, index uint64, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunDelSecretContext(ctx, index, __ink_params)
+	if err != nil {
+		return nil, err
+	}
+	return chain.CallOfTransaction(
+		c,
+		__ink_params.PayAmount,
+		gas.GasRequired,
+		gas.StorageDeposit,
+		util.InkContractInput{
+			Selector: "0x8f1a7248",
+			Args:     []any{index},
+		},
+	)
+}
+
+func (c *Cloud) DryRunSetCode(
+	code_hash types.H256, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	return c.DryRunSetCodeContext(context.Background(), code_hash, __ink_params)
+}
+
+func (c *Cloud) DryRunSetCodeContext(
+	ctx context.Context, code_hash types.H256, __ink_params chain.DryRunParams,
+) (*util.Result[util.NullTuple, Error], *chain.DryRunReturnGas, error) {
+	if c.ChainClient.IsDebug() {
+		fmt.Println()
+		util.LogWithPurple("[ DryRun   method ]", "set_code")
+	}
+	v, gas, err := chain.DryRunInkContext[util.Result[util.NullTuple, Error]](
+		ctx,
+		c,
+		__ink_params.Origin,
+		__ink_params.PayAmount,
+		__ink_params.GasLimit,
+		__ink_params.StorageDepositLimit,
+		util.InkContractInput{
+			Selector: "0x694fb50f",
+			Args:     []any{code_hash},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if v != nil && v.IsErr {
+		return nil, nil, &chain.ContractResultError{Decoded: v.E}
+	}
+
+	if gas != nil && __ink_params.EstimateFee {
+		gas.Fee, err = chain.EstimateInkFee(
+			ctx,
+			c,
+			__ink_params.Origin,
+			__ink_params.KeyType,
+			__ink_params.PayAmount,
+			gas,
+			util.InkContractInput{
+				Selector: "0x694fb50f",
+				Args:     []any{code_hash},
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return v, gas, nil
+}
+
+func (c *Cloud) ExecSetCode(
+	code_hash types.H256, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	return c.ExecSetCodeContext(context.Background(), code_hash, __ink_params)
+}
+
+func (c *Cloud) ExecSetCodeContext(
+	ctx context.Context, code_hash types.H256, __ink_params chain.ExecParams,
+) (*chain.TxReceipt, error) {
+	__ink_origin, err := __ink_params.OriginContext(ctx, c.Client())
+	if err != nil {
+		return nil, err
+	}
+	_param := chain.DefaultParamWithOrigin(__ink_origin)
+	_param.PayAmount = __ink_params.PayAmount
+	_param.EstimateFee = false
+	_, gas, err := c.DryRunSetCodeContext(ctx, code_hash, _param)
+	if err != nil {
+		return nil, err
+	}
+	return chain.CallInkContext(
+		ctx,
+		c,
+		gas.GasRequired,
+		gas.StorageDeposit,
+		util.InkContractInput{
+			Selector: "0x694fb50f",
+			Args:     []any{code_hash},
+		},
+		__ink_params,
+	)
+}
+
+func (c *Cloud) CallOfSetCode(
+	code_hash types.H256, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	return c.CallOfSetCodeContext(context.Background(), code_hash, __ink_params)
+}
+
+func (c *Cloud) CallOfSetCodeContext(
+	ctx context.Context, code_hash types.H256, __ink_params chain.DryRunParams,
+) (*types.Call, error) {
+	__ink_params.EstimateFee = false
+	_, gas, err := c.DryRunSetCodeContext(ctx, code_hash, __ink_params)
+	if err != nil {
+		return nil, err
+	}
+	return chain.CallOfTransaction(
+		c,
+		__ink_params.PayAmount,
+		gas.GasRequired,
+		gas.StorageDeposit,
+		util.InkContractInput{
+			Selector: "0x694fb50f",
+			Args:     []any{code_hash},
+		},
+	)
+}
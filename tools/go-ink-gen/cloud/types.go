@@ -8,6 +8,113 @@ import (
 	"github.com/wetee-dao/ink.go/util"
 )
 
+type Pod struct { // Composite
+	Name       []byte
+	Owner      types.H160
+	Contract   types.H160
+	Ptype      PodType
+	StartBlock uint32
+	TeeType    TEEType
+}
+type PodType struct { // Enum
+	CPU    *bool // 0
+	GPU    *bool // 1
+	SCRIPT *bool // 2
+}
+
+func (ty PodType) Encode(encoder scale.Encoder) (err error) {
+	if ty.CPU != nil {
+		err = encoder.PushByte(0)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if ty.GPU != nil {
+		err = encoder.PushByte(1)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if ty.SCRIPT != nil {
+		err = encoder.PushByte(2)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+	return fmt.Errorf("unrecognized enum")
+}
+
+func (ty *PodType) Decode(decoder scale.Decoder) (err error) {
+	variant, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+	switch variant {
+	case 0: // Base
+		t := true
+		ty.CPU = &t
+		return
+	case 1: // Base
+		t := true
+		ty.GPU = &t
+		return
+	case 2: // Base
+		t := true
+		ty.SCRIPT = &t
+		return
+	default:
+		return fmt.Errorf("unrecognized enum")
+	}
+}
+
+type TEEType struct { // Enum
+	SGX *bool // 0
+	CVM *bool // 1
+}
+
+func (ty TEEType) Encode(encoder scale.Encoder) (err error) {
+	if ty.SGX != nil {
+		err = encoder.PushByte(0)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if ty.CVM != nil {
+		err = encoder.PushByte(1)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+	return fmt.Errorf("unrecognized enum")
+}
+
+func (ty *TEEType) Decode(decoder scale.Decoder) (err error) {
+	variant, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+	switch variant {
+	case 0: // Base
+		t := true
+		ty.SGX = &t
+		return
+	case 1: // Base
+		t := true
+		ty.CVM = &t
+		return
+	default:
+		return fmt.Errorf("unrecognized enum")
+	}
+}
+
 type Service struct { // Enum
 	Tcp        *uint16 // 0
 	Udp        *uint16 // 1
@@ -186,11 +293,149 @@ func (ty *DiskClass) Decode(decoder scale.Decoder) (err error) {
 	}
 }
 
+type Env struct { // Enum
+	Env *struct { // 0
+		F0 []byte
+		F1 []byte
+	}
+	File *struct { // 1
+		F0 []byte
+		F1 []byte
+	}
+	Encrypt *struct { // 2
+		F0 []byte
+		F1 uint64
+	}
+}
+
+func (ty Env) Encode(encoder scale.Encoder) (err error) {
+	if ty.Env != nil {
+		err = encoder.PushByte(0)
+		if err != nil {
+			return err
+		}
+
+		err = encoder.Encode(ty.Env.F0)
+		if err != nil {
+			return err
+		}
+
+		err = encoder.Encode(ty.Env.F1)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	if ty.File != nil {
+		err = encoder.PushByte(1)
+		if err != nil {
+			return err
+		}
+
+		err = encoder.Encode(ty.File.F0)
+		if err != nil {
+			return err
+		}
+
+		err = encoder.Encode(ty.File.F1)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	if ty.Encrypt != nil {
+		err = encoder.PushByte(2)
+		if err != nil {
+			return err
+		}
+
+		err = encoder.Encode(ty.Encrypt.F0)
+		if err != nil {
+			return err
+		}
+
+		err = encoder.Encode(ty.Encrypt.F1)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+	return fmt.Errorf("unrecognized enum")
+}
+
+func (ty *Env) Decode(decoder scale.Decoder) (err error) {
+	variant, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+	switch variant {
+	case 0: // Tuple
+		ty.Env = &struct {
+			F0 []byte
+			F1 []byte
+		}{}
+
+		err = decoder.Decode(&ty.Env.F0)
+		if err != nil {
+			return err
+		}
+
+		err = decoder.Decode(&ty.Env.F1)
+		if err != nil {
+			return err
+		}
+
+		return
+	case 1: // Tuple
+		ty.File = &struct {
+			F0 []byte
+			F1 []byte
+		}{}
+
+		err = decoder.Decode(&ty.File.F0)
+		if err != nil {
+			return err
+		}
+
+		err = decoder.Decode(&ty.File.F1)
+		if err != nil {
+			return err
+		}
+
+		return
+	case 2: // Tuple
+		ty.Encrypt = &struct {
+			F0 []byte
+			F1 uint64
+		}{}
+
+		err = decoder.Decode(&ty.Encrypt.F0)
+		if err != nil {
+			return err
+		}
+
+		err = decoder.Decode(&ty.Encrypt.F1)
+		if err != nil {
+			return err
+		}
+
+		return
+	default:
+		return fmt.Errorf("unrecognized enum")
+	}
+}
+
 type Container struct { // Composite
 	Image   []byte
 	Command Command
 	Port    []Service
-	Cr      Cr
+	Cr      CR
+	Env     []Env
 }
 type Command struct { // Enum
 	SH   *[]byte // 0
@@ -282,34 +527,32 @@ func (ty *Command) Decode(decoder scale.Decoder) (err error) {
 	}
 }
 
-type Cr struct { // Composite
+type CR struct { // Composite
 	Cpu  uint32
 	Mem  uint32
 	Disk []Disk
 	Gpu  uint32
 }
-type Pod struct { // Composite
-	Id            uint64
-	Creator       types.H160
-	ContractId    types.H160
-	StartBlock    uint32
-	Name          []byte
-	TemplateId    util.Option[types.U128]
-	Image         []byte
-	Meta          []byte
-	Command       Command
-	Port          []Service
-	Cr            Cr
-	SideContainer []Container
-	TeeVersion    TEEVersion
-}
-type TEEVersion struct { // Enum
-	SGX *bool // 0
-	CVM *bool // 1
+type Secret struct { // Composite
+	Name []byte
+	Hash util.Option[types.H256]
+}
+type Error struct { // Enum
+	SetCodeFailed          *bool // 0
+	MustCallByGovContract  *bool // 1
+	WorkerLevelNotEnough   *bool // 2
+	RegionNotMatch         *bool // 3
+	WorkerNotOnline        *bool // 4
+	NotPodOwner            *bool // 5
+	PodKeyNotExist         *bool // 6
+	PodStatusError         *bool // 7
+	InvalidSideChainCaller *bool // 8
+	DelFailed              *bool // 9
+	NotFound               *bool // 10
 }
 
-func (ty TEEVersion) Encode(encoder scale.Encoder) (err error) {
-	if ty.SGX != nil {
+func (ty Error) Encode(encoder scale.Encoder) (err error) {
+	if ty.SetCodeFailed != nil {
 		err = encoder.PushByte(0)
 		if err != nil {
 			return err
@@ -317,17 +560,89 @@ func (ty TEEVersion) Encode(encoder scale.Encoder) (err error) {
 		return nil
 	}
 
-	if ty.CVM != nil {
+	if ty.MustCallByGovContract != nil {
 		err = encoder.PushByte(1)
 		if err != nil {
 			return err
 		}
 		return nil
 	}
+
+	if ty.WorkerLevelNotEnough != nil {
+		err = encoder.PushByte(2)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if ty.RegionNotMatch != nil {
+		err = encoder.PushByte(3)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if ty.WorkerNotOnline != nil {
+		err = encoder.PushByte(4)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if ty.NotPodOwner != nil {
+		err = encoder.PushByte(5)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if ty.PodKeyNotExist != nil {
+		err = encoder.PushByte(6)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if ty.PodStatusError != nil {
+		err = encoder.PushByte(7)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if ty.InvalidSideChainCaller != nil {
+		err = encoder.PushByte(8)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if ty.DelFailed != nil {
+		err = encoder.PushByte(9)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if ty.NotFound != nil {
+		err = encoder.PushByte(10)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
 	return fmt.Errorf("unrecognized enum")
 }
 
-func (ty *TEEVersion) Decode(decoder scale.Decoder) (err error) {
+func (ty *Error) Decode(decoder scale.Decoder) (err error) {
 	variant, err := decoder.ReadOneByte()
 	if err != nil {
 		return err
@@ -335,24 +650,111 @@ func (ty *TEEVersion) Decode(decoder scale.Decoder) (err error) {
 	switch variant {
 	case 0: // Base
 		t := true
-		ty.SGX = &t
+		ty.SetCodeFailed = &t
 		return
 	case 1: // Base
 		t := true
-		ty.CVM = &t
+		ty.MustCallByGovContract = &t
+		return
+	case 2: // Base
+		t := true
+		ty.WorkerLevelNotEnough = &t
+		return
+	case 3: // Base
+		t := true
+		ty.RegionNotMatch = &t
+		return
+	case 4: // Base
+		t := true
+		ty.WorkerNotOnline = &t
+		return
+	case 5: // Base
+		t := true
+		ty.NotPodOwner = &t
+		return
+	case 6: // Base
+		t := true
+		ty.PodKeyNotExist = &t
+		return
+	case 7: // Base
+		t := true
+		ty.PodStatusError = &t
+		return
+	case 8: // Base
+		t := true
+		ty.InvalidSideChainCaller = &t
+		return
+	case 9: // Base
+		t := true
+		ty.DelFailed = &t
+		return
+	case 10: // Base
+		t := true
+		ty.NotFound = &t
 		return
 	default:
 		return fmt.Errorf("unrecognized enum")
 	}
 }
+func (ty *Error) Error() string {
+	if ty.SetCodeFailed != nil {
+		return "SetCodeFailed"
+	}
 
-type Error struct { // Enum
-	SetCodeFailed          *bool // 0
-	MustCallByMainContract *bool // 1
+	if ty.MustCallByGovContract != nil {
+		return "MustCallByGovContract"
+	}
+
+	if ty.WorkerLevelNotEnough != nil {
+		return "WorkerLevelNotEnough"
+	}
+
+	if ty.RegionNotMatch != nil {
+		return "RegionNotMatch"
+	}
+
+	if ty.WorkerNotOnline != nil {
+		return "WorkerNotOnline"
+	}
+
+	if ty.NotPodOwner != nil {
+		return "NotPodOwner"
+	}
+
+	if ty.PodKeyNotExist != nil {
+		return "PodKeyNotExist"
+	}
+
+	if ty.PodStatusError != nil {
+		return "PodStatusError"
+	}
+
+	if ty.InvalidSideChainCaller != nil {
+		return "InvalidSideChainCaller"
+	}
+
+	if ty.DelFailed != nil {
+		return "DelFailed"
+	}
+
+	if ty.NotFound != nil {
+		return "NotFound"
+	}
+	return "Unknown"
 }
 
-func (ty Error) Encode(encoder scale.Encoder) (err error) {
-	if ty.SetCodeFailed != nil {
+type ContainerInput struct { // Composite
+	Etype     EditType
+	Container Container
+}
+type EditType struct { // Enum
+	INSERT *bool   // 0
+	UPDATE *uint64 // 1
+	REMOVE *uint64 // 2
+}
+
+func (ty EditType) Encode(encoder scale.Encoder) (err error) {
+	if ty.INSERT != nil {
 		err = encoder.PushByte(0)
 		if err != nil {
 			return err
@@ -360,17 +762,33 @@ func (ty Error) Encode(encoder scale.Encoder) (err error) {
 		return nil
 	}
 
-	if ty.MustCallByMainContract != nil {
+	if ty.UPDATE != nil {
 		err = encoder.PushByte(1)
 		if err != nil {
 			return err
 		}
+		err = encoder.Encode(*ty.UPDATE)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if ty.REMOVE != nil {
+		err = encoder.PushByte(2)
+		if err != nil {
+			return err
+		}
+		err = encoder.Encode(*ty.REMOVE)
+		if err != nil {
+			return err
+		}
 		return nil
 	}
 	return fmt.Errorf("unrecognized enum")
 }
 
-func (ty *Error) Decode(decoder scale.Decoder) (err error) {
+func (ty *EditType) Decode(decoder scale.Decoder) (err error) {
 	variant, err := decoder.ReadOneByte()
 	if err != nil {
 		return err
@@ -378,28 +796,57 @@ func (ty *Error) Decode(decoder scale.Decoder) (err error) {
 	switch variant {
 	case 0: // Base
 		t := true
-		ty.SetCodeFailed = &t
+		ty.INSERT = &t
 		return
-	case 1: // Base
-		t := true
-		ty.MustCallByMainContract = &t
+	case 1: // Inline
+		ty.UPDATE = new(uint64)
+		err = decoder.Decode(ty.UPDATE)
+		if err != nil {
+			return err
+		}
+		return
+	case 2: // Inline
+		ty.REMOVE = new(uint64)
+		err = decoder.Decode(ty.REMOVE)
+		if err != nil {
+			return err
+		}
 		return
 	default:
 		return fmt.Errorf("unrecognized enum")
 	}
 }
-func (ty *Error) Error() string {
-	if ty.SetCodeFailed != nil {
-		return "SetCodeFailed"
-	}
 
-	if ty.MustCallByMainContract != nil {
-		return "MustCallByMainContract"
-	}
-	return "Unknown"
+type Tuple_106 struct { // Tuple
+	F0 uint64
+	F1 Pod
+	F2 []Tuple_108
 }
-
-type Tuple_48 struct { // Tuple
-	F0 uint32
+type Tuple_108 struct { // Tuple
+	F0 uint64
+	F1 Container
+}
+type Tuple_112 struct { // Tuple
+	F0 uint64
+	F1 uint32
+	F2 uint32
+	F3 byte
+}
+type Tuple_115 struct { // Tuple
+	F0 Pod
+	F1 []Tuple_108
+	F2 uint32
+	F3 byte
+}
+type Tuple_119 struct { // Tuple
+	F0 uint64
 	F1 Pod
+	F2 []Tuple_108
+	F3 uint32
+	F4 uint32
+	F5 byte
+}
+type Tuple_122 struct { // Tuple
+	F0 uint64
+	F1 Secret
 }
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/wetee-dao/ink.go/util"
+)
+
+// EventBox for code generator
+type EventBox struct {
+	PackageName string
+	Name        string
+	Events      []Event
+}
+
+// Event of contract
+type Event struct {
+	Label          string
+	StructName     string
+	SignatureTopic string
+	Docs           []string
+	Fields         []EventField
+}
+
+// EventField of event
+type EventField struct {
+	Name    string
+	Type    string
+	Indexed bool
+}
+
+// Parse events of contract
+func (r *ReviveGen) parseEvents() []Event {
+	events := make([]Event, 0, len(r.Abi.Spec.Events))
+	for _, e := range r.Abi.Spec.Events {
+		util.LogWithYellow("--------------------------------------------------event " + e.Label)
+		fields := make([]EventField, 0, len(e.Args))
+		for _, arg := range e.Args {
+			typeName := ""
+			if len(arg.Type.DisplayName) > 0 {
+				typeName = arg.Type.DisplayName[len(arg.Type.DisplayName)-1]
+			}
+			rtype := r.RecursionTypes(arg.Type.Type, typeName, 1)
+			fields = append(fields, EventField{
+				Name:    UnderscoreToCamelCase(arg.Label),
+				Type:    rtype[1],
+				Indexed: arg.Indexed,
+			})
+		}
+
+		// anonymous event has no signature topic, it can not be matched
+		if e.SignatureTopic == "" {
+			fmt.Println("skip anonymous event", e.Label)
+			continue
+		}
+
+		events = append(events, Event{
+			Label:          e.Label,
+			StructName:     UnderscoreToCamelCase(e.Label) + "Event",
+			SignatureTopic: strings.ToLower(e.SignatureTopic),
+			Docs:           e.Docs,
+			Fields:         fields,
+		})
+	}
+
+	return events
+}
+
+func eventGen(eventData EventBox) []byte {
+	t := template.Must(template.New("event").Parse(eventTemp))
+	var result bytes.Buffer
+	err := t.Execute(&result, eventData)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	return result.Bytes()
+}
+
+var eventTemp = `package {{.PackageName}}
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	chain "github.com/wetee-dao/ink.go"
+	"github.com/wetee-dao/ink.go/util"
+)
+
+{{ range .Events }}
+// Signature topic of {{.Label}} event
+const {{.StructName}}Topic = "{{.SignatureTopic}}"
+
+// {{.Label}} event
+{{- range .Docs }}
+// {{.}}
+{{- end }}
+type {{.StructName}} struct {
+	{{- range .Fields }}
+	{{.Name}} {{.Type}}{{if .Indexed}} // indexed{{end}}
+	{{- end }}
+}
+{{ end }}
+
+// Decode event data by signature topic, topics[0] is the signature topic of event
+func DecodeEvent(topics [][32]byte, data []byte) (any, error) {
+	if len(topics) == 0 {
+		return nil, chain.ErrUnknownEvent
+	}
+
+	switch types.Hash(topics[0]).Hex() {
+	{{- range .Events }}
+	case {{.StructName}}Topic:
+		v, err := chain.DecodeInkEvent[{{.StructName}}](data)
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	{{- end }}
+	}
+
+	return nil, chain.ErrUnknownEvent
+}
+
+{{ range .Events }}
+// Watch {{.Label}} event of contract
+func (c *{{$.Name}}) Watch{{.StructName}}() (*chain.EventSubscription[{{.StructName}}], error) {
+	return c.Watch{{.StructName}}Context(context.Background())
+}
+
+// Watch {{.Label}} event of contract, the subscription ends when ctx is done
+func (c *{{$.Name}}) Watch{{.StructName}}Context(ctx context.Context) (*chain.EventSubscription[{{.StructName}}], error) {
+	if c.ChainClient.IsDebug() {
+		util.LogWithCyan("[ Watch    event ]", "{{.Label}}")
+	}
+	return chain.WatchInkEventContext[{{.StructName}}](ctx, c, {{.StructName}}Topic)
+}
+{{ end }}
+`
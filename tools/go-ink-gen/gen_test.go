@@ -3,6 +3,9 @@ package main
 import (
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/wetee-dao/ink.go/util"
@@ -25,3 +28,145 @@ func TestGen(t *testing.T) {
 
 	revice.SaveTypes()
 }
+
+func TestEventGen(t *testing.T) {
+	data, err := os.ReadFile("../../example/contracts/pod.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revice, err := NewReviveGen(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revice.Abi.Spec.Events = []util.SpecEvent{
+		{
+			Label:          "Transferred",
+			SignatureTopic: "0xB5B61A3E6A21A16BE4F044B517C28AC692492F73C5BFD3F60178AD98C767F4CB",
+			Args: []util.EventArg{
+				{Label: "to", Indexed: true, Type: util.TypeWithDisplayName{DisplayName: []string{"Address"}, Type: 0}},
+				{Label: "value", Type: util.TypeWithDisplayName{DisplayName: []string{"U256"}, Type: 4}},
+			},
+		},
+		{
+			Label: "Anonymous",
+		},
+	}
+
+	events := revice.parseEvents()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+
+	code := eventGen(EventBox{PackageName: "pod", Name: "Pod", Events: events})
+	code, err = formatAndCleanCode(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []string{
+		"type TransferredEvent struct",
+		"To    types.H160 // indexed",
+		`const TransferredEventTopic = "0xb5b61a3e6a21a16be4f044b517c28ac692492f73c5bfd3f60178ad98c767f4cb"`,
+		"func DecodeEvent(topics [][32]byte, data []byte) (any, error)",
+		"func (c *Pod) WatchTransferredEvent() (*chain.EventSubscription[TransferredEvent], error)",
+	} {
+		if !strings.Contains(string(code), s) {
+			t.Fatalf("generated code missing %q:\n%s", s, code)
+		}
+	}
+}
+
+// 生成的事件代码与 pod 合约一起编译，在子进程中测试编码后的事件能被 DecodeEvent 解码
+// Generated event code is compiled with pod contract, and events encoded in a sub test are decoded by DecodeEvent
+func TestEventGenDecode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds generated package")
+	}
+
+	data, err := os.ReadFile("../../example/contracts/pod.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	revice, err := NewReviveGen(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revice.Abi.Spec.Events = []util.SpecEvent{
+		{
+			Label:          "Transferred",
+			SignatureTopic: "0xb5b61a3e6a21a16be4f044b517c28ac692492f73c5bfd3f60178ad98c767f4cb",
+			Args: []util.EventArg{
+				{Label: "to", Indexed: true, Type: util.TypeWithDisplayName{DisplayName: []string{"Address"}, Type: 0}},
+				{Label: "value", Type: util.TypeWithDisplayName{DisplayName: []string{"U256"}, Type: 4}},
+			},
+		},
+	}
+	code, err := formatAndCleanCode(eventGen(EventBox{PackageName: "pod", Name: "Pod", Events: revice.parseEvents()}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 以 _ 开头的目录不会被 ./... 匹配
+	// directories starting with _ are not matched by ./...
+	dir, err := os.MkdirTemp(".", "_eventgen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string][]byte{"events.go": code, "events_test.go": []byte(eventDecodeTest)}
+	for _, name := range []string{"calls.go", "types.go"} {
+		if files[name], err = os.ReadFile(filepath.Join("../../example/contracts/pod", name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), src, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := exec.Command("go", "test", "./"+filepath.Base(dir)).CombinedOutput()
+	if err != nil {
+		t.Fatalf("decode generated event: %v\n%s", err, out)
+	}
+}
+
+const eventDecodeTest = `package pod
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	chain "github.com/wetee-dao/ink.go"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	event := TransferredEvent{To: types.H160{1}, Value: types.NewU256(*big.NewInt(5))}
+	data, err := codec.Encode(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	topic, _ := types.NewHashFromHexString(TransferredEventTopic)
+
+	v, err := DecodeEvent([][32]byte{topic}, data)
+	decoded, ok := v.(*TransferredEvent)
+	if err != nil || !ok || decoded.To != event.To || decoded.Value.Cmp(event.Value.Int) != 0 {
+		t.Fatalf("DecodeEvent: %v %v", v, err)
+	}
+
+	for _, bad := range [][]byte{data[:len(data)-1], append(data, 0)} {
+		if v, err := DecodeEvent([][32]byte{topic}, bad); err == nil || v != nil {
+			t.Errorf("DecodeEvent %x: %#v %v", bad, v, err)
+		}
+	}
+	if v, err := DecodeEvent([][32]byte{{1}}, data); !errors.Is(err, chain.ErrUnknownEvent) || v != nil {
+		t.Errorf("unknown event: %#v %v", v, err)
+	}
+}
+`
@@ -134,6 +134,14 @@ func (r *ReviveGen) SaveTypes() {
 		})
 	}
 
+	/// Parse events
+	events := EventBox{
+		PackageName: name,
+		Name:        calls.Name,
+		Events:      r.parseEvents(),
+	}
+	calls.Events = events.Events
+
 	var typeData = "package " + name + "\n"
 	typeData += "import (\n"
 	typeData += "  \"github.com/wetee-dao/ink.go/util\"\n"
@@ -180,6 +188,20 @@ func (r *ReviveGen) SaveTypes() {
 		log.Fatal(err)
 	}
 
+	os.Remove("./" + name + "/events.go")
+	if len(events.Events) == 0 {
+		return
+	}
+	eventData := eventGen(events)
+	eventData, err = formatAndCleanCode(eventData)
+	if err != nil {
+		log.Fatalf("Error formatting and cleaning code: %v", err)
+	}
+
+	err = os.WriteFile("./"+name+"/events.go", eventData, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
 }
 
 func (r *ReviveGen) GetReturnValue(ty int, name string, level int) (sty int, sname string) {
@@ -0,0 +1,41 @@
+package ink
+
+import (
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc"
+)
+
+// rpc 传输层，决定如何连接节点，可替换为录制、回放等实现
+// Transport of rpc, it decides how nodes are connected and can be replaced by recording or replaying implementations
+type Transport interface {
+	Dial(url string) (client.Client, error)
+}
+
+// websocket 传输层，默认使用
+// Websocket transport, used by default
+type WebsocketTransport struct{}
+
+func (WebsocketTransport) Dial(url string) (client.Client, error) {
+	return client.Connect(url)
+}
+
+// 通过传输层连接 url
+// Connect to url with transport
+func newSubstrateAPI(transport Transport, url string) (*gsrpc.SubstrateAPI, error) {
+	cl, err := transport.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := rpc.NewRPC(cl)
+	if err != nil {
+		cl.Close()
+		return nil, err
+	}
+
+	return &gsrpc.SubstrateAPI{
+		RPC:    r,
+		Client: cl,
+	}, nil
+}
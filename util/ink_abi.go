@@ -8,6 +8,7 @@ type InkAbi struct {
 	Contract Contract  `json:"contract"`
 	Spec     Spec      `json:"spec"`
 	Types    []AbiType `json:"types"`
+	Storage  Storage   `json:"storage"`
 	Version  int       `json:"version,omitempty"`
 }
 
@@ -25,13 +26,17 @@ type Spec struct {
 }
 
 type SpecEvent struct {
-	Args  []EventArg `json:"args"`
-	Docs  []string   `json:"docs"`
-	Label string     `json:"label"`
+	Args       []EventArg `json:"args"`
+	Docs       []string   `json:"docs"`
+	Label      string     `json:"label"`
+	ModulePath string     `json:"module_path"`
+	// blake2b_256 of the event signature, empty for anonymous events
+	SignatureTopic string `json:"signature_topic"`
 }
 type EventArg struct {
 	Docs    []string            `json:"docs"`
 	Indexed bool                `json:"indexed"`
+	Label   string              `json:"label"`
 	Payable bool                `json:"payable"`
 	Type    TypeWithDisplayName `json:"type"`
 }
@@ -62,9 +67,15 @@ type AbiType struct {
 }
 
 type AbiSubType struct {
-	Path []string `json:"path,omitempty"`
-	Def  Def      `json:"def"`
-	Docs []string `json:"docs,omitempty"`
+	Path   []string    `json:"path,omitempty"`
+	Params []TypeParam `json:"params,omitempty"`
+	Def    Def         `json:"def"`
+	Docs   []string    `json:"docs,omitempty"`
+}
+
+type TypeParam struct {
+	Name string `json:"name"`
+	Type *int   `json:"type"` // nil for phantom params
 }
 
 type Def struct {
@@ -129,6 +140,56 @@ type DefBitSequence struct {
 	BitOrderType int `json:"bitOrderType"`
 }
 
+// 合约存储布局
+// Storage layout of contract
+type Storage struct {
+	Root *RootLayout `json:"root,omitempty"`
+}
+
+// 独立存储单元，如根结构体、Mapping 和 Lazy
+// Layout stored in its own cell, such as the root struct, Mapping and Lazy
+type RootLayout struct {
+	Layout  Layout `json:"layout"`
+	RootKey string `json:"root_key"`
+	Ty      int    `json:"ty"`
+}
+
+type Layout struct {
+	Leaf   *LeafLayout   `json:"leaf,omitempty"`
+	Root   *RootLayout   `json:"root,omitempty"`
+	Hash   any           `json:"hash,omitempty"`
+	Array  *ArrayLayout  `json:"array,omitempty"`
+	Struct *StructLayout `json:"struct,omitempty"`
+	Enum   *EnumLayout   `json:"enum,omitempty"`
+}
+
+type LeafLayout struct {
+	Key string `json:"key"`
+	Ty  int    `json:"ty"`
+}
+
+type ArrayLayout struct {
+	Len    int    `json:"len"`
+	Layout Layout `json:"layout"`
+	Offset string `json:"offset"`
+}
+
+type StructLayout struct {
+	Name   string        `json:"name"`
+	Fields []FieldLayout `json:"fields"`
+}
+
+type FieldLayout struct {
+	Name   string `json:"name"`
+	Layout Layout `json:"layout"`
+}
+
+type EnumLayout struct {
+	Name        string                  `json:"name"`
+	DispatchKey string                  `json:"dispatchKey"`
+	Variants    map[string]StructLayout `json:"variants"`
+}
+
 func InitAbi(raw []byte) (*InkAbi, error) {
 	var abi InkAbi
 	err := json.Unmarshal(raw, &abi)
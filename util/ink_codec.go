@@ -0,0 +1,746 @@
+package util
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// 根据 ABI 类型注册表编解码的动态值
+// Dynamic values encoded and decoded with the type registry of ABI
+//
+// Go value         <-> ABI type
+// bool             <-> bool
+// number / string  <-> u8..u256, i8..i256, Compact, U256 (u128 and wider decode to decimal string)
+// string           <-> str
+// "0x.." hex       <-> Vec<u8>, [u8; N] and newtypes of them such as H160
+// []any            <-> Vec<T>, [T; N], tuples and structs with unnamed fields
+// map[string]any   <-> structs with named fields
+// nil / value      <-> Option<T>
+// "Name" or {"Name": fields} <-> enum variants
+
+// RawArg 为已经 SCALE 编码的参数，编码时原样写入
+// RawArg is a SCALE encoded argument, it is written as is
+type RawArg []byte
+
+func (r RawArg) Encode(encoder scale.Encoder) error {
+	return encoder.Write(r)
+}
+
+// RawBytes 解码时读取剩余全部数据
+// RawBytes takes all remaining bytes when decoding
+type RawBytes []byte
+
+func (r *RawBytes) Decode(decoder scale.Decoder) error {
+	*r = (*r)[:0]
+	for {
+		b, err := decoder.ReadOneByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		*r = append(*r, b)
+	}
+}
+
+// 获取类型
+// Get type of id from registry
+func (a *InkAbi) TypeOf(id int) (*AbiSubType, error) {
+	if id >= 0 && id < len(a.Types) && a.Types[id].Id == id {
+		return &a.Types[id].Type, nil
+	}
+	for i := range a.Types {
+		if a.Types[i].Id == id {
+			return &a.Types[i].Type, nil
+		}
+	}
+	return nil, fmt.Errorf("abi: type %d not found", id)
+}
+
+// 获取合约方法
+// Get message by label
+func (a *InkAbi) Message(label string) (*Message, error) {
+	for i := range a.Spec.Messages {
+		if a.Spec.Messages[i].Label == label {
+			return &a.Spec.Messages[i], nil
+		}
+	}
+	return nil, fmt.Errorf("abi: message %s not found", label)
+}
+
+// 获取合约构造函数
+// Get constructor by label
+func (a *InkAbi) Constructor(label string) (*Message, error) {
+	for i := range a.Spec.Constructors {
+		if a.Spec.Constructors[i].Label == label {
+			return &a.Spec.Constructors[i], nil
+		}
+	}
+	return nil, fmt.Errorf("abi: constructor %s not found", label)
+}
+
+// 编码合约方法参数，args 为按参数名的 map、按顺序的数组或 JSON
+// Encode arguments of message, args is a map by label, a positional slice or JSON of them
+func (a *InkAbi) EncodeArgs(msg *Message, args any) ([]any, error) {
+	args, err := normalizeJSON(args)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]any, len(msg.Args))
+	switch v := args.(type) {
+	case nil:
+		if len(msg.Args) > 0 {
+			return nil, fmt.Errorf("abi: %s expects %d args", msg.Label, len(msg.Args))
+		}
+	case map[string]any:
+		for i, arg := range msg.Args {
+			value, ok := v[arg.Label]
+			if !ok {
+				return nil, fmt.Errorf("abi: %s missing arg %s", msg.Label, arg.Label)
+			}
+			values[i] = value
+		}
+	case []any:
+		if len(v) != len(msg.Args) {
+			return nil, fmt.Errorf("abi: %s expects %d args, got %d", msg.Label, len(msg.Args), len(v))
+		}
+		copy(values, v)
+	default:
+		return nil, fmt.Errorf("abi: unsupported args %T", args)
+	}
+
+	encoded := make([]any, len(msg.Args))
+	for i, arg := range msg.Args {
+		bt, err := a.EncodeValue(arg.Type.Type, values[i])
+		if err != nil {
+			return nil, fmt.Errorf("abi: %s arg %s: %w", msg.Label, arg.Label, err)
+		}
+		encoded[i] = RawArg(bt)
+	}
+
+	return encoded, nil
+}
+
+// 按类型编码值
+// SCALE encode value as type id
+func (a *InkAbi) EncodeValue(typeId int, value any) ([]byte, error) {
+	value, err := normalizeJSON(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = a.encodeValue(&buf, typeId, value)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// 按类型解码值，数据必须被完全读取
+// SCALE decode data as type id, all data must be consumed
+func (a *InkAbi) DecodeValue(typeId int, data []byte) (any, error) {
+	r := bytes.NewReader(data)
+	v, err := a.decodeValue(r, typeId)
+	if err != nil {
+		return nil, err
+	}
+	if r.Len() > 0 {
+		return nil, fmt.Errorf("abi: %d bytes left after decoding type %d", r.Len(), typeId)
+	}
+	return v, nil
+}
+
+// 按类型解码值并转换为 JSON
+// SCALE decode data as type id and marshal it to JSON
+func (a *InkAbi) DecodeValueJSON(typeId int, data []byte) (json.RawMessage, error) {
+	v, err := a.DecodeValue(typeId, data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// 根据签名 topic 获取事件
+// Get event by signature topic
+func (a *InkAbi) Event(signatureTopic string) (*SpecEvent, error) {
+	for i := range a.Spec.Events {
+		if a.Spec.Events[i].SignatureTopic != "" && strings.EqualFold(a.Spec.Events[i].SignatureTopic, signatureTopic) {
+			return &a.Spec.Events[i], nil
+		}
+	}
+	return nil, fmt.Errorf("abi: event %s not found", signatureTopic)
+}
+
+// 解码事件数据，按参数名返回 JSON
+// Decode event data to JSON object keyed by arg label
+func (a *InkAbi) DecodeEventJSON(event *SpecEvent, data []byte) (json.RawMessage, error) {
+	r := bytes.NewReader(data)
+	m := make(map[string]any, len(event.Args))
+	for _, arg := range event.Args {
+		v, err := a.decodeValue(r, arg.Type.Type)
+		if err != nil {
+			return nil, fmt.Errorf("abi: event %s arg %s: %w", event.Label, arg.Label, err)
+		}
+		m[arg.Label] = v
+	}
+	if r.Len() > 0 {
+		return nil, fmt.Errorf("abi: %d bytes left after decoding event %s", r.Len(), event.Label)
+	}
+	return json.Marshal(m)
+}
+
+func (a *InkAbi) encodeValue(buf *bytes.Buffer, typeId int, value any) error {
+	ty, err := a.TypeOf(typeId)
+	if err != nil {
+		return err
+	}
+	def := ty.Def
+
+	switch {
+	case isU256(ty):
+		return encodePrimitive(buf, "u256", value)
+	case def.Primitive != nil:
+		return encodePrimitive(buf, string(*def.Primitive), value)
+	case def.Compact != nil:
+		n, err := toBigInt(value)
+		if err != nil {
+			return err
+		}
+		bt, err := Encode(types.NewUCompact(n))
+		if err != nil {
+			return err
+		}
+		buf.Write(bt)
+		return nil
+	case def.Sequence != nil:
+		if a.isU8(def.Sequence.Type) {
+			bt, err := toBytes(value)
+			if err != nil {
+				return err
+			}
+			writeCompactLen(buf, len(bt))
+			buf.Write(bt)
+			return nil
+		}
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("expect array, got %T", value)
+		}
+		writeCompactLen(buf, len(items))
+		for _, item := range items {
+			if err := a.encodeValue(buf, def.Sequence.Type, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case def.Array != nil:
+		if a.isU8(def.Array.Type) {
+			bt, err := toBytes(value)
+			if err != nil {
+				return err
+			}
+			if len(bt) != def.Array.Len {
+				return fmt.Errorf("expect %d bytes, got %d", def.Array.Len, len(bt))
+			}
+			buf.Write(bt)
+			return nil
+		}
+		items, ok := value.([]any)
+		if !ok || len(items) != def.Array.Len {
+			return fmt.Errorf("expect array of %d items", def.Array.Len)
+		}
+		for _, item := range items {
+			if err := a.encodeValue(buf, def.Array.Type, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case def.Tuple != nil:
+		if len(*def.Tuple) == 0 {
+			return nil
+		}
+		items, ok := value.([]any)
+		if !ok || len(items) != len(*def.Tuple) {
+			return fmt.Errorf("expect tuple of %d items", len(*def.Tuple))
+		}
+		for i, id := range *def.Tuple {
+			if err := a.encodeValue(buf, id, items[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case def.Composite != nil:
+		return a.encodeFields(buf, def.Composite.Fields, value)
+	case def.Variant != nil:
+		if isOption(ty) {
+			if value == nil {
+				buf.WriteByte(0)
+				return nil
+			}
+			for _, variant := range def.Variant.Variants {
+				if variant.Name == "Some" && len(variant.Fields) == 1 {
+					buf.WriteByte(byte(variant.Index))
+					return a.encodeValue(buf, variant.Fields[0].Type, value)
+				}
+			}
+			return errors.New("invalid Option type")
+		}
+
+		name, fields := "", any(nil)
+		switch v := value.(type) {
+		case string:
+			name = v
+		case map[string]any:
+			if len(v) != 1 {
+				return errors.New("expect enum as {\"Variant\": value}")
+			}
+			for k, f := range v {
+				name, fields = k, f
+			}
+		default:
+			return fmt.Errorf("expect enum variant, got %T", value)
+		}
+
+		for _, variant := range def.Variant.Variants {
+			if variant.Name == name {
+				buf.WriteByte(byte(variant.Index))
+				return a.encodeFields(buf, variant.Fields, fields)
+			}
+		}
+		return fmt.Errorf("unknown variant %s", name)
+	}
+
+	return fmt.Errorf("unsupported type %d", typeId)
+}
+
+func (a *InkAbi) encodeFields(buf *bytes.Buffer, fields []SubField, value any) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	if fields[0].Name != "" {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("expect object, got %T", value)
+		}
+		for _, f := range fields {
+			v, ok := m[f.Name]
+			if !ok {
+				return fmt.Errorf("missing field %s", f.Name)
+			}
+			if err := a.encodeValue(buf, f.Type, v); err != nil {
+				return fmt.Errorf("field %s: %w", f.Name, err)
+			}
+		}
+		return nil
+	}
+
+	if len(fields) == 1 {
+		return a.encodeValue(buf, fields[0].Type, value)
+	}
+
+	items, ok := value.([]any)
+	if !ok || len(items) != len(fields) {
+		return fmt.Errorf("expect array of %d items", len(fields))
+	}
+	for i, f := range fields {
+		if err := a.encodeValue(buf, f.Type, items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *InkAbi) decodeValue(r *bytes.Reader, typeId int) (any, error) {
+	ty, err := a.TypeOf(typeId)
+	if err != nil {
+		return nil, err
+	}
+	def := ty.Def
+
+	switch {
+	case isU256(ty):
+		return decodePrimitive(r, "u256")
+	case def.Primitive != nil:
+		return decodePrimitive(r, string(*def.Primitive))
+	case def.Compact != nil:
+		n, err := scale.NewDecoder(r).DecodeUintCompact()
+		if err != nil {
+			return nil, err
+		}
+		if n.IsUint64() {
+			return n.Uint64(), nil
+		}
+		return n.String(), nil
+	case def.Sequence != nil:
+		n, err := scale.NewDecoder(r).DecodeUintCompact()
+		if err != nil {
+			return nil, err
+		}
+		// 长度来自链上数据，不能超过剩余数据
+		// length comes from chain data, it can not exceed the remaining data
+		if !n.IsUint64() || n.Uint64() > uint64(r.Len()) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if a.isU8(def.Sequence.Type) {
+			return readHex(r, int(n.Uint64()))
+		}
+		return a.decodeItems(r, def.Sequence.Type, int(n.Uint64()))
+	case def.Array != nil:
+		if a.isU8(def.Array.Type) {
+			return readHex(r, def.Array.Len)
+		}
+		return a.decodeItems(r, def.Array.Type, def.Array.Len)
+	case def.Tuple != nil:
+		if len(*def.Tuple) == 0 {
+			return nil, nil
+		}
+		items := make([]any, 0, len(*def.Tuple))
+		for _, id := range *def.Tuple {
+			v, err := a.decodeValue(r, id)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		}
+		return items, nil
+	case def.Composite != nil:
+		return a.decodeFields(r, def.Composite.Fields)
+	case def.Variant != nil:
+		index, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		for _, variant := range def.Variant.Variants {
+			if variant.Index != int(index) {
+				continue
+			}
+			if isOption(ty) {
+				if len(variant.Fields) == 0 {
+					return nil, nil
+				}
+				return a.decodeValue(r, variant.Fields[0].Type)
+			}
+			if len(variant.Fields) == 0 {
+				return variant.Name, nil
+			}
+			fields, err := a.decodeFields(r, variant.Fields)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{variant.Name: fields}, nil
+		}
+		return nil, fmt.Errorf("unknown variant index %d of type %d", index, typeId)
+	}
+
+	return nil, fmt.Errorf("unsupported type %d", typeId)
+}
+
+func (a *InkAbi) decodeItems(r *bytes.Reader, typeId int, n int) ([]any, error) {
+	items := make([]any, 0, min(n, r.Len()))
+	for range n {
+		v, err := a.decodeValue(r, typeId)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+	}
+	return items, nil
+}
+
+func (a *InkAbi) decodeFields(r *bytes.Reader, fields []SubField) (any, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	if fields[0].Name != "" {
+		m := make(map[string]any, len(fields))
+		for _, f := range fields {
+			v, err := a.decodeValue(r, f.Type)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			m[f.Name] = v
+		}
+		return m, nil
+	}
+
+	if len(fields) == 1 {
+		return a.decodeValue(r, fields[0].Type)
+	}
+
+	items := make([]any, 0, len(fields))
+	for _, f := range fields {
+		v, err := a.decodeValue(r, f.Type)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+	}
+	return items, nil
+}
+
+func (a *InkAbi) isU8(typeId int) bool {
+	ty, err := a.TypeOf(typeId)
+	return err == nil && ty.Def.Primitive != nil && *ty.Def.Primitive == "u8"
+}
+
+// primitive_types::U256 按数字处理，而不是 [u64; 4]
+// primitive_types::U256 is mapped to number instead of [u64; 4]
+func isU256(ty *AbiSubType) bool {
+	return len(ty.Path) == 2 && ty.Path[0] == "primitive_types" && ty.Path[1] == "U256"
+}
+
+func isOption(ty *AbiSubType) bool {
+	return len(ty.Path) == 1 && ty.Path[0] == "Option" && len(ty.Def.Variant.Variants) == 2
+}
+
+// 整数位宽，signed 表示有符号
+// bits of integer primitive
+func intBits(primitive string) (bits int, signed bool, ok bool) {
+	if len(primitive) < 2 || (primitive[0] != 'u' && primitive[0] != 'i') {
+		return 0, false, false
+	}
+	n, err := strconv.Atoi(primitive[1:])
+	if err != nil {
+		return 0, false, false
+	}
+	return n, primitive[0] == 'i', true
+}
+
+func encodePrimitive(buf *bytes.Buffer, primitive string, value any) error {
+	switch primitive {
+	case "bool":
+		b, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("expect bool, got %T", value)
+		}
+		if b {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+		return nil
+	case "str":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expect string, got %T", value)
+		}
+		writeCompactLen(buf, len(s))
+		buf.WriteString(s)
+		return nil
+	case "char":
+		s, ok := value.(string)
+		if !ok || len([]rune(s)) != 1 {
+			return fmt.Errorf("expect char, got %v", value)
+		}
+		return encodePrimitive(buf, "u32", int64([]rune(s)[0]))
+	}
+
+	bits, signed, ok := intBits(primitive)
+	if !ok {
+		return fmt.Errorf("unsupported primitive %s", primitive)
+	}
+
+	n, err := toBigInt(value)
+	if err != nil {
+		return err
+	}
+
+	size := bits / 8
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	v := new(big.Int).Set(n)
+	if signed {
+		half := new(big.Int).Rsh(limit, 1)
+		if v.Cmp(half) >= 0 || v.Cmp(new(big.Int).Neg(half)) < 0 {
+			return fmt.Errorf("%s overflow: %s", primitive, n.String())
+		}
+		if v.Sign() < 0 {
+			v.Add(v, limit)
+		}
+	} else if v.Sign() < 0 || v.Cmp(limit) >= 0 {
+		return fmt.Errorf("%s overflow: %s", primitive, n.String())
+	}
+
+	be := v.FillBytes(make([]byte, size))
+	for i := size - 1; i >= 0; i-- {
+		buf.WriteByte(be[i])
+	}
+	return nil
+}
+
+func decodePrimitive(r *bytes.Reader, primitive string) (any, error) {
+	switch primitive {
+	case "bool":
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		return b == 1, nil
+	case "str":
+		n, err := scale.NewDecoder(r).DecodeUintCompact()
+		if err != nil {
+			return nil, err
+		}
+		if !n.IsUint64() || n.Uint64() > uint64(r.Len()) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		bt := make([]byte, n.Uint64())
+		if _, err := io.ReadFull(r, bt); err != nil {
+			return nil, err
+		}
+		return string(bt), nil
+	case "char":
+		v, err := decodePrimitive(r, "u32")
+		if err != nil {
+			return nil, err
+		}
+		return string(rune(v.(uint64))), nil
+	}
+
+	bits, signed, ok := intBits(primitive)
+	if !ok {
+		return nil, fmt.Errorf("unsupported primitive %s", primitive)
+	}
+
+	size := bits / 8
+	le := make([]byte, size)
+	if _, err := io.ReadFull(r, le); err != nil {
+		return nil, err
+	}
+	be := make([]byte, size)
+	for i := range le {
+		be[size-1-i] = le[i]
+	}
+
+	n := new(big.Int).SetBytes(be)
+	if signed && le[size-1]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(bits)))
+	}
+	return bigToValue(n, bits), nil
+}
+
+// 64 位以内返回数字，否则返回十进制字符串
+// integers up to 64 bits are numbers, wider ones are decimal strings
+func bigToValue(n *big.Int, bits int) any {
+	if bits <= 64 {
+		if n.Sign() < 0 {
+			return n.Int64()
+		}
+		return n.Uint64()
+	}
+	return n.String()
+}
+
+func toBigInt(value any) (*big.Int, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return parseBigInt(v.String())
+	case string:
+		return parseBigInt(v)
+	case float64:
+		if v != float64(int64(v)) {
+			return nil, fmt.Errorf("expect integer, got %v", v)
+		}
+		return big.NewInt(int64(v)), nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case int32:
+		return big.NewInt(int64(v)), nil
+	case uint:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case uint32:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint8:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case *big.Int:
+		return v, nil
+	case big.Int:
+		return &v, nil
+	}
+	return nil, fmt.Errorf("expect integer, got %T", value)
+}
+
+func parseBigInt(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %s", s)
+	}
+	return n, nil
+}
+
+func toBytes(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case string:
+		if strings.HasPrefix(v, "0x") {
+			return hex.DecodeString(v[2:])
+		}
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case []any:
+		bt := make([]byte, 0, len(v))
+		for _, item := range v {
+			n, err := toBigInt(item)
+			if err != nil || n.Sign() < 0 || n.BitLen() > 8 {
+				return nil, fmt.Errorf("invalid byte %v", item)
+			}
+			bt = append(bt, byte(n.Uint64()))
+		}
+		return bt, nil
+	}
+	return nil, fmt.Errorf("expect hex string or bytes, got %T", value)
+}
+
+func readHex(r *bytes.Reader, n int) (string, error) {
+	if n > r.Len() {
+		return "", io.ErrUnexpectedEOF
+	}
+	bt := make([]byte, n)
+	if _, err := io.ReadFull(r, bt); err != nil {
+		return "", err
+	}
+	return "0x" + hex.EncodeToString(bt), nil
+}
+
+func writeCompactLen(buf *bytes.Buffer, n int) {
+	bt, _ := Encode(types.NewUCompactFromUInt(uint64(n)))
+	buf.Write(bt)
+}
+
+// 把 JSON 数据解析为 any，数字保持为 json.Number
+// Unmarshal JSON input to any, numbers are kept as json.Number
+func normalizeJSON(value any) (any, error) {
+	var raw []byte
+	switch v := value.(type) {
+	case json.RawMessage:
+		raw = v
+	default:
+		return value, nil
+	}
+
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	var out any
+	if err := d.Decode(&out); err != nil {
+		return nil, errors.New("abi: invalid JSON: " + err.Error())
+	}
+	return out, nil
+}
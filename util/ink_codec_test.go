@@ -0,0 +1,103 @@
+package util
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestInkCodec(t *testing.T) {
+	raw, err := os.ReadFile("../example/contracts/pod.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	abi, err := InitAbi(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := abi.Message("pay_for_woker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	args, err := abi.EncodeArgs(msg, json.RawMessage(`{"worker":"0x0c17c8bf3e4054632f59c2ea44a7efce60804642","amount":"1000"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(args) != 2 {
+		t.Fatalf("expect 2 args, got %d", len(args))
+	}
+	if got := hex.EncodeToString(args[0].(RawArg)); got != "0c17c8bf3e4054632f59c2ea44a7efce60804642" {
+		t.Errorf("worker encoded to %s", got)
+	}
+	amount := args[1].(RawArg)
+	if len(amount) != 32 || amount[0] != 0xe8 || amount[1] != 0x03 {
+		t.Errorf("amount encoded to %x", []byte(amount))
+	}
+
+	// MessageResult<Result<(), Error>>
+	cases := []struct {
+		data string
+		want string
+	}{
+		{"0000", `{"Ok":{"Ok":null}}`},
+		{"000102", `{"Ok":{"Err":"InsufficientBalance"}}`},
+		{"0101", `{"Err":"CouldNotReadInput"}`},
+	}
+	for _, c := range cases {
+		data, _ := hex.DecodeString(c.data)
+		got, err := abi.DecodeValueJSON(msg.ReturnType.Type, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != c.want {
+			t.Errorf("decode %s: got %s, want %s", c.data, got, c.want)
+		}
+	}
+
+	if _, err := abi.DecodeValue(msg.ReturnType.Type, []byte{0, 0, 0}); err == nil {
+		t.Error("expect error of trailing bytes")
+	}
+}
+
+func TestInkCodecHugeLength(t *testing.T) {
+	raw, err := os.ReadFile("../example/contracts/cloud.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	abi, err := InitAbi(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 长度为 2^64-1 和 2^32 的 compact 前缀，数据被截断
+	// compact length of 2^64-1 and 2^32, data is truncated
+	lengths := [][]byte{
+		{0x13, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		{0x07, 0x00, 0x00, 0x00, 0x00, 0x01},
+	}
+	for _, length := range lengths {
+		data := append(length, 1, 2, 3)
+
+		// Vec<u8> 和其他类型的 Vec
+		// Vec<u8> and Vec of other types
+		for _, typeId := range []int{6, 60} {
+			if _, err := abi.DecodeValue(typeId, data); !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("decode type %d of length %x: %v", typeId, length, err)
+			}
+		}
+
+		event := &SpecEvent{Label: "Huge", Args: []EventArg{{Label: "items", Type: TypeWithDisplayName{Type: 60}}}}
+		if _, err := abi.DecodeEventJSON(event, data); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("decode event of length %x: %v", length, err)
+		}
+
+		if _, err := decodePrimitive(bytes.NewReader(data), "str"); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("decode str of length %x: %v", length, err)
+		}
+	}
+}
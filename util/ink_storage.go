@@ -0,0 +1,228 @@
+package util
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// 合约存储项类型
+// Kind of contract storage entry
+type StorageKind uint8
+
+const (
+	// 与同一单元中的其他字段打包存储
+	// packed with other fields in the cell of its nearest root layout
+	StoragePacked StorageKind = iota
+	// ink_storage::Lazy，单独存储在自己的单元中
+	// ink_storage::Lazy, stored in its own cell
+	StorageLazy
+	// ink_storage::Mapping，每个键对应一个单元
+	// ink_storage::Mapping, every key has its own cell
+	StorageMapping
+)
+
+func (k StorageKind) String() string {
+	switch k {
+	case StoragePacked:
+		return "packed"
+	case StorageLazy:
+		return "lazy"
+	case StorageMapping:
+		return "mapping"
+	}
+	return "unknown"
+}
+
+// 合约存储项，由 ABI 存储布局解析得到
+// Entry of contract storage resolved from the storage layout of ABI
+type StorageEntry struct {
+	Path string
+	Kind StorageKind
+	// 存储单元的 key，即 SCALE 编码的 ink! Key
+	// key of storage cell, the SCALE encoded ink! Key
+	CellKey []byte
+	// 存储单元的类型，Packed 时有效
+	// type of storage cell, only for packed entry
+	CellType int
+	// 值的类型
+	// type of value
+	Type int
+	// Mapping 键的类型
+	// type of Mapping key
+	KeyType int
+
+	// field indexes from CellType to Type
+	fields []int
+}
+
+// 根据字段路径解析存储项，路径为以 . 分隔的字段名，空路径为根结构体
+// Resolve storage entry by path of field names separated by ".", empty path is the root struct
+func (a *InkAbi) StorageEntry(path string) (*StorageEntry, error) {
+	root := a.Storage.Root
+	if root == nil {
+		return nil, errors.New("abi: storage layout not found")
+	}
+
+	entry := &StorageEntry{Path: path}
+	if err := a.enterRoot(entry, root); err != nil {
+		return nil, err
+	}
+
+	layout := root.Layout
+	var segments []string
+	if path != "" {
+		segments = strings.Split(path, ".")
+	}
+	for _, name := range segments {
+		if entry.Kind != StoragePacked {
+			return nil, fmt.Errorf("abi: storage %s: %s has no fields", path, entry.Kind)
+		}
+		if layout.Struct == nil {
+			return nil, fmt.Errorf("abi: storage %s: %s is not a struct", path, name)
+		}
+
+		i := slices.IndexFunc(layout.Struct.Fields, func(f FieldLayout) bool { return f.Name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("abi: storage %s: field %s not found", path, name)
+		}
+		if err := a.enterField(entry, name); err != nil {
+			return nil, fmt.Errorf("abi: storage %s: %w", path, err)
+		}
+
+		layout = layout.Struct.Fields[i].Layout
+		if layout.Root != nil {
+			if err := a.enterRoot(entry, layout.Root); err != nil {
+				return nil, fmt.Errorf("abi: storage %s: %w", path, err)
+			}
+			layout = layout.Root.Layout
+		}
+	}
+
+	return entry, nil
+}
+
+// 进入新的存储单元
+// Enter a new storage cell
+func (a *InkAbi) enterRoot(entry *StorageEntry, root *RootLayout) error {
+	key, err := hex.DecodeString(strings.TrimPrefix(root.RootKey, "0x"))
+	if err != nil {
+		return fmt.Errorf("root key %s: %w", root.RootKey, err)
+	}
+	ty, err := a.TypeOf(root.Ty)
+	if err != nil {
+		return err
+	}
+
+	entry.CellKey = key
+	entry.fields = nil
+	if len(ty.Path) < 2 || ty.Path[0] != "ink_storage" {
+		entry.Kind = StoragePacked
+		entry.CellType = root.Ty
+		entry.Type = root.Ty
+		return nil
+	}
+
+	switch ty.Path[len(ty.Path)-1] {
+	case "Mapping":
+		entry.Kind = StorageMapping
+		entry.KeyType, err = typeParam(ty, "K")
+		if err != nil {
+			return err
+		}
+		entry.Type, err = typeParam(ty, "V")
+		return err
+	case "Lazy":
+		entry.Kind = StorageLazy
+		entry.Type, err = typeParam(ty, "V")
+		return err
+	}
+
+	return fmt.Errorf("unsupported storage type %s", strings.Join(ty.Path, "::"))
+}
+
+// 进入打包存储的字段，布局中的包装结构体（如合约引用）在类型中是透明的
+// Enter field of packed struct, wrapper structs of layout (such as contract refs) are transparent in types
+func (a *InkAbi) enterField(entry *StorageEntry, name string) error {
+	ty, err := a.TypeOf(entry.Type)
+	if err != nil {
+		return err
+	}
+	if ty.Def.Composite == nil {
+		return fmt.Errorf("type %d is not a struct", entry.Type)
+	}
+
+	fields := ty.Def.Composite.Fields
+	i := slices.IndexFunc(fields, func(f SubField) bool { return f.Name == name })
+	if i >= 0 {
+		entry.fields = append(entry.fields, i)
+		entry.Type = fields[i].Type
+		return nil
+	}
+	if len(fields) == 1 {
+		return nil
+	}
+
+	return fmt.Errorf("field %s not found in type %d", name, entry.Type)
+}
+
+func typeParam(ty *AbiSubType, name string) (int, error) {
+	for _, p := range ty.Params {
+		if p.Name == name && p.Type != nil {
+			return *p.Type, nil
+		}
+	}
+	return 0, fmt.Errorf("type %s has no param %s", strings.Join(ty.Path, "::"), name)
+}
+
+// 存储项的单元 key，Mapping 需要传入 SCALE 编码的键
+// Key of storage cell, the SCALE encoded key is required by Mapping
+func (e *StorageEntry) Key(mapKey []byte) ([]byte, error) {
+	if e.Kind != StorageMapping {
+		if mapKey != nil {
+			return nil, fmt.Errorf("abi: storage %s is %s, it has no key", e.Path, e.Kind)
+		}
+		return e.CellKey, nil
+	}
+	if mapKey == nil {
+		return nil, fmt.Errorf("abi: storage %s is mapping, key is required", e.Path)
+	}
+
+	key := make([]byte, 0, len(e.CellKey)+len(mapKey))
+	key = append(key, e.CellKey...)
+	return append(key, mapKey...), nil
+}
+
+// 从存储单元数据中取出存储项的 SCALE 编码值
+// Take the SCALE encoded value of entry from the data of its storage cell
+func (a *InkAbi) StorageValue(e *StorageEntry, cell []byte) ([]byte, error) {
+	if e.Kind != StoragePacked {
+		return cell, nil
+	}
+
+	r := bytes.NewReader(cell)
+	ty := e.CellType
+	for _, index := range e.fields {
+		t, err := a.TypeOf(ty)
+		if err != nil {
+			return nil, err
+		}
+		fields := t.Def.Composite.Fields
+		for _, f := range fields[:index] {
+			if _, err := a.decodeValue(r, f.Type); err != nil {
+				return nil, fmt.Errorf("abi: storage %s skip field %s: %w", e.Path, f.Name, err)
+			}
+		}
+		ty = fields[index].Type
+	}
+
+	start := len(cell) - r.Len()
+	if _, err := a.decodeValue(r, ty); err != nil {
+		return nil, fmt.Errorf("abi: storage %s: %w", e.Path, err)
+	}
+
+	return cell[start : len(cell)-r.Len()], nil
+}
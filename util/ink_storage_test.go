@@ -0,0 +1,106 @@
+package util
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"testing"
+)
+
+func TestStorageEntry(t *testing.T) {
+	raw, err := os.ReadFile("../example/contracts/cloud.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	abi, err := InitAbi(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		path    string
+		kind    StorageKind
+		cellKey string
+		ty      int
+	}{
+		{"", StoragePacked, "00000000", abi.Storage.Root.Ty},
+		{"mint_interval", StoragePacked, "00000000", 7},
+		{"subnet.inner.addr", StoragePacked, "00000000", 0},
+		{"pods.next_id", StoragePacked, "00000000", 5},
+		{"pods.store", StorageMapping, "0c4ca8e4", 9},
+		{"pod_version", StorageMapping, "02e9fa25", 7},
+	}
+	for _, c := range cases {
+		entry, err := abi.StorageEntry(c.path)
+		if err != nil {
+			t.Fatalf("%s: %v", c.path, err)
+		}
+		if entry.Kind != c.kind || hex.EncodeToString(entry.CellKey) != c.cellKey || entry.Type != c.ty {
+			t.Errorf("%s: got %s 0x%x type %d", c.path, entry.Kind, entry.CellKey, entry.Type)
+		}
+	}
+
+	entry, _ := abi.StorageEntry("pod_version")
+	key, err := entry.Key([]byte{7, 0, 0, 0, 0, 0, 0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(key) != "02e9fa250700000000000000" {
+		t.Errorf("mapping key 0x%x", key)
+	}
+	if _, err := entry.Key(nil); err == nil {
+		t.Error("expect error of missing mapping key")
+	}
+
+	for _, path := range []string{"unknown", "pod_version.x", "mint_interval.x"} {
+		if _, err := abi.StorageEntry(path); err == nil {
+			t.Errorf("%s: expect error", path)
+		}
+	}
+}
+
+func TestStorageValue(t *testing.T) {
+	raw, err := os.ReadFile("../example/contracts/pod.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	abi, err := InitAbi(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// cloud_contract, pod_id, owner, balance, allowance
+	var cell []byte
+	cell = append(cell, bytes.Repeat([]byte{1}, 20)...)
+	cell = append(cell, 9, 0, 0, 0, 0, 0, 0, 0)
+	cell = append(cell, bytes.Repeat([]byte{2}, 20)...)
+	balance := make([]byte, 32)
+	balance[0] = 100
+	cell = append(cell, balance...)
+	cell = append(cell, 0)
+
+	entry, err := abi.StorageEntry("balance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := abi.StorageValue(entry, cell)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(value, balance) {
+		t.Errorf("balance 0x%x", value)
+	}
+
+	entry, _ = abi.StorageEntry("owner")
+	v, err := abi.StorageValue(entry, cell)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := abi.DecodeValueJSON(entry.Type, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != `"0x0202020202020202020202020202020202020202"` {
+		t.Errorf("owner %s", got)
+	}
+}
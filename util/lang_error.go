@@ -0,0 +1,57 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+// ink! 语言错误，合约无法处理调用时返回
+// ink! LangError, returned when the contract can not dispatch the call
+type LangError struct {
+	IsCouldNotReadInput bool
+}
+
+func (ty LangError) Encode(encoder scale.Encoder) (err error) {
+	if ty.IsCouldNotReadInput {
+		return encoder.PushByte(1)
+	}
+	return fmt.Errorf("Unrecognized variant")
+}
+
+func (ty *LangError) Decode(decoder scale.Decoder) (err error) {
+	variant, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+	switch variant {
+	case 1:
+		ty.IsCouldNotReadInput = true
+		return
+	default:
+		return fmt.Errorf("Unrecognized variant")
+	}
+}
+
+func (ty LangError) Error() string {
+	if ty.IsCouldNotReadInput {
+		return "LangError::CouldNotReadInput"
+	}
+	return "LangError::Unknown"
+}
+
+// 解码 MessageResult::Err(LangError) 形式的回滚数据
+// Decode revert data in form of MessageResult::Err(LangError)
+func DecodeLangError(data []byte) (*LangError, bool) {
+	if len(data) != 2 || data[0] != 1 {
+		return nil, false
+	}
+
+	lerr := &LangError{}
+	err := scale.NewDecoder(bytes.NewReader(data[1:])).Decode(lerr)
+	if err != nil {
+		return nil, false
+	}
+	return lerr, true
+}
@@ -20,6 +20,12 @@ func (r Result[T, Err]) UnWrap() (T, error) {
 	return r.V, nil
 }
 
+// 返回 Err 值，Ok 时返回 false
+// Err value of result, false is returned for Ok
+func (r Result[T, Err]) ErrValue() (any, bool) {
+	return r.E, r.IsErr
+}
+
 func (r Result[T, Err]) Encode(encoder scale.Encoder) (err error) {
 	if r.IsErr {
 		err = encoder.PushByte(1)
@@ -230,3 +230,26 @@ type ExecReturnValue struct {
 	// Field 1 with TypeId=14
 	Data []byte
 }
+
+// ReviveApi_get_storage 错误
+// Error of ReviveApi_get_storage, pallet_revive::ContractAccessError
+type ContractAccessError uint8
+
+const (
+	ContractDoesntExist ContractAccessError = iota
+	ContractKeyDecodingFailed
+)
+
+func (e ContractAccessError) Error() string {
+	switch e {
+	case ContractDoesntExist:
+		return "contract access error: DoesntExist"
+	case ContractKeyDecodingFailed:
+		return "contract access error: KeyDecodingFailed"
+	}
+	return fmt.Sprintf("contract access error: unknown %d", uint8(e))
+}
+
+// ReviveApi_get_storage 返回值
+// Result of ReviveApi_get_storage
+type GetStorageResult = Result[Option[[]byte], ContractAccessError]
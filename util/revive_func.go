@@ -1,6 +1,7 @@
 package util
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -94,6 +95,17 @@ func Keccak256Hash(data []byte) []byte {
 
 var eth = []byte{0xEE, 0xEE, 0xEE, 0xEE, 0xEE, 0xEE, 0xEE, 0xEE, 0xEE, 0xEE, 0xEE, 0xEE}
 
+// 账户是否由以太坊地址派生，即 H160 后接 12 个 0xEE
+// Whether the account is derived from Ethereum address, that is H160 followed by 12 bytes of 0xEE
 func IsEthDerived(account_bytes []byte) bool {
-	return hex.EncodeToString(account_bytes[:20]) == hex.EncodeToString(eth)
+	return len(account_bytes) == 32 && bytes.Equal(account_bytes[20:], eth)
+}
+
+// 以太坊地址在 pallet-revive 中对应的账户
+// Fallback account of Ethereum address in pallet-revive
+func AccountIDFromH160(address types.H160) types.AccountID {
+	var account types.AccountID
+	copy(account[:20], address[:])
+	copy(account[20:], eth)
+	return account
 }
@@ -15,3 +15,15 @@ func TestHexToH160(t *testing.T) {
 		t.Error(err2)
 	}
 }
+
+func TestAccountIDFromH160(t *testing.T) {
+	address, _ := HexToH160("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266")
+	account := AccountIDFromH160(address)
+	if !IsEthDerived(account[:]) {
+		t.Errorf("account %x is not eth derived", account)
+	}
+	h160, err := H160FromPublicKey(account[:])
+	if err != nil || h160 != address {
+		t.Errorf("H160FromPublicKey %x %v", h160, err)
+	}
+}